@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"strings"
+
+	"github.com/knative/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/archive"
+	"github.com/tektoncd/pipeline/pkg/s3"
+)
+
+var (
+	operation              = flag.String("operation", "", "Either upload or download")
+	name                   = flag.String("name", "", "Name of the PipelineResource the artifact is a copy of")
+	path                   = flag.String("path", "", "Path of the artifact in the artifact storage, reported with its checksum")
+	location               = flag.String("location", "", "The gs:// or s3:// location or the path the archive of the artifact is stored at, without extension")
+	dir                    = flag.String("dir", "", "Directory the artifact is copied from or to")
+	checksum               = flag.String("checksum", "", "Checksum the archive downloaded must have")
+	endpoint               = flag.String("endpoint", "", "Endpoint of the S3-compatible service, defaults to AWS")
+	region                 = flag.String("region", s3.DefaultRegion, "Region used to sign S3 requests")
+	pathStyle              = flag.Bool("path-style", false, "Address the S3 bucket in the URL path instead of the host name")
+	terminationMessagePath = flag.String("terminationMessagePath", "/dev/termination-log", "Path the checksum and size of the uploaded artifact are reported to as JSON")
+)
+
+func main() {
+	flag.Parse()
+	logger, _ := logging.NewLogger("", "artifactcopy")
+	defer logger.Sync()
+
+	if *operation != "upload" && *operation != "download" {
+		logger.Fatalf("Unknown operation %q, must be upload or download", *operation)
+	}
+	if *location == "" || *dir == "" {
+		logger.Fatal("The -location and -dir flags are required")
+	}
+
+	// The archive is stored in the parent of its location, named after
+	// the last element of the location.
+	i := strings.LastIndex(*location, "/")
+	if i <= 0 {
+		logger.Fatalf("Invalid artifact location %q", *location)
+	}
+	storeLocation, key := (*location)[:i], (*location)[i+1:]
+	if strings.HasPrefix(storeLocation, "gs://") {
+		if err := archive.ActivateServiceAccount(); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	store, err := archive.NewStore(storeLocation, s3.Config{
+		Endpoint:  *endpoint,
+		Region:    *region,
+		PathStyle: *pathStyle,
+	}.WithEnvCredentials())
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	if *operation == "download" {
+		if err := archive.GetDir(store, key, *dir, *checksum); err != nil {
+			logger.Fatalf("Error copying artifact %s to %s: %s", *location, *dir, err)
+		}
+		logger.Infof("Copied artifact %s to %s", *location, *dir)
+		return
+	}
+
+	sum, size, err := archive.PutDir(store, key, *dir)
+	if err != nil {
+		logger.Fatalf("Error copying %s to artifact %s: %s", *dir, *location, err)
+	}
+	logger.Infof("Copied %s to artifact %s of %d bytes with checksum %s", *dir, *location, size, sum)
+
+	// The controller reads the termination message of this container to
+	// record the checksum and size in the TaskRun status.
+	output, err := json.Marshal([]v1alpha1.ArtifactResult{{
+		Name:     *name,
+		Path:     *path,
+		Checksum: sum,
+		Size:     size,
+	}})
+	if err != nil {
+		logger.Fatalf("Error encoding the artifact checksum: %s", err)
+	}
+	if err := ioutil.WriteFile(*terminationMessagePath, output, 0644); err != nil {
+		logger.Warnf("Unable to report the artifact checksum to %s: %s", *terminationMessagePath, err)
+	}
+}
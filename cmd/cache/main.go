@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/knative/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/archive"
+	"github.com/tektoncd/pipeline/pkg/cache"
+	"github.com/tektoncd/pipeline/pkg/s3"
+)
+
+var (
+	operation = flag.String("operation", "", "Either restore or save")
+	name      = flag.String("name", "", "Name of the cache")
+	key       = flag.String("key", "", "Key the cache is saved under, in addition to the content of the key files")
+	keyFiles  = flag.String("key-files", "", "Comma separated glob patterns of the files whose content keys the cache")
+	paths     = flag.String("paths", "", "Comma separated directories to cache")
+	location  = flag.String("location", "", "The gs:// or s3:// location or the directory the caches are stored in")
+	endpoint  = flag.String("endpoint", "", "Endpoint of the S3-compatible service, defaults to AWS")
+	region    = flag.String("region", s3.DefaultRegion, "Region used to sign S3 requests")
+	pathStyle = flag.Bool("path-style", false, "Address the S3 bucket in the URL path instead of the host name")
+)
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func main() {
+	flag.Parse()
+	logger, _ := logging.NewLogger("", "cache")
+	defer logger.Sync()
+
+	if *operation != "restore" && *operation != "save" {
+		logger.Fatalf("Unknown operation %q, must be restore or save", *operation)
+	}
+	if *name == "" || *paths == "" || *location == "" {
+		logger.Fatal("The -name, -paths and -location flags are required")
+	}
+
+	// Caching only speeds up runs, so failing to restore or save a cache
+	// is logged without failing the step.
+	k, err := cache.Key(*name, *key, split(*keyFiles))
+	if err != nil {
+		logger.Warnf("Skipping %s of cache %s: error computing key: %s", *operation, *name, err)
+		return
+	}
+	if strings.HasPrefix(*location, "gs://") {
+		if err := archive.ActivateServiceAccount(); err != nil {
+			logger.Warnf("Skipping %s of cache %s: %s", *operation, *name, err)
+			return
+		}
+	}
+	store, err := archive.NewStore(*location, s3.Config{
+		Endpoint:  *endpoint,
+		Region:    *region,
+		PathStyle: *pathStyle,
+	}.WithEnvCredentials())
+	if err != nil {
+		logger.Warnf("Skipping %s of cache %s: %s", *operation, *name, err)
+		return
+	}
+
+	switch *operation {
+	case "restore":
+		found, err := cache.Restore(store, k, split(*paths))
+		switch {
+		case err != nil:
+			logger.Warnf("Error restoring cache %s: %s", k, err)
+		case found:
+			logger.Infof("Restored cache %s", k)
+		default:
+			logger.Infof("No cache stored for %s", k)
+		}
+	case "save":
+		saved, err := cache.Save(store, k, split(*paths))
+		switch {
+		case err != nil:
+			logger.Warnf("Error saving cache %s: %s", k, err)
+		case saved:
+			logger.Infof("Saved cache %s", k)
+		default:
+			logger.Infof("Cache %s is already stored", k)
+		}
+	}
+}
@@ -31,9 +31,12 @@ import (
 
 	sharedclientset "github.com/knative/pkg/client/clientset/versioned"
 	"github.com/knative/pkg/controller"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
+	"github.com/tektoncd/pipeline/pkg/pruner"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/system"
 
 	"github.com/knative/pkg/configmap"
@@ -45,12 +48,21 @@ import (
 const (
 	threadsPerController = 2
 	resyncPeriod         = 10 * time.Hour
+	// artifactSweepPeriod is how often the artifact storage kept for
+	// PipelineRuns is checked for expiration.
+	artifactSweepPeriod = time.Minute
+	// runPrunePeriod is how often completed runs are checked for deletion.
+	runPrunePeriod = time.Minute
 )
 
 var (
 	masterURL  string
 	kubeconfig string
 	namespace  string
+
+	entrypointCacheConfigMap string
+	entrypointOffline        bool
+	pinStepImages            bool
 )
 
 func main() {
@@ -103,26 +115,52 @@ func main() {
 	}
 
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, opt.ResyncPeriod, kubeinformers.WithNamespace(namespace))
+	// The informers of the controller's own namespace, which holds its
+	// ConfigMaps whatever namespace the controller is restricted to.
+	systemKubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, opt.ResyncPeriod, kubeinformers.WithNamespace(system.GetNamespace()))
 	pipelineInformerFactory := pipelineinformers.NewSharedInformerFactoryWithOptions(pipelineClient, opt.ResyncPeriod, pipelineinformers.WithNamespace(namespace))
 
 	taskInformer := pipelineInformerFactory.Tekton().V1alpha1().Tasks()
 	clusterTaskInformer := pipelineInformerFactory.Tekton().V1alpha1().ClusterTasks()
+	stepTemplateInformer := pipelineInformerFactory.Tekton().V1alpha1().StepTemplates()
+	clusterStepTemplateInformer := pipelineInformerFactory.Tekton().V1alpha1().ClusterStepTemplates()
+	resourceTypeInformer := pipelineInformerFactory.Tekton().V1alpha1().ResourceTypes()
 	taskRunInformer := pipelineInformerFactory.Tekton().V1alpha1().TaskRuns()
 	resourceInformer := pipelineInformerFactory.Tekton().V1alpha1().PipelineResources()
 	podInformer := kubeInformerFactory.Core().V1().Pods()
+	systemConfigMapInformer := systemKubeInformerFactory.Core().V1().ConfigMaps()
 
 	pipelineInformer := pipelineInformerFactory.Tekton().V1alpha1().Pipelines()
 	pipelineRunInformer := pipelineInformerFactory.Tekton().V1alpha1().PipelineRuns()
 	timeoutHandler := reconciler.NewTimeoutHandler(stopCh, logger)
 
+	var cacheOpts []entrypoint.CacheOption
+	if entrypointCacheConfigMap != "" {
+		store := entrypoint.NewConfigMapStore(kubeClient, systemConfigMapInformer.Lister(), system.GetNamespace(), entrypointCacheConfigMap, logger)
+		cacheOpts = append(cacheOpts, entrypoint.WithStore(store))
+	}
+	if entrypointOffline {
+		cacheOpts = append(cacheOpts, entrypoint.WithOfflineMode())
+	}
+	entrypointCache, err := entrypoint.NewCache(cacheOpts...)
+	if err != nil {
+		logger.Fatalf("Error creating entrypoint cache: %v", err)
+	}
+
+	cloudEventSender := taskrun.NewCloudEventSender(pipelineClient, logger)
 	trc := taskrun.NewController(opt,
 		taskRunInformer,
 		taskInformer,
 		clusterTaskInformer,
+		stepTemplateInformer,
+		clusterStepTemplateInformer,
 		resourceInformer,
+		resourceTypeInformer,
 		podInformer,
-		nil, //entrypoint cache will be initialized by controller if not provided
+		entrypointCache,
+		pinStepImages,
 		timeoutHandler,
+		cloudEventSender,
 	)
 	prc := pipelinerun.NewController(opt,
 		pipelineRunInformer,
@@ -147,6 +185,7 @@ func main() {
 	configMapWatcher.Watch(logging.ConfigName, logging.UpdateLevelFromConfigMap(logger, atomicLevel, logging.ControllerLogKey))
 
 	kubeInformerFactory.Start(stopCh)
+	systemKubeInformerFactory.Start(stopCh)
 	pipelineInformerFactory.Start(stopCh)
 	if err := configMapWatcher.Start(stopCh); err != nil {
 		logger.Fatalf("failed to start configuration manager: %v", err)
@@ -157,9 +196,13 @@ func main() {
 	for i, synced := range []cache.InformerSynced{
 		taskInformer.Informer().HasSynced,
 		clusterTaskInformer.Informer().HasSynced,
+		stepTemplateInformer.Informer().HasSynced,
+		clusterStepTemplateInformer.Informer().HasSynced,
+		resourceTypeInformer.Informer().HasSynced,
 		taskRunInformer.Informer().HasSynced,
 		resourceInformer.Informer().HasSynced,
 		podInformer.Informer().HasSynced,
+		systemConfigMapInformer.Informer().HasSynced,
 	} {
 		if ok := cache.WaitForCacheSync(stopCh, synced); !ok {
 			logger.Fatalf("failed to wait for cache at index %v to sync", i)
@@ -178,6 +221,16 @@ func main() {
 		}(ctrlr)
 	}
 
+	// Deliver the CloudEvents of the finished TaskRuns.
+	go cloudEventSender.Run(threadsPerController, stopCh)
+
+	// Delete the artifact storage kept by the retention policy once it expires.
+	go artifacts.NewSweeper(kubeClient, pipelineRunInformer.Lister(), logger).Run(artifactSweepPeriod, stopCh)
+
+	// Delete the completed runs that outlived their retention.
+	go pruner.NewPruner(kubeClient, pipelineClient, pipelineRunInformer.Lister(), taskRunInformer.Lister(),
+		pipelineInformer.Lister(), logger).Run(runPrunePeriod, stopCh)
+
 	<-stopCh
 }
 
@@ -185,4 +238,7 @@ func init() {
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 	flag.StringVar(&namespace, "namespace", corev1.NamespaceAll, "Namespace to restrict informer to. Optional, defaults to all namespaces.")
+	flag.StringVar(&entrypointCacheConfigMap, "entrypoint-cache-configmap", "entrypoint-cache", "Name of the ConfigMap in the controller's namespace which persists the entrypoints of images referenced by digest. Optional, set to empty to disable.")
+	flag.BoolVar(&entrypointOffline, "entrypoint-offline", false, "Never look up image entrypoints in registries; steps must specify a command or use an image whose entrypoint is cached.")
+	flag.BoolVar(&pinStepImages, "pin-step-images", false, "Resolve the images of TaskRun steps to digests before creating their pods, and reuse them when retrying.")
 }
@@ -32,19 +32,49 @@ var (
 	ep       = flag.String("entrypoint", "", "Original specified entrypoint to execute")
 	waitFile = flag.String("wait_file", "", "If specified, file to wait for")
 	postFile = flag.String("post_file", "", "If specified, file to write upon completion")
+
+	breakpoint          = flag.Bool("breakpoint", false, "If specified, pause after the command completes")
+	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, pause after the command fails")
+	debugTimeout        = flag.Duration("debug_timeout", time.Hour, "How long to stay paused at a breakpoint before resuming")
+	paused              = flag.Bool("paused", false, "If specified, only check whether the step writing post_file is paused at a breakpoint")
+	resume              = flag.Bool("continue", false, "If specified, only resume the step writing post_file from its breakpoint")
+)
+
+// Suffixes appended to the post file of a step to coordinate breakpoints.
+const (
+	breakpointSuffix = ".breakpoint"
+	continueSuffix   = ".continue"
 )
 
 func main() {
 	flag.Parse()
 
+	// -paused and -continue are invoked through `kubectl exec` (or a
+	// readiness probe) against a step that is already running.
+	if *paused {
+		if _, err := os.Stat(*postFile + breakpointSuffix); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *resume {
+		if _, err := os.Create(*postFile + continueSuffix); err != nil {
+			log.Fatalf("Creating %q: %v", *postFile+continueSuffix, err)
+		}
+		os.Exit(0)
+	}
+
 	e := entrypoint.Entrypointer{
-		Entrypoint: *ep,
-		WaitFile:   *waitFile,
-		PostFile:   *postFile,
-		Args:       flag.Args(),
-		Waiter:     &RealWaiter{},
-		Runner:     &RealRunner{},
-		PostWriter: &RealPostWriter{},
+		Entrypoint:          *ep,
+		WaitFile:            *waitFile,
+		PostFile:            *postFile,
+		Args:                flag.Args(),
+		Breakpoint:          *breakpoint,
+		BreakpointOnFailure: *breakpointOnFailure,
+		Waiter:              &RealWaiter{},
+		Runner:              &RealRunner{},
+		PostWriter:          &RealPostWriter{},
+		Debugger:            &RealDebugger{Timeout: *debugTimeout},
 	}
 	if err := e.Go(); err != nil {
 		switch err.(type) {
@@ -128,6 +158,34 @@ func (*RealPostWriter) Write(file string) {
 	}
 }
 
+// RealDebugger pauses by writing a breakpoint file next to the post file and
+// polling for the continue file, giving up once Timeout has elapsed.
+type RealDebugger struct {
+	Timeout time.Duration
+}
+
+var _ entrypoint.Debugger = (*RealDebugger)(nil)
+
+func (d *RealDebugger) Pause(file string) error {
+	bp, cont := file+breakpointSuffix, file+continueSuffix
+	if _, err := os.Create(bp); err != nil {
+		return xerrors.Errorf("Creating %q: %w", bp, err)
+	}
+	defer os.Remove(bp)
+	log.Printf("Paused at breakpoint for up to %s; run `%s -continue -post_file %s` to resume", d.Timeout, os.Args[0], file)
+
+	deadline := time.Now().Add(d.Timeout)
+	for ; time.Now().Before(deadline); time.Sleep(time.Second) {
+		if _, err := os.Stat(cont); err == nil {
+			return nil
+		} else if !os.IsNotExist(err) {
+			return xerrors.Errorf("Waiting for %q: %w", cont, err)
+		}
+	}
+	log.Printf("Debug timeout of %s elapsed, resuming", d.Timeout)
+	return nil
+}
+
 type skipError string
 
 func (e skipError) Error() string {
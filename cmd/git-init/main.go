@@ -16,16 +16,34 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"flag"
+	"io/ioutil"
+	"strings"
 
 	"github.com/knative/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/git"
+	"go.uber.org/zap"
 )
 
 var (
-	url      = flag.String("url", "", "The url of the Git repository to initialize.")
-	revision = flag.String("revision", "", "The Git revision to make the repository HEAD")
-	path     = flag.String("path", "", "Path of directory under which git repository will be copied")
+	url                       = flag.String("url", "", "The url of the Git repository to initialize.")
+	revision                  = flag.String("revision", "", "The Git revision to make the repository HEAD")
+	path                      = flag.String("path", "", "Path of directory under which git repository will be copied")
+	refspec                   = flag.String("refspec", "", "Space separated refspecs to fetch, the revision is then checked out from them")
+	depth                     = flag.Uint("depth", 1, "Depth of the history to fetch, 0 fetches the full history")
+	submodules                = flag.Bool("submodules", false, "Fetch and check out submodules")
+	sparseCheckoutDirectories = flag.String("sparseCheckoutDirectories", "", "Comma separated directories to check out instead of the whole tree")
+	sslVerify                 = flag.Bool("sslVerify", true, "Verify the certificate of the Git server")
+	name                      = flag.String("name", "", "Name of the PipelineResource the commit is reported for")
+	terminationMessagePath    = flag.String("terminationMessagePath", "/dev/termination-log", "Path the fetched commit is reported to as JSON")
+	mode                      = flag.String("mode", "download", "Whether to download the repository to path or upload the changes in path to branch")
+	branch                    = flag.String("branch", "", "The branch the changes are pushed to when uploading")
+	message                   = flag.String("message", "Update from Tekton", "The message of the commit pushed when uploading")
+	authorName                = flag.String("authorName", "Tekton", "The name of the author of the commit pushed when uploading")
+	authorEmail               = flag.String("authorEmail", "tekton@tekton.dev", "The email of the author of the commit pushed when uploading")
+	force                     = flag.Bool("force", false, "Overwrite the branch instead of requiring the push to fast-forward it")
 )
 
 func main() {
@@ -33,7 +51,61 @@ func main() {
 	logger, _ := logging.NewLogger("", "git-init")
 	defer logger.Sync()
 
-	if err := git.Fetch(logger, *revision, *path, *url); err != nil {
+	switch *mode {
+	case "download":
+		download(logger)
+	case "upload":
+		spec := git.PushSpec{
+			URL:         *url,
+			Branch:      *branch,
+			Path:        *path,
+			Message:     *message,
+			AuthorName:  *authorName,
+			AuthorEmail: *authorEmail,
+			Force:       *force,
+			SSLVerify:   *sslVerify,
+		}
+		if err := git.Push(logger, spec); err != nil {
+			logger.Fatalf("Error pushing to git repository: %s", err)
+		}
+	default:
+		logger.Fatalf("Unknown mode %q, expected download or upload", *mode)
+	}
+}
+
+func download(logger *zap.SugaredLogger) {
+	spec := git.FetchSpec{
+		URL:        *url,
+		Revision:   *revision,
+		Refspec:    *refspec,
+		Path:       *path,
+		Depth:      *depth,
+		Submodules: *submodules,
+		SSLVerify:  *sslVerify,
+	}
+	if *sparseCheckoutDirectories != "" {
+		spec.SparseCheckoutDirectories = strings.Split(*sparseCheckoutDirectories, ",")
+	}
+	if err := git.Fetch(logger, spec); err != nil {
 		logger.Fatalf("Error fetching git repository: %s", err)
 	}
+
+	commit, err := git.HeadCommit(logger)
+	if err != nil {
+		logger.Fatalf("Error resolving the fetched commit: %s", err)
+	}
+	// The controller reads the termination message of this container to
+	// record the commit in the TaskRun status.
+	output, err := json.Marshal([]v1alpha1.PipelineResourceResult{{
+		Name:   *name,
+		Commit: commit,
+		Ref:    *revision,
+		URL:    *url,
+	}})
+	if err != nil {
+		logger.Fatalf("Error encoding the fetched commit: %s", err)
+	}
+	if err := ioutil.WriteFile(*terminationMessagePath, output, 0644); err != nil {
+		logger.Warnf("Unable to report the fetched commit to %s: %s", *terminationMessagePath, err)
+	}
 }
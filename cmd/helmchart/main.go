@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/knative/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/helmchart"
+)
+
+var (
+	operation = flag.String("operation", "", "Either fetch or upload")
+	url       = flag.String("url", "", "The URL of the chart repository, or the oci:// URL of the registry namespace of the chart")
+	name      = flag.String("name", "", "The name of the chart")
+	version   = flag.String("version", "", "The version of the chart to fetch or upload")
+	path      = flag.String("path", "", "Local directory to fetch the chart into or upload it from")
+)
+
+func main() {
+	flag.Parse()
+	logger, _ := logging.NewLogger("", "helmchart")
+	defer logger.Sync()
+
+	spec := helmchart.Spec{
+		URL:      *url,
+		Name:     *name,
+		Version:  *version,
+		Path:     *path,
+		Username: os.Getenv(helmchart.UsernameEnvVar),
+		Password: os.Getenv(helmchart.PasswordEnvVar),
+	}
+
+	switch *operation {
+	case "fetch":
+		if err := helmchart.Fetch(http.DefaultClient, spec); err != nil {
+			logger.Fatalf("Error fetching chart %s %s from %s: %s", *name, *version, *url, err)
+		}
+	case "upload":
+		digest, err := helmchart.Upload(http.DefaultClient, spec)
+		if err != nil {
+			logger.Fatalf("Error uploading chart %s %s to %s: %s", *name, *version, *url, err)
+		}
+		logger.Infof("Uploaded chart %s %s to %s, digest %s", *name, *version, *url, digest)
+	default:
+		logger.Fatalf("Unknown operation %q, must be fetch or upload", *operation)
+	}
+}
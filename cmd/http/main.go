@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/knative/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/httpresource"
+)
+
+var (
+	operation = flag.String("operation", "", "Either download or upload")
+	url       = flag.String("url", "", "The URL to download from or upload to")
+	path      = flag.String("path", "", "Local directory to download into or upload from")
+	format    = flag.String("format", "", "One of file, tar, tar.gz or zip, detected from the URL if empty")
+	checksum  = flag.String("checksum", "", "The <algorithm>:<hex digest> the download must match, sha256 or sha512")
+)
+
+func main() {
+	flag.Parse()
+	logger, _ := logging.NewLogger("", "http")
+	defer logger.Sync()
+
+	c, err := httpresource.ParseChecksum(*checksum)
+	if err != nil {
+		logger.Fatalf("Error parsing checksum: %s", err)
+	}
+	spec := httpresource.Spec{
+		URL:           *url,
+		Format:        httpresource.Format(*format),
+		Checksum:      c,
+		Path:          *path,
+		Authorization: os.Getenv(httpresource.AuthorizationEnvVar),
+	}
+	if spec.Format == "" {
+		spec.Format = httpresource.DetectFormat(*url)
+	}
+
+	switch *operation {
+	case "download":
+		err = httpresource.Download(http.DefaultClient, spec)
+	case "upload":
+		err = httpresource.Upload(http.DefaultClient, spec)
+	default:
+		logger.Fatalf("Unknown operation %q, must be download or upload", *operation)
+	}
+	if err != nil {
+		logger.Fatalf("Error running %s of %s: %s", *operation, *url, err)
+	}
+}
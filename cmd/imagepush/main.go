@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/knative/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/imagepush"
+)
+
+var (
+	name                   = flag.String("name", "", "Name of the PipelineResource the digest is reported for")
+	url                    = flag.String("url", "", "The image reference to push to")
+	path                   = flag.String("path", "", "Path of the OCI image layout to push")
+	terminationMessagePath = flag.String("terminationMessagePath", "/dev/termination-log", "Path the pushed digest is reported to as JSON")
+)
+
+func main() {
+	flag.Parse()
+	logger, _ := logging.NewLogger("", "imagepush")
+	defer logger.Sync()
+
+	// The registry credentials of the service account are written to
+	// $HOME/.docker/config.json by creds-init.
+	digest, err := imagepush.Push(*path, *url, authn.DefaultKeychain)
+	if err != nil {
+		logger.Fatalf("Error pushing %s to %s: %s", *path, *url, err)
+	}
+	logger.Infof("Pushed %s@%s", *url, digest)
+
+	// The controller reads the termination message of this container to
+	// record the digest in the TaskRun status.
+	output, err := json.Marshal([]v1alpha1.PipelineResourceResult{{
+		Name:   *name,
+		Digest: digest.String(),
+	}})
+	if err != nil {
+		logger.Fatalf("Error encoding the pushed digest: %s", err)
+	}
+	if err := ioutil.WriteFile(*terminationMessagePath, output, 0644); err != nil {
+		logger.Warnf("Unable to report the pushed digest to %s: %s", *terminationMessagePath, err)
+	}
+}
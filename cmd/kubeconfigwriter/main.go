@@ -25,9 +25,9 @@ import (
 
 	"go.uber.org/zap"
 	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/kubeconfig"
 	"github.com/tektoncd/pipeline/pkg/logging"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 )
@@ -51,13 +51,8 @@ func main() {
 }
 
 func createKubeconfigFile(resource *v1alpha1.ClusterResource, logger *zap.SugaredLogger) {
-	cluster := &clientcmdapi.Cluster{
-		Server:                   resource.URL,
-		InsecureSkipTLSVerify:    resource.Insecure,
-		CertificateAuthorityData: resource.CAData,
-	}
 	if caFromEnv := os.Getenv("CADATA"); caFromEnv != "" {
-		cluster.CertificateAuthorityData = []byte(caFromEnv)
+		resource.CAData = []byte(caFromEnv)
 	}
 	if tokenFromEnv := os.Getenv("TOKEN"); tokenFromEnv != "" {
 		resource.Token = strings.TrimRight(tokenFromEnv, "\r\n")
@@ -68,29 +63,13 @@ func createKubeconfigFile(resource *v1alpha1.ClusterResource, logger *zap.Sugare
 	if passwordFromEnv := os.Getenv("PASSWORD"); passwordFromEnv != "" {
 		resource.Password = passwordFromEnv
 	}
-	//only one authentication technique per user is allowed in a kubeconfig, so clear out the password if a token is provided
-	user := resource.Username
-	pass := resource.Password
-	if resource.Token != "" {
-		user = ""
-		pass = ""
-	}
-	auth := &clientcmdapi.AuthInfo{
-		Token:    resource.Token,
-		Username: user,
-		Password: pass,
+	if certFromEnv := os.Getenv("CLIENTCERTIFICATEDATA"); certFromEnv != "" {
+		resource.ClientCertificateData = []byte(certFromEnv)
 	}
-	context := &clientcmdapi.Context{
-		Cluster:  resource.Name,
-		AuthInfo: resource.Username,
+	if keyFromEnv := os.Getenv("CLIENTKEYDATA"); keyFromEnv != "" {
+		resource.ClientKeyData = []byte(keyFromEnv)
 	}
-	c := clientcmdapi.NewConfig()
-	c.Clusters[resource.Name] = cluster
-	c.AuthInfos[resource.Username] = auth
-	c.Contexts[resource.Name] = context
-	c.CurrentContext = resource.Name
-	c.APIVersion = "v1"
-	c.Kind = "Config"
+	c := kubeconfig.Build(resource)
 
 	destinationFile := fmt.Sprintf("/workspace/%s/kubeconfig", resource.Name)
 	if err := clientcmd.WriteToFile(*c, destinationFile); err != nil {
@@ -0,0 +1,58 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/knative/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/pullrequest"
+)
+
+var (
+	prURL    = flag.String("url", "", "The URL of the pull request")
+	path     = flag.String("path", "", "Directory the pull request is downloaded to or uploaded from")
+	mode     = flag.String("mode", "download", "Either download or upload")
+	provider = flag.String("provider", "", "The SCM provider, guessed from the URL if empty")
+	apiURL   = flag.String("api-url", "", "The API endpoint of the provider, for self-hosted installations")
+)
+
+func main() {
+	flag.Parse()
+	logger, _ := logging.NewLogger("", "pullrequest-init")
+	defer logger.Sync()
+
+	p, err := pullrequest.NewProvider(*provider, *prURL, *apiURL, os.Getenv(pullrequest.TokenEnvVar))
+	if err != nil {
+		logger.Fatalf("Error creating provider for %s: %s", *prURL, err)
+	}
+
+	ctx := context.Background()
+	switch *mode {
+	case "download":
+		err = pullrequest.Download(ctx, p, *path)
+	case "upload":
+		err = pullrequest.Upload(ctx, p, *path)
+	default:
+		logger.Fatalf("Unknown mode %q, must be download or upload", *mode)
+	}
+	if err != nil {
+		logger.Fatalf("Error running %s of %s: %s", *mode, *prURL, err)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+
+	"github.com/knative/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/s3"
+)
+
+var (
+	operation = flag.String("operation", "", "One of download, upload or delete")
+	location  = flag.String("location", "", "The s3://bucket/key location of the object or prefix")
+	path      = flag.String("path", "", "Local directory to download into or upload from")
+	endpoint  = flag.String("endpoint", "", "Endpoint of the S3-compatible service, defaults to AWS")
+	region    = flag.String("region", s3.DefaultRegion, "Region used to sign requests")
+	pathStyle = flag.Bool("path-style", false, "Address the bucket in the URL path instead of the host name")
+	dir       = flag.Bool("dir", false, "Treat the location as a prefix and copy every object under it")
+)
+
+func main() {
+	flag.Parse()
+	logger, _ := logging.NewLogger("", "s3")
+	defer logger.Sync()
+
+	bucket, key, err := s3.ParseLocation(*location)
+	if err != nil {
+		logger.Fatalf("Error parsing location: %s", err)
+	}
+	c, err := s3.NewClient(s3.Config{
+		Endpoint:  *endpoint,
+		Region:    *region,
+		Bucket:    bucket,
+		PathStyle: *pathStyle,
+	}.WithEnvCredentials())
+	if err != nil {
+		logger.Fatalf("Error creating S3 client: %s", err)
+	}
+
+	switch *operation {
+	case "download":
+		err = s3.Download(c, key, *path, *dir)
+	case "upload":
+		err = s3.Upload(c, *path, key, *dir)
+	case "delete":
+		err = s3.Delete(c, key, *dir)
+	default:
+		logger.Fatalf("Unknown operation %q, must be download, upload or delete", *operation)
+	}
+	if err != nil {
+		logger.Fatalf("Error running %s of %s: %s", *operation, *location, err)
+	}
+}
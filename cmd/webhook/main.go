@@ -83,11 +83,14 @@ func main() {
 		Client:  kubeClient,
 		Options: options,
 		Handlers: map[schema.GroupVersionKind]webhook.GenericCRD{
-			v1alpha1.SchemeGroupVersion.WithKind("Pipeline"):         &v1alpha1.Pipeline{},
-			v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"): &v1alpha1.PipelineResource{},
-			v1alpha1.SchemeGroupVersion.WithKind("Task"):             &v1alpha1.Task{},
-			v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):          &v1alpha1.TaskRun{},
-			v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):      &v1alpha1.PipelineRun{},
+			v1alpha1.SchemeGroupVersion.WithKind("Pipeline"):            &v1alpha1.Pipeline{},
+			v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"):    &v1alpha1.PipelineResource{},
+			v1alpha1.SchemeGroupVersion.WithKind("Task"):                &v1alpha1.Task{},
+			v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):             &v1alpha1.TaskRun{},
+			v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):         &v1alpha1.PipelineRun{},
+			v1alpha1.SchemeGroupVersion.WithKind("StepTemplate"):        &v1alpha1.StepTemplate{},
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterStepTemplate"): &v1alpha1.ClusterStepTemplate{},
+			v1alpha1.SchemeGroupVersion.WithKind("ResourceType"):        &v1alpha1.ResourceType{},
 		},
 		Logger: logger,
 	}
@@ -76,36 +76,33 @@ func (b *ArtifactBucket) StorageBasePath(pr *PipelineRun) string {
 }
 
 // GetCopyFromStorageToContainerSpec returns a container used to download artifacts from temporary storage
-func (b *ArtifactBucket) GetCopyFromStorageToContainerSpec(name, sourcePath, destinationPath string) []corev1.Container {
-	args := []string{"-args", fmt.Sprintf("cp -r %s %s", fmt.Sprintf("%s/%s/*", b.Location, sourcePath), destinationPath)}
-
-	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
-
-	return []corev1.Container{{
-		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-dest-mkdir-%s", name)),
-		Image:   *BashNoopImage,
-		Command: []string{"/ko-app/bash"},
-		Args: []string{
-			"-args", strings.Join([]string{"mkdir", "-p", destinationPath}, " "),
-		},
-	}, {
-		Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-from-%s", name)),
-		Image:        *gsutilImage,
-		Command:      []string{"/ko-app/gsutil"},
-		Args:         args,
-		Env:          envVars,
-		VolumeMounts: secretVolumeMount,
-	}}
+func (b *ArtifactBucket) GetCopyFromStorageToContainerSpec(name, sourcePath, destinationPath, checksum string) []corev1.Container {
+	c := artifactCopyFromContainer(name, sourcePath, b.location(sourcePath), destinationPath, checksum)
+	c.Env, c.VolumeMounts = getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
+	return []corev1.Container{c}
 }
 
 // GetCopyToStorageFromContainerSpec returns a container used to upload artifacts for temporary storage
 func (b *ArtifactBucket) GetCopyToStorageFromContainerSpec(name, sourcePath, destinationPath string) []corev1.Container {
-	args := []string{"-args", fmt.Sprintf("cp -r %s %s", sourcePath, fmt.Sprintf("%s/%s", b.Location, destinationPath))}
+	c := artifactCopyToContainer(name, destinationPath, b.location(destinationPath), sourcePath)
+	c.Env, c.VolumeMounts = getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
+	return []corev1.Container{c}
+}
+
+// location returns the location of the artifact stored at path in the bucket
+func (b *ArtifactBucket) location(path string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(b.Location, "/"), path)
+}
+
+// GetDeleteContainerSpec returns a container used to delete the artifacts
+// stored under path
+func (b *ArtifactBucket) GetDeleteContainerSpec(path string) []corev1.Container {
+	args := []string{"-args", fmt.Sprintf("-m rm -r -f %s", b.location(path))}
 
 	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
 
 	return []corev1.Container{{
-		Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-to-%s", name)),
+		Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("artifact-delete"),
 		Image:        *gsutilImage,
 		Command:      []string{"/ko-app/gsutil"},
 		Args:         args,
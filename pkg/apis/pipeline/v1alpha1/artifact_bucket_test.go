@@ -46,20 +46,16 @@ func TestBucketGetCopyFromContainerSpec(t *testing.T) {
 	names.TestingSeed()
 
 	want := []corev1.Container{{
-		Name:    "artifact-dest-mkdir-workspace-9l9zj",
-		Image:   "override-with-bash-noop:latest",
-		Command: []string{"/ko-app/bash"},
-		Args:    []string{"-args", "mkdir -p /workspace/destination"},
-	}, {
-		Name:         "artifact-copy-from-workspace-mz4c7",
-		Image:        "override-with-gsutil-image:latest",
-		Command:      []string{"/ko-app/gsutil"},
-		Args:         []string{"-args", "cp -r gs://fake-bucket/src-path/* /workspace/destination"},
+		Name:    "artifact-copy-from-workspace-9l9zj",
+		Image:   "override-with-artifactcopy-image:latest",
+		Command: []string{"/ko-app/artifactcopy"},
+		Args: []string{"-operation", "download", "-name", "workspace", "-path", "src-path",
+			"-location", "gs://fake-bucket/src-path", "-dir", "/workspace/destination", "-checksum", "sha256:abc"},
 		Env:          []corev1.EnvVar{{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: fmt.Sprintf("/var/bucketsecret/%s/serviceaccount", secretName)}},
 		VolumeMounts: []corev1.VolumeMount{{Name: expectedVolumeName, MountPath: fmt.Sprintf("/var/bucketsecret/%s", secretName)}},
 	}}
 
-	got := bucket.GetCopyFromStorageToContainerSpec("workspace", "src-path", "/workspace/destination")
+	got := bucket.GetCopyFromStorageToContainerSpec("workspace", "src-path", "/workspace/destination", "sha256:abc")
 	if d := cmp.Diff(got, want); d != "" {
 		t.Errorf("Diff:\n%s", d)
 	}
@@ -68,10 +64,11 @@ func TestBucketGetCopyFromContainerSpec(t *testing.T) {
 func TestBucketGetCopyToContainerSpec(t *testing.T) {
 	names.TestingSeed()
 	want := []corev1.Container{{
-		Name:         "artifact-copy-to-workspace-9l9zj",
-		Image:        "override-with-gsutil-image:latest",
-		Command:      []string{"/ko-app/gsutil"},
-		Args:         []string{"-args", "cp -r src-path gs://fake-bucket/workspace/destination"},
+		Name:    "artifact-copy-to-workspace-9l9zj",
+		Image:   "override-with-artifactcopy-image:latest",
+		Command: []string{"/ko-app/artifactcopy"},
+		Args: []string{"-operation", "upload", "-name", "workspace", "-path", "workspace/destination",
+			"-location", "gs://fake-bucket/workspace/destination", "-dir", "src-path"},
 		Env:          []corev1.EnvVar{{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: fmt.Sprintf("/var/bucketsecret/%s/serviceaccount", secretName)}},
 		VolumeMounts: []corev1.VolumeMount{{Name: expectedVolumeName, MountPath: fmt.Sprintf("/var/bucketsecret/%s", secretName)}},
 	}}
@@ -82,6 +79,22 @@ func TestBucketGetCopyToContainerSpec(t *testing.T) {
 	}
 }
 
+func TestBucketGetDeleteContainerSpec(t *testing.T) {
+	names.TestingSeed()
+	want := []corev1.Container{{
+		Name:         "artifact-delete-9l9zj",
+		Image:        "override-with-gsutil-image:latest",
+		Command:      []string{"/ko-app/gsutil"},
+		Args:         []string{"-args", "-m rm -r -f gs://fake-bucket/pr-ns-bucket"},
+		Env:          []corev1.EnvVar{{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: fmt.Sprintf("/var/bucketsecret/%s/serviceaccount", secretName)}},
+		VolumeMounts: []corev1.VolumeMount{{Name: expectedVolumeName, MountPath: fmt.Sprintf("/var/bucketsecret/%s", secretName)}},
+	}}
+	got := bucket.GetDeleteContainerSpec("pr-ns-bucket")
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
 func TestGetSecretsVolumes(t *testing.T) {
 	names.TestingSeed()
 	want := []corev1.Volume{{
@@ -28,6 +28,8 @@ import (
 var (
 	pvcDir        = "/pvc"
 	BashNoopImage = flag.String("bash-noop-image", "override-with-bash-noop:latest", "The container image containing bash shell")
+
+	artifactCopyImage = flag.String("artifactcopy-image", "override-with-artifactcopy-image:latest", "The container image containing our artifact copy helper binary")
 )
 
 // ArtifactPVC represents the pvc created by the pipelinerun
@@ -35,6 +37,12 @@ var (
 type ArtifactPVC struct {
 	Name                  string
 	PersistentVolumeClaim *corev1.PersistentVolumeClaim
+
+	// AccessMode is the access mode of the PVC, ReadWriteOnce if unset.
+	AccessMode corev1.PersistentVolumeAccessMode
+	// MountDirect is whether the artifacts are mounted from the PVC at the
+	// paths of the resources instead of being copied to and from it.
+	MountDirect bool
 }
 
 // GetType returns the type of the artifact storage
@@ -47,36 +55,62 @@ func (p *ArtifactPVC) StorageBasePath(pr *PipelineRun) string {
 	return pvcDir
 }
 
+// NeedsCoScheduling returns whether the pods of the TaskRuns of a
+// pipelinerun must run on the same node to mount the PVC directly.
+func (p *ArtifactPVC) NeedsCoScheduling() bool {
+	return p.MountDirect && p.AccessMode != corev1.ReadWriteMany
+}
+
 // GetCopyFromStorageToContainerSpec returns a container used to download artifacts from temporary storage
-func (p *ArtifactPVC) GetCopyFromStorageToContainerSpec(name, sourcePath, destinationPath string) []corev1.Container {
-	return []corev1.Container{{
-		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("source-copy-%s", name)),
-		Image:   *BashNoopImage,
-		Command: []string{"/ko-app/bash"},
-		Args:    []string{"-args", strings.Join([]string{"cp", "-r", fmt.Sprintf("%s/.", sourcePath), destinationPath}, " ")},
-	}}
+func (p *ArtifactPVC) GetCopyFromStorageToContainerSpec(name, sourcePath, destinationPath, checksum string) []corev1.Container {
+	if p.MountDirect {
+		// The outputs were written to the pvc directly rather than archived,
+		// so their directory tree is copied.
+		return []corev1.Container{{
+			Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("source-copy-%s", name)),
+			Image:   *BashNoopImage,
+			Command: []string{"/ko-app/bash"},
+			Args:    []string{"-args", strings.Join([]string{"cp", "-r", fmt.Sprintf("%s/.", sourcePath), destinationPath}, " ")},
+		}}
+	}
+	return []corev1.Container{
+		artifactCopyFromContainer(name, sourcePath, sourcePath, destinationPath, checksum),
+	}
 }
 
 // GetCopyToStorageFromContainerSpec returns a container used to upload artifacts for temporary storage
 func (p *ArtifactPVC) GetCopyToStorageFromContainerSpec(name, sourcePath, destinationPath string) []corev1.Container {
-	return []corev1.Container{{
-		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("source-mkdir-%s", name)),
-		Image:   *BashNoopImage,
-		Command: []string{"/ko-app/bash"},
-		Args: []string{
+	c := artifactCopyToContainer(name, destinationPath, destinationPath, sourcePath)
+	c.VolumeMounts = []corev1.VolumeMount{getPvcMount(p.Name)}
+	return []corev1.Container{c}
+}
 
-			"-args", strings.Join([]string{"mkdir", "-p", destinationPath}, " "),
-		},
-		VolumeMounts: []corev1.VolumeMount{getPvcMount(p.Name)},
-	}, {
-		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("source-copy-%s", name)),
-		Image:   *BashNoopImage,
-		Command: []string{"/ko-app/bash"},
-		Args: []string{
-			"-args", strings.Join([]string{"cp", "-r", fmt.Sprintf("%s/.", sourcePath), destinationPath}, " "),
-		},
-		VolumeMounts: []corev1.VolumeMount{getPvcMount(p.Name)},
-	}}
+// artifactCopyFromContainer returns the container extracting the archive
+// stored at location into dir, once its checksum is verified. path is the
+// path of the artifact in the artifact storage.
+func artifactCopyFromContainer(name, path, location, dir, checksum string) corev1.Container {
+	args := []string{"-operation", "download", "-name", name, "-path", path, "-location", location, "-dir", dir}
+	if checksum != "" {
+		args = append(args, "-checksum", checksum)
+	}
+	return corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-from-%s", name)),
+		Image:   *artifactCopyImage,
+		Command: []string{"/ko-app/artifactcopy"},
+		Args:    args,
+	}
+}
+
+// artifactCopyToContainer returns the container storing an archive of dir at
+// location. It reports the checksum of the archive in its termination message
+// for the TaskRun status.
+func artifactCopyToContainer(name, path, location, dir string) corev1.Container {
+	return corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-to-%s", name)),
+		Image:   *artifactCopyImage,
+		Command: []string{"/ko-app/artifactcopy"},
+		Args:    []string{"-operation", "upload", "-name", name, "-path", path, "-location", location, "-dir", dir},
+	}
 }
 
 func getPvcMount(name string) corev1.VolumeMount {
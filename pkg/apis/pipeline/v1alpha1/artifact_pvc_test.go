@@ -32,13 +32,14 @@ func TestPVCGetCopyFromContainerSpec(t *testing.T) {
 		Name: "pipelinerun-pvc",
 	}
 	want := []corev1.Container{{
-		Name:    "source-copy-workspace-9l9zj",
-		Image:   "override-with-bash-noop:latest",
-		Command: []string{"/ko-app/bash"},
-		Args:    []string{"-args", "cp -r src-path/. /workspace/destination"},
+		Name:    "artifact-copy-from-workspace-9l9zj",
+		Image:   "override-with-artifactcopy-image:latest",
+		Command: []string{"/ko-app/artifactcopy"},
+		Args: []string{"-operation", "download", "-name", "workspace", "-path", "/pvc/task/workspace",
+			"-location", "/pvc/task/workspace", "-dir", "/workspace/destination", "-checksum", "sha256:abc"},
 	}}
 
-	got := pvc.GetCopyFromStorageToContainerSpec("workspace", "src-path", "/workspace/destination")
+	got := pvc.GetCopyFromStorageToContainerSpec("workspace", "/pvc/task/workspace", "/workspace/destination", "sha256:abc")
 	if d := cmp.Diff(got, want); d != "" {
 		t.Errorf("Diff:\n%s", d)
 	}
@@ -51,20 +52,15 @@ func TestPVCGetCopyToContainerSpec(t *testing.T) {
 		Name: "pipelinerun-pvc",
 	}
 	want := []corev1.Container{{
-		Name:         "source-mkdir-workspace-9l9zj",
-		Image:        "override-with-bash-noop:latest",
-		Command:      []string{"/ko-app/bash"},
-		Args:         []string{"-args", "mkdir -p /workspace/destination"},
-		VolumeMounts: []corev1.VolumeMount{{MountPath: "/pvc", Name: "pipelinerun-pvc"}},
-	}, {
-		Name:         "source-copy-workspace-mz4c7",
-		Image:        "override-with-bash-noop:latest",
-		Command:      []string{"/ko-app/bash"},
-		Args:         []string{"-args", "cp -r src-path/. /workspace/destination"},
+		Name:    "artifact-copy-to-workspace-9l9zj",
+		Image:   "override-with-artifactcopy-image:latest",
+		Command: []string{"/ko-app/artifactcopy"},
+		Args: []string{"-operation", "upload", "-name", "workspace", "-path", "/pvc/task/workspace",
+			"-location", "/pvc/task/workspace", "-dir", "src-path"},
 		VolumeMounts: []corev1.VolumeMount{{MountPath: "/pvc", Name: "pipelinerun-pvc"}},
 	}}
 
-	got := pvc.GetCopyToStorageFromContainerSpec("workspace", "src-path", "/workspace/destination")
+	got := pvc.GetCopyToStorageFromContainerSpec("workspace", "src-path", "/pvc/task/workspace")
 	if d := cmp.Diff(got, want); d != "" {
 		t.Errorf("Diff:\n%s", d)
 	}
@@ -100,3 +96,27 @@ func TestStorageBasePath(t *testing.T) {
 		t.Errorf("Diff:\n%s", d)
 	}
 }
+
+func TestPVCNeedsCoScheduling(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		pvc  ArtifactPVC
+		want bool
+	}{{
+		desc: "copied",
+		pvc:  ArtifactPVC{Name: "pipelinerun-pvc"},
+	}, {
+		desc: "mounted read-write-once",
+		pvc:  ArtifactPVC{Name: "pipelinerun-pvc", MountDirect: true},
+		want: true,
+	}, {
+		desc: "mounted read-write-many",
+		pvc:  ArtifactPVC{Name: "pipelinerun-pvc", AccessMode: corev1.ReadWriteMany, MountDirect: true},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := c.pvc.NeedsCoScheduling(); got != c.want {
+				t.Errorf("NeedsCoScheduling() = %t, want %t", got, c.want)
+			}
+		})
+	}
+}
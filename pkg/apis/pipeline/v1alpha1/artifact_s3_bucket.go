@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// BucketEndpointKey is the name of the configmap entry that specifies the
+	// endpoint of an S3-compatible bucket. AWS S3 is used when it is empty.
+	BucketEndpointKey = "bucket.endpoint"
+
+	// BucketRegionKey is the name of the configmap entry that specifies the
+	// region used to sign requests to an S3-compatible bucket.
+	BucketRegionKey = "bucket.region"
+
+	// BucketPathStyleKey is the name of the configmap entry that, when set to
+	// "true", addresses an S3-compatible bucket as a path of the endpoint.
+	BucketPathStyleKey = "bucket.path.style"
+
+	// BucketCredentialsSecretName is the name of the configmap entry that
+	// specifies the secret holding the AWS_ACCESS_KEY_ID and
+	// AWS_SECRET_ACCESS_KEY keys used to access an S3-compatible bucket.
+	BucketCredentialsSecretName = "bucket.credentials.secret.name"
+)
+
+const (
+	// ArtifactStorageS3BucketType indicates that artifacts are stored in an
+	// S3-compatible bucket.
+	ArtifactStorageS3BucketType = "s3bucket"
+)
+
+// ArtifactS3Bucket contains the configuration of an S3-compatible bucket, such
+// as AWS S3 or MinIO, defined in the Bucket config map.
+type ArtifactS3Bucket struct {
+	Location  string
+	Endpoint  string
+	Region    string
+	PathStyle bool
+	Secrets   []SecretParam
+}
+
+// GetType returns the type of the artifact storage
+func (b *ArtifactS3Bucket) GetType() string {
+	return ArtifactStorageS3BucketType
+}
+
+// StorageBasePath returns the path to be used to store artifacts in a pipelinerun temporary storage
+func (b *ArtifactS3Bucket) StorageBasePath(pr *PipelineRun) string {
+	return fmt.Sprintf("%s-%s-bucket", pr.Name, pr.Namespace)
+}
+
+// GetCopyFromStorageToContainerSpec returns a container used to download artifacts from temporary storage
+func (b *ArtifactS3Bucket) GetCopyFromStorageToContainerSpec(name, sourcePath, destinationPath, checksum string) []corev1.Container {
+	r := b.resource(sourcePath, destinationPath)
+	c := artifactCopyFromContainer(name, sourcePath, r.Location, destinationPath, checksum)
+	c.Args = append(c.Args, b.endpointArgs()...)
+	c.Env = r.envVars()
+	return []corev1.Container{c}
+}
+
+// GetCopyToStorageFromContainerSpec returns a container used to upload artifacts for temporary storage
+func (b *ArtifactS3Bucket) GetCopyToStorageFromContainerSpec(name, sourcePath, destinationPath string) []corev1.Container {
+	r := b.resource(destinationPath, sourcePath)
+	c := artifactCopyToContainer(name, destinationPath, r.Location, sourcePath)
+	c.Args = append(c.Args, b.endpointArgs()...)
+	c.Env = r.envVars()
+	return []corev1.Container{c}
+}
+
+// endpointArgs returns the arguments of the artifact copy helper addressing
+// the bucket.
+func (b *ArtifactS3Bucket) endpointArgs() []string {
+	var args []string
+	if b.Endpoint != "" {
+		args = append(args, "-endpoint", b.Endpoint)
+	}
+	if b.Region != "" {
+		args = append(args, "-region", b.Region)
+	}
+	if b.PathStyle {
+		args = append(args, "-path-style")
+	}
+	return args
+}
+
+// GetDeleteContainerSpec returns a container used to delete the artifacts
+// stored under path
+func (b *ArtifactS3Bucket) GetDeleteContainerSpec(path string) []corev1.Container {
+	r := b.resource(path, "")
+	return []corev1.Container{{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("artifact-delete"),
+		Image:   *s3Image,
+		Command: []string{"/ko-app/s3"},
+		Args:    r.args("delete"),
+		Env:     r.envVars(),
+	}}
+}
+
+// GetSecretsVolumes returns the list of volumes for secrets to be mounted
+// on pod. The credentials are passed as environment variables so there are
+// none.
+func (b *ArtifactS3Bucket) GetSecretsVolumes() []corev1.Volume {
+	return nil
+}
+
+// resource returns the S3Resource copying the objects under key, relative to
+// the bucket location, to or from the local directory dir.
+func (b *ArtifactS3Bucket) resource(key, dir string) *S3Resource {
+	return &S3Resource{
+		Location:       strings.TrimSuffix(b.Location, "/") + "/" + key,
+		Endpoint:       b.Endpoint,
+		Region:         b.Region,
+		PathStyle:      b.PathStyle,
+		TypeDir:        true,
+		DestinationDir: dir,
+		Secrets:        b.Secrets,
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	s3Bucket = ArtifactS3Bucket{
+		Location:  "s3://fake-bucket/",
+		Endpoint:  "http://minio:9000",
+		PathStyle: true,
+		Secrets: []SecretParam{{
+			FieldName:  "AWS_ACCESS_KEY_ID",
+			SecretName: secretName,
+			SecretKey:  "AWS_ACCESS_KEY_ID",
+		}},
+	}
+
+	s3BucketEnv = []corev1.EnvVar{{
+		Name: "AWS_ACCESS_KEY_ID",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  "AWS_ACCESS_KEY_ID",
+			},
+		},
+	}}
+)
+
+func TestS3BucketGetCopyFromContainerSpec(t *testing.T) {
+	names.TestingSeed()
+
+	want := []corev1.Container{{
+		Name:    "artifact-copy-from-workspace-9l9zj",
+		Image:   "override-with-artifactcopy-image:latest",
+		Command: []string{"/ko-app/artifactcopy"},
+		Args: []string{"-operation", "download", "-name", "workspace", "-path", "src-path",
+			"-location", "s3://fake-bucket/src-path", "-dir", "/workspace/destination", "-checksum", "sha256:abc",
+			"-endpoint", "http://minio:9000", "-path-style"},
+		Env: s3BucketEnv,
+	}}
+
+	got := s3Bucket.GetCopyFromStorageToContainerSpec("workspace", "src-path", "/workspace/destination", "sha256:abc")
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestS3BucketGetCopyToContainerSpec(t *testing.T) {
+	names.TestingSeed()
+	want := []corev1.Container{{
+		Name:    "artifact-copy-to-workspace-9l9zj",
+		Image:   "override-with-artifactcopy-image:latest",
+		Command: []string{"/ko-app/artifactcopy"},
+		Args: []string{"-operation", "upload", "-name", "workspace", "-path", "workspace/destination",
+			"-location", "s3://fake-bucket/workspace/destination", "-dir", "src-path",
+			"-endpoint", "http://minio:9000", "-path-style"},
+		Env: s3BucketEnv,
+	}}
+
+	got := s3Bucket.GetCopyToStorageFromContainerSpec("workspace", "src-path", "workspace/destination")
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestS3BucketGetDeleteContainerSpec(t *testing.T) {
+	names.TestingSeed()
+	want := []corev1.Container{{
+		Name:    "artifact-delete-9l9zj",
+		Image:   "override-with-s3-image:latest",
+		Command: []string{"/ko-app/s3"},
+		Args:    []string{"-operation", "delete", "-location", "s3://fake-bucket/pr-ns-bucket", "-path", "", "-endpoint", "http://minio:9000", "-path-style", "-dir"},
+		Env:     s3BucketEnv,
+	}}
+	got := s3Bucket.GetDeleteContainerSpec("pr-ns-bucket")
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestS3BucketGetSecretsVolumes(t *testing.T) {
+	if got := s3Bucket.GetSecretsVolumes(); len(got) != 0 {
+		t.Errorf("Expected no secret volumes, got %v", got)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	cacheImage = flag.String("cache-image", "override-with-cache-image:latest", "The container image containing our cache helper binary")
+
+	cacheSecretVolumeMountPath = "/var/cachesecret"
+)
+
+const (
+	// CacheVolumeName is the name of the volume of the PersistentVolumeClaim
+	// caches are stored in when no bucket is configured.
+	CacheVolumeName = "tekton-cache"
+
+	cacheDir = "/cache"
+)
+
+// CacheStorage is where the caches of Tasks are stored across runs: a GCS or
+// S3-compatible bucket location, or a PersistentVolumeClaim shared by the
+// runs of a namespace.
+type CacheStorage struct {
+	// Location is the gs:// or s3:// location caches are stored under. It
+	// is empty when they are stored in the claim.
+	Location  string
+	Endpoint  string
+	Region    string
+	PathStyle bool
+	// ClaimName is the name of the PersistentVolumeClaim caches are stored
+	// in when Location is empty.
+	ClaimName string
+	Secrets   []SecretParam
+}
+
+// GetRestoreContainerSpec returns the container restoring the cache c before
+// the steps of the Task run.
+func (s *CacheStorage) GetRestoreContainerSpec(c TaskCache) corev1.Container {
+	return s.container("restore", c)
+}
+
+// GetSaveContainerSpec returns the container saving the cache c once the
+// steps of the Task ran.
+func (s *CacheStorage) GetSaveContainerSpec(c TaskCache) corev1.Container {
+	return s.container("save", c)
+}
+
+// GetVolumes returns the volumes the restore and save containers mount.
+func (s *CacheStorage) GetVolumes() []corev1.Volume {
+	if s.Location == "" {
+		return []corev1.Volume{{
+			Name: CacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: s.ClaimName},
+			},
+		}}
+	}
+	if s.isS3() {
+		return nil
+	}
+	var volumes []corev1.Volume
+	for _, sec := range s.Secrets {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("volume-cache-%s", sec.SecretName),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: sec.SecretName,
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+func (s *CacheStorage) isS3() bool {
+	return strings.HasPrefix(s.Location, "s3://")
+}
+
+func (s *CacheStorage) container(operation string, c TaskCache) corev1.Container {
+	location := s.Location
+	if location == "" {
+		location = cacheDir
+	}
+	args := []string{"-operation", operation, "-name", c.Name, "-location", location, "-paths", strings.Join(c.Paths, ",")}
+	if c.Key != "" {
+		args = append(args, "-key", c.Key)
+	}
+	if len(c.KeyFiles) > 0 {
+		args = append(args, "-key-files", strings.Join(c.KeyFiles, ","))
+	}
+	container := corev1.Container{
+		Name:       names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("cache-%s-%s", operation, c.Name)),
+		Image:      *cacheImage,
+		Command:    []string{"/ko-app/cache"},
+		WorkingDir: workspaceDir,
+	}
+	switch {
+	case s.Location == "":
+		container.VolumeMounts = []corev1.VolumeMount{{Name: CacheVolumeName, MountPath: cacheDir}}
+	case s.isS3():
+		if s.Endpoint != "" {
+			args = append(args, "-endpoint", s.Endpoint)
+		}
+		if s.Region != "" {
+			args = append(args, "-region", s.Region)
+		}
+		if s.PathStyle {
+			args = append(args, "-path-style")
+		}
+		container.Env = (&S3Resource{Secrets: s.Secrets}).envVars()
+	default:
+		container.Env, container.VolumeMounts = getSecretEnvVarsAndVolumeMounts("cache", cacheSecretVolumeMountPath, s.Secrets)
+	}
+	container.Args = args
+	return container
+}
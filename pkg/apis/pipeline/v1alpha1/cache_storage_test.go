@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var goModCache = TaskCache{
+	Name:     "go-mod",
+	Paths:    []string{"/go/pkg/mod"},
+	Key:      "go1.12",
+	KeyFiles: []string{"go.sum", "tools/go.sum"},
+}
+
+func TestCacheStorageGCS(t *testing.T) {
+	names.TestingSeed()
+	s := &CacheStorage{
+		Location: "gs://fake-bucket/caches/ns",
+		Secrets: []SecretParam{{
+			FieldName:  "GOOGLE_APPLICATION_CREDENTIALS",
+			SecretName: secretName,
+			SecretKey:  "serviceaccount",
+		}},
+	}
+	want := []corev1.Container{{
+		Name:       "cache-restore-go-mod-9l9zj",
+		Image:      "override-with-cache-image:latest",
+		Command:    []string{"/ko-app/cache"},
+		Args:       []string{"-operation", "restore", "-name", "go-mod", "-location", "gs://fake-bucket/caches/ns", "-paths", "/go/pkg/mod", "-key", "go1.12", "-key-files", "go.sum,tools/go.sum"},
+		WorkingDir: "/workspace",
+		Env: []corev1.EnvVar{{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: "/var/cachesecret/secret1/serviceaccount",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "volume-cache-secret1",
+			MountPath: "/var/cachesecret/secret1",
+		}},
+	}, {
+		Name:       "cache-save-go-mod-mz4c7",
+		Image:      "override-with-cache-image:latest",
+		Command:    []string{"/ko-app/cache"},
+		Args:       []string{"-operation", "save", "-name", "go-mod", "-location", "gs://fake-bucket/caches/ns", "-paths", "/go/pkg/mod", "-key", "go1.12", "-key-files", "go.sum,tools/go.sum"},
+		WorkingDir: "/workspace",
+		Env: []corev1.EnvVar{{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: "/var/cachesecret/secret1/serviceaccount",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "volume-cache-secret1",
+			MountPath: "/var/cachesecret/secret1",
+		}},
+	}}
+	got := []corev1.Container{s.GetRestoreContainerSpec(goModCache), s.GetSaveContainerSpec(goModCache)}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected containers (-want +got): %s", d)
+	}
+
+	wantVolumes := []corev1.Volume{{
+		Name: "volume-cache-secret1",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+		},
+	}}
+	if d := cmp.Diff(wantVolumes, s.GetVolumes()); d != "" {
+		t.Errorf("Unexpected volumes (-want +got): %s", d)
+	}
+}
+
+func TestCacheStorageS3(t *testing.T) {
+	names.TestingSeed()
+	s := &CacheStorage{
+		Location:  "s3://fake-bucket/caches/ns",
+		Endpoint:  "http://minio:9000",
+		Region:    "eu-west-1",
+		PathStyle: true,
+		Secrets:   s3Bucket.Secrets,
+	}
+	want := corev1.Container{
+		Name:       "cache-save-go-mod-9l9zj",
+		Image:      "override-with-cache-image:latest",
+		Command:    []string{"/ko-app/cache"},
+		Args:       []string{"-operation", "save", "-name", "go-mod", "-location", "s3://fake-bucket/caches/ns", "-paths", "/go/pkg/mod", "-key", "go1.12", "-key-files", "go.sum,tools/go.sum", "-endpoint", "http://minio:9000", "-region", "eu-west-1", "-path-style"},
+		WorkingDir: "/workspace",
+		Env:        s3BucketEnv,
+	}
+	if d := cmp.Diff(want, s.GetSaveContainerSpec(goModCache)); d != "" {
+		t.Errorf("Unexpected container (-want +got): %s", d)
+	}
+	if volumes := s.GetVolumes(); len(volumes) != 0 {
+		t.Errorf("Expected no volumes but got %v", volumes)
+	}
+}
+
+func TestCacheStoragePVC(t *testing.T) {
+	names.TestingSeed()
+	s := &CacheStorage{ClaimName: "build-cache"}
+	c := TaskCache{Name: "m2", Paths: []string{"/root/.m2", "${inputs.resources.source.path}/target"}}
+	want := corev1.Container{
+		Name:         "cache-restore-m2-9l9zj",
+		Image:        "override-with-cache-image:latest",
+		Command:      []string{"/ko-app/cache"},
+		Args:         []string{"-operation", "restore", "-name", "m2", "-location", "/cache", "-paths", "/root/.m2,${inputs.resources.source.path}/target"},
+		WorkingDir:   "/workspace",
+		VolumeMounts: []corev1.VolumeMount{{Name: "tekton-cache", MountPath: "/cache"}},
+	}
+	if d := cmp.Diff(want, s.GetRestoreContainerSpec(c)); d != "" {
+		t.Errorf("Unexpected container (-want +got): %s", d)
+	}
+
+	wantVolumes := []corev1.Volume{{
+		Name: "tekton-cache",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "build-cache"},
+		},
+	}}
+	if d := cmp.Diff(wantVolumes, s.GetVolumes()); d != "" {
+		t.Errorf("Unexpected volumes (-want +got): %s", d)
+	}
+}
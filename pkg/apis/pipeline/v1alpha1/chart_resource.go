@@ -0,0 +1,184 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// chartUsernameEnv and chartPasswordEnv are the environment variables
+	// the credentials of the chart repository are passed in.
+	chartUsernameEnv = "HELM_USERNAME"
+	chartPasswordEnv = "HELM_PASSWORD"
+)
+
+var (
+	helmChartImage = flag.String("helmchart-image", "override-with-helmchart:latest",
+		"The container image containing our Helm chart binary.")
+
+	// chartSecretEnvs are the environment variables of the supported secret
+	// field names of chart resources.
+	chartSecretEnvs = map[string]string{"username": chartUsernameEnv, "password": chartPasswordEnv}
+)
+
+// ChartResource is a version of a Helm chart of a chart repository or OCI
+// registry, which is fetched into the workspace as an input, and packaged
+// from the workspace and uploaded as an output.
+type ChartResource struct {
+	// Name is the name of the chart, the name of the resource by default.
+	Name string               `json:"name"`
+	Type PipelineResourceType `json:"type"`
+	// URL is the URL of the chart repository, or the oci:// URL of the
+	// registry namespace holding the chart.
+	URL            string `json:"url"`
+	Version        string `json:"version"`
+	DestinationDir string `json:"destinationDir"`
+	// Secrets holds the field names and corresponding secrets of the
+	// credentials of the repository.
+	Secrets []SecretParam `json:"secrets"`
+}
+
+// NewChartResource creates a new chart resource to pass to a Task
+func NewChartResource(r *PipelineResource) (*ChartResource, error) {
+	if r.Spec.Type != PipelineResourceTypeChart {
+		return nil, xerrors.Errorf("ChartResource: Cannot create a chart resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	s := &ChartResource{
+		Name:    r.Name,
+		Type:    r.Spec.Type,
+		Secrets: r.Spec.SecretParams,
+	}
+	for _, param := range r.Spec.Params {
+		switch {
+		case strings.EqualFold(param.Name, "Name"):
+			s.Name = param.Value
+		case strings.EqualFold(param.Name, "URL"):
+			s.URL = param.Value
+		case strings.EqualFold(param.Name, "Version"):
+			s.Version = param.Value
+		}
+	}
+	if !isChartURL(s.URL) {
+		return nil, xerrors.Errorf("ChartResource: Need a http, https or oci URL to be specified in order to create chart resource %s", r.Name)
+	}
+	if s.Version == "" {
+		return nil, xerrors.Errorf("ChartResource: Need a version to be specified in order to create chart resource %s", r.Name)
+	}
+	for _, secret := range s.Secrets {
+		if _, ok := chartSecretEnvs[strings.ToLower(secret.FieldName)]; !ok {
+			return nil, xerrors.Errorf("ChartResource: Unsupported secret field %q for chart resource %s, only username and password are supported", secret.FieldName, r.Name)
+		}
+	}
+	return s, nil
+}
+
+// GetName returns the name of the resource
+func (s ChartResource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "chart"
+func (s ChartResource) GetType() PipelineResourceType {
+	return PipelineResourceTypeChart
+}
+
+// GetParams returns the resource params
+func (s ChartResource) GetParams() []Param { return []Param{} }
+
+// Replacements is used for template replacement on a ChartResource inside of a Taskrun.
+func (s *ChartResource) Replacements() map[string]string {
+	return map[string]string{
+		"name":    s.Name,
+		"type":    string(s.Type),
+		"url":     s.URL,
+		"version": s.Version,
+		"path":    s.DestinationDir,
+	}
+}
+
+// SetDestinationDirectory sets the directory the chart is fetched to and
+// packaged from.
+func (s *ChartResource) SetDestinationDirectory(dir string) {
+	s.DestinationDir = dir
+}
+
+// GetDownloadContainerSpec returns the containers fetching the chart to the
+// destination directory.
+func (s *ChartResource) GetDownloadContainerSpec() ([]corev1.Container, error) {
+	if s.DestinationDir == "" {
+		return nil, xerrors.Errorf("ChartResource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	return []corev1.Container{
+		CreateDirContainer(s.Name, s.DestinationDir),
+		s.container(fmt.Sprintf("fetch-%s", s.Name), "fetch"),
+	}, nil
+}
+
+// GetUploadContainerSpec returns the container packaging the chart of the
+// destination directory and uploading it.
+func (s *ChartResource) GetUploadContainerSpec() ([]corev1.Container, error) {
+	if s.DestinationDir == "" {
+		return nil, xerrors.Errorf("ChartResource: Expect Destination Directory param to be set: %s", s.Name)
+	}
+	return []corev1.Container{s.container(fmt.Sprintf("upload-%s", s.Name), "upload")}, nil
+}
+
+func (s *ChartResource) container(name, operation string) corev1.Container {
+	var env []corev1.EnvVar
+	for _, secret := range s.Secrets {
+		env = append(env, corev1.EnvVar{
+			Name: chartSecretEnvs[strings.ToLower(secret.FieldName)],
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.SecretName},
+					Key:                  secret.SecretKey,
+				},
+			},
+		})
+	}
+	return corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(name),
+		Image:   *helmChartImage,
+		Command: []string{"/ko-app/helmchart"},
+		Args: []string{
+			"-operation", operation,
+			"-url", s.URL,
+			"-name", s.Name,
+			"-version", s.Version,
+			"-path", s.DestinationDir,
+		},
+		Env: env,
+	}
+}
+
+// isChartURL returns whether rawURL is the URL of a chart repository or the
+// oci:// URL of a registry.
+func isChartURL(rawURL string) bool {
+	if strings.HasPrefix(rawURL, "oci://") {
+		u, err := url.Parse(rawURL)
+		return err == nil && u.Host != ""
+	}
+	return isHTTPURL(rawURL)
+}
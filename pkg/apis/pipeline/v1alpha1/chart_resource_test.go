@@ -0,0 +1,229 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewChartResource(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		resource *PipelineResource
+		want     *ChartResource
+	}{{
+		name: "chart repository",
+		resource: &PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "wizzbang"},
+			Spec: PipelineResourceSpec{
+				Type: PipelineResourceTypeChart,
+				Params: []Param{
+					{Name: "url", Value: "https://charts.example.com"},
+					{Name: "version", Value: "1.2.3"},
+				},
+				SecretParams: []SecretParam{{FieldName: "password", SecretName: "charts", SecretKey: "password"}},
+			},
+		},
+		want: &ChartResource{
+			Name:    "wizzbang",
+			Type:    PipelineResourceTypeChart,
+			URL:     "https://charts.example.com",
+			Version: "1.2.3",
+			Secrets: []SecretParam{{FieldName: "password", SecretName: "charts", SecretKey: "password"}},
+		},
+	}, {
+		name: "oci registry with chart name",
+		resource: &PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "wizzbang-chart"},
+			Spec: PipelineResourceSpec{
+				Type: PipelineResourceTypeChart,
+				Params: []Param{
+					{Name: "url", Value: "oci://registry.example.com/charts"},
+					{Name: "name", Value: "wizzbang"},
+					{Name: "version", Value: "1.2.3"},
+				},
+			},
+		},
+		want: &ChartResource{
+			Name:    "wizzbang",
+			Type:    PipelineResourceTypeChart,
+			URL:     "oci://registry.example.com/charts",
+			Version: "1.2.3",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ResourceFromType(tc.resource, nil)
+			if err != nil {
+				t.Fatalf("ResourceFromType() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestNewChartResource_Invalid(t *testing.T) {
+	for _, r := range []*PipelineResource{{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-resource"},
+		Spec:       PipelineResourceSpec{Type: PipelineResourceTypeGit},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "no-url"},
+		Spec: PipelineResourceSpec{
+			Type:   PipelineResourceTypeChart,
+			Params: []Param{{Name: "version", Value: "1.2.3"}},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "oci-without-host"},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeChart,
+			Params: []Param{
+				{Name: "url", Value: "oci:///charts"},
+				{Name: "version", Value: "1.2.3"},
+			},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "no-version"},
+		Spec: PipelineResourceSpec{
+			Type:   PipelineResourceTypeChart,
+			Params: []Param{{Name: "url", Value: "https://charts.example.com"}},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "bad-secret"},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeChart,
+			Params: []Param{
+				{Name: "url", Value: "https://charts.example.com"},
+				{Name: "version", Value: "1.2.3"},
+			},
+			SecretParams: []SecretParam{{FieldName: "authorization", SecretName: "s", SecretKey: "k"}},
+		},
+	}} {
+		t.Run(r.Name, func(t *testing.T) {
+			if _, err := NewChartResource(r); err == nil {
+				t.Error("Expected error creating chart resource")
+			}
+		})
+	}
+}
+
+func TestChartResource_Replacements(t *testing.T) {
+	r := &ChartResource{
+		Name:           "wizzbang",
+		Type:           PipelineResourceTypeChart,
+		URL:            "https://charts.example.com",
+		Version:        "1.2.3",
+		DestinationDir: "/workspace/chart",
+	}
+	want := map[string]string{
+		"name":    "wizzbang",
+		"type":    "chart",
+		"url":     "https://charts.example.com",
+		"version": "1.2.3",
+		"path":    "/workspace/chart",
+	}
+	if d := cmp.Diff(want, r.Replacements()); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestChartResource_GetContainerSpecs(t *testing.T) {
+	names.TestingSeed()
+	r := &ChartResource{
+		Name:           "wizzbang",
+		Type:           PipelineResourceTypeChart,
+		URL:            "oci://registry.example.com/charts",
+		Version:        "1.2.3",
+		DestinationDir: "/workspace/chart",
+		Secrets: []SecretParam{{
+			FieldName:  "username",
+			SecretName: "registry",
+			SecretKey:  "user",
+		}, {
+			FieldName:  "password",
+			SecretName: "registry",
+			SecretKey:  "token",
+		}},
+	}
+	env := []corev1.EnvVar{{
+		Name: "HELM_USERNAME",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "registry"},
+				Key:                  "user",
+			},
+		},
+	}, {
+		Name: "HELM_PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "registry"},
+				Key:                  "token",
+			},
+		},
+	}}
+
+	wantDownload := []corev1.Container{{
+		Name:    "create-dir-wizzbang-9l9zj",
+		Image:   "override-with-bash-noop:latest",
+		Command: []string{"/ko-app/bash"},
+		Args:    []string{"-args", "mkdir -p /workspace/chart"},
+	}, {
+		Name:    "fetch-wizzbang-mz4c7",
+		Image:   "override-with-helmchart:latest",
+		Command: []string{"/ko-app/helmchart"},
+		Args:    []string{"-operation", "fetch", "-url", "oci://registry.example.com/charts", "-name", "wizzbang", "-version", "1.2.3", "-path", "/workspace/chart"},
+		Env:     env,
+	}}
+	gotDownload, err := r.GetDownloadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetDownloadContainerSpec() = %v", err)
+	}
+	if d := cmp.Diff(wantDownload, gotDownload); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+
+	wantUpload := []corev1.Container{{
+		Name:    "upload-wizzbang-mssqb",
+		Image:   "override-with-helmchart:latest",
+		Command: []string{"/ko-app/helmchart"},
+		Args:    []string{"-operation", "upload", "-url", "oci://registry.example.com/charts", "-name", "wizzbang", "-version", "1.2.3", "-path", "/workspace/chart"},
+		Env:     env,
+	}}
+	gotUpload, err := r.GetUploadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetUploadContainerSpec() = %v", err)
+	}
+	if d := cmp.Diff(wantUpload, gotUpload); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestChartResource_GetContainerSpecs_NoDestination(t *testing.T) {
+	r := &ChartResource{Name: "wizzbang", URL: "https://charts.example.com", Version: "1.2.3"}
+	if _, err := r.GetDownloadContainerSpec(); err == nil {
+		t.Error("Expected error getting download containers without a destination directory")
+	}
+	if _, err := r.GetUploadContainerSpec(); err == nil {
+		t.Error("Expected error getting upload containers without a destination directory")
+	}
+}
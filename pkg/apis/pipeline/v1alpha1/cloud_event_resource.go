@@ -0,0 +1,92 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CloudEventResource is an output resource to whose target URI the controller
+// sends a CloudEvent when the TaskRun using it finishes.
+type CloudEventResource struct {
+	Name string               `json:"name"`
+	Type PipelineResourceType `json:"type"`
+	// TargetURI is the URI the CloudEvent is sent to.
+	TargetURI string `json:"targetURI"`
+}
+
+// NewCloudEventResource creates a new CloudEvent resource to pass to a Task
+func NewCloudEventResource(r *PipelineResource) (*CloudEventResource, error) {
+	if r.Spec.Type != PipelineResourceTypeCloudEvent {
+		return nil, xerrors.Errorf("CloudEventResource: Cannot create a CloudEvent resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	s := &CloudEventResource{
+		Name: r.Name,
+		Type: r.Spec.Type,
+	}
+	for _, param := range r.Spec.Params {
+		if strings.EqualFold(param.Name, "TargetURI") {
+			s.TargetURI = param.Value
+		}
+	}
+	if !isHTTPURL(s.TargetURI) {
+		return nil, xerrors.Errorf("CloudEventResource: Need a http or https targetURI to be specified in order to create CloudEvent resource %s", r.Name)
+	}
+	return s, nil
+}
+
+// GetName returns the name of the resource
+func (s CloudEventResource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "cloudEvent"
+func (s CloudEventResource) GetType() PipelineResourceType {
+	return PipelineResourceTypeCloudEvent
+}
+
+// GetParams returns the resource params
+func (s CloudEventResource) GetParams() []Param { return []Param{} }
+
+// Replacements is used for template replacement on a CloudEventResource inside of a Taskrun.
+func (s *CloudEventResource) Replacements() map[string]string {
+	return map[string]string{
+		"name":      s.Name,
+		"type":      string(s.Type),
+		"targetURI": s.TargetURI,
+	}
+}
+
+// SetDestinationDirectory is a no-op, the CloudEvent being sent by the
+// controller rather than from the workspace.
+func (s *CloudEventResource) SetDestinationDirectory(path string) {
+}
+
+// GetDownloadContainerSpec returns no containers, there being nothing to
+// fetch.
+func (s *CloudEventResource) GetDownloadContainerSpec() ([]corev1.Container, error) {
+	return nil, nil
+}
+
+// GetUploadContainerSpec returns no containers, the CloudEvent being sent by
+// the controller once the TaskRun finished.
+func (s *CloudEventResource) GetUploadContainerSpec() ([]corev1.Container, error) {
+	return nil, nil
+}
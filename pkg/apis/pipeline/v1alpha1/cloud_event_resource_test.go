@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewCloudEventResource(t *testing.T) {
+	r := &PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cloud-event",
+		},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeCloudEvent,
+			Params: []Param{{
+				Name:  "targetURI",
+				Value: "https://sink.example.com/events",
+			}},
+		},
+	}
+	want := &CloudEventResource{
+		Name:      "test-cloud-event",
+		Type:      PipelineResourceTypeCloudEvent,
+		TargetURI: "https://sink.example.com/events",
+	}
+
+	got, err := ResourceFromType(r, nil)
+	if err != nil {
+		t.Fatalf("ResourceFromType() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+	containers, err := got.GetUploadContainerSpec()
+	if err != nil || len(containers) != 0 {
+		t.Errorf("Expected no upload containers, got %v, %v", containers, err)
+	}
+}
+
+func TestNewCloudEventResource_Invalid(t *testing.T) {
+	for _, r := range []*PipelineResource{{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-resource"},
+		Spec:       PipelineResourceSpec{Type: PipelineResourceTypeGit},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "no-target"},
+		Spec:       PipelineResourceSpec{Type: PipelineResourceTypeCloudEvent},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "relative-target"},
+		Spec: PipelineResourceSpec{
+			Type:   PipelineResourceTypeCloudEvent,
+			Params: []Param{{Name: "targetURI", Value: "/events"}},
+		},
+	}} {
+		t.Run(r.Name, func(t *testing.T) {
+			if _, err := NewCloudEventResource(r); err == nil {
+				t.Error("Expected error creating CloudEvent resource")
+			}
+		})
+	}
+}
+
+func TestCloudEventResource_Replacements(t *testing.T) {
+	r := &CloudEventResource{
+		Name:      "test-cloud-event",
+		Type:      PipelineResourceTypeCloudEvent,
+		TargetURI: "https://sink.example.com/events",
+	}
+	want := map[string]string{
+		"name":      "test-cloud-event",
+		"type":      "cloudEvent",
+		"targetURI": "https://sink.example.com/events",
+	}
+	if d := cmp.Diff(want, r.Replacements()); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
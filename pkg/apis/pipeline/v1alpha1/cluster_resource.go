@@ -54,8 +54,46 @@ type ClusterResource struct {
 	CAData []byte `json:"cadata"`
 	//Secrets holds a struct to indicate a field name and corresponding secret name to populate it
 	Secrets []SecretParam `json:"secrets"`
+	// Namespace is the default namespace of the context of the cluster.
+	Namespace string `json:"namespace,omitempty"`
+	// ClientCertificateData and ClientKeyData hold the PEM-encoded client
+	// certificate and key used for TLS client authentication.
+	ClientCertificateData []byte `json:"clientCertificateData,omitempty"`
+	ClientKeyData         []byte `json:"clientKeyData,omitempty"`
+	// Exec is the credential plugin run to get the credentials of the user.
+	Exec *ClusterExecConfig `json:"exec,omitempty"`
+	// Contexts are the contexts of the kubeconfig besides the one named after
+	// the cluster, for the same cluster and user in other namespaces.
+	Contexts []ClusterContext `json:"contexts,omitempty"`
 }
 
+// ClusterExecConfig is a command run to get the credentials of the user of a
+// ClusterResource, as the exec section of a kubeconfig.
+type ClusterExecConfig struct {
+	Command    string              `json:"command"`
+	Args       []string            `json:"args,omitempty"`
+	Env        []ClusterExecEnvVar `json:"env,omitempty"`
+	APIVersion string              `json:"apiVersion,omitempty"`
+}
+
+// ClusterExecEnvVar is an environment variable set when running the
+// credential plugin of a ClusterResource.
+type ClusterExecEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ClusterContext is an additional context of the kubeconfig of a
+// ClusterResource.
+type ClusterContext struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// defaultExecAPIVersion is the version of the ExecCredential exchanged with
+// credential plugins when none is provided.
+const defaultExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+
 // NewClusterResource create a new k8s cluster resource to pass to a pipeline task
 func NewClusterResource(r *PipelineResource) (*ClusterResource, error) {
 	if r.Spec.Type != PipelineResourceTypeCluster {
@@ -86,9 +124,42 @@ func NewClusterResource(r *PipelineResource) (*ClusterResource, error) {
 				sDec, _ := b64.StdEncoding.DecodeString(param.Value)
 				clusterResource.CAData = sDec
 			}
+		case strings.EqualFold(param.Name, "ClientCertificateData"):
+			if param.Value != "" {
+				sDec, _ := b64.StdEncoding.DecodeString(param.Value)
+				clusterResource.ClientCertificateData = sDec
+			}
+		case strings.EqualFold(param.Name, "ClientKeyData"):
+			if param.Value != "" {
+				sDec, _ := b64.StdEncoding.DecodeString(param.Value)
+				clusterResource.ClientKeyData = sDec
+			}
+		case strings.EqualFold(param.Name, "Namespace"):
+			clusterResource.Namespace = param.Value
+		case strings.EqualFold(param.Name, "Contexts"):
+			contexts, err := parseClusterContexts(param.Value)
+			if err != nil {
+				return nil, xerrors.Errorf("ClusterResource: Invalid contexts of %s: %w", r.Name, err)
+			}
+			clusterResource.Contexts = contexts
+		case strings.EqualFold(param.Name, "ExecCommand"):
+			clusterExec(&clusterResource).Command = param.Value
+		case strings.EqualFold(param.Name, "ExecArgs"):
+			clusterExec(&clusterResource).Args = strings.Fields(param.Value)
+		case strings.EqualFold(param.Name, "ExecAPIVersion"):
+			clusterExec(&clusterResource).APIVersion = param.Value
+		case strings.EqualFold(param.Name, "ExecEnv"):
+			env, err := parseClusterExecEnv(param.Value)
+			if err != nil {
+				return nil, xerrors.Errorf("ClusterResource: Invalid execEnv of %s: %w", r.Name, err)
+			}
+			clusterExec(&clusterResource).Env = env
 		}
 	}
 	clusterResource.Secrets = r.Spec.SecretParams
+	if clusterResource.Exec != nil && clusterResource.Exec.APIVersion == "" {
+		clusterResource.Exec.APIVersion = defaultExecAPIVersion
+	}
 
 	if len(clusterResource.CAData) == 0 {
 		clusterResource.Insecure = true
@@ -103,6 +174,48 @@ func NewClusterResource(r *PipelineResource) (*ClusterResource, error) {
 	return &clusterResource, nil
 }
 
+// clusterExec returns the credential plugin of r, creating it if needed.
+func clusterExec(r *ClusterResource) *ClusterExecConfig {
+	if r.Exec == nil {
+		r.Exec = &ClusterExecConfig{}
+	}
+	return r.Exec
+}
+
+// parseClusterContexts parses comma separated <name>=<namespace> contexts.
+func parseClusterContexts(value string) ([]ClusterContext, error) {
+	var contexts []ClusterContext
+	for _, c := range strings.Split(value, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		parts := strings.SplitN(c, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, xerrors.Errorf("expected <name>=<namespace> but got %q", c)
+		}
+		contexts = append(contexts, ClusterContext{Name: parts[0], Namespace: parts[1]})
+	}
+	return contexts, nil
+}
+
+// parseClusterExecEnv parses comma separated <name>=<value> variables.
+func parseClusterExecEnv(value string) ([]ClusterExecEnvVar, error) {
+	var env []ClusterExecEnvVar
+	for _, e := range strings.Split(value, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, xerrors.Errorf("expected <name>=<value> but got %q", e)
+		}
+		env = append(env, ClusterExecEnvVar{Name: parts[0], Value: parts[1]})
+	}
+	return env, nil
+}
+
 // GetName returns the name of the resource
 func (s ClusterResource) GetName() string {
 	return s.Name
@@ -124,15 +237,16 @@ func (s ClusterResource) GetParams() []Param { return []Param{} }
 // Replacements is used for template replacement on a ClusterResource inside of a Taskrun.
 func (s *ClusterResource) Replacements() map[string]string {
 	return map[string]string{
-		"name":     s.Name,
-		"type":     string(s.Type),
-		"url":      s.URL,
-		"revision": s.Revision,
-		"username": s.Username,
-		"password": s.Password,
-		"token":    s.Token,
-		"insecure": strconv.FormatBool(s.Insecure),
-		"cadata":   string(s.CAData),
+		"name":      s.Name,
+		"type":      string(s.Type),
+		"url":       s.URL,
+		"revision":  s.Revision,
+		"username":  s.Username,
+		"password":  s.Password,
+		"token":     s.Token,
+		"insecure":  strconv.FormatBool(s.Insecure),
+		"cadata":    string(s.CAData),
+		"namespace": s.Namespace,
 	}
 }
 
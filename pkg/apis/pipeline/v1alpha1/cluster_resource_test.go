@@ -165,6 +165,103 @@ func TestNewClusterResource(t *testing.T) {
 				SecretName: "secret1",
 			}},
 		},
+	}, {
+		desc: "resource with client certificate in a namespace",
+		resource: &PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster-resource",
+				Namespace: "foo",
+			},
+			Spec: PipelineResourceSpec{
+				Type: PipelineResourceTypeCluster,
+				Params: []Param{{
+					Name:  "name",
+					Value: "test-cluster-resource",
+				}, {
+					Name:  "url",
+					Value: "http://10.10.10.10",
+				}, {
+					Name:  "cadata",
+					Value: "bXktY2x1c3Rlci1jZXJ0Cg",
+				}, {
+					Name:  "clientCertificateData",
+					Value: "bXktY2xpZW50LWNlcnQK",
+				}, {
+					Name:  "clientKeyData",
+					Value: "bXktY2xpZW50LWtleQo=",
+				}, {
+					Name:  "namespace",
+					Value: "deploy",
+				}},
+			},
+		},
+		want: &ClusterResource{
+			Name:                  "test-cluster-resource",
+			Type:                  PipelineResourceTypeCluster,
+			URL:                   "http://10.10.10.10",
+			CAData:                []byte("my-cluster-cert"),
+			ClientCertificateData: []byte("my-client-cert\n"),
+			ClientKeyData:         []byte("my-client-key\n"),
+			Namespace:             "deploy",
+		},
+	}, {
+		desc: "resource with credential plugin and contexts",
+		resource: &PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster-resource",
+				Namespace: "foo",
+			},
+			Spec: PipelineResourceSpec{
+				Type: PipelineResourceTypeCluster,
+				Params: []Param{{
+					Name:  "name",
+					Value: "test-cluster-resource",
+				}, {
+					Name:  "url",
+					Value: "http://10.10.10.10",
+				}, {
+					Name:  "cadata",
+					Value: "bXktY2x1c3Rlci1jZXJ0Cg",
+				}, {
+					Name:  "execCommand",
+					Value: "aws-iam-authenticator",
+				}, {
+					Name:  "execArgs",
+					Value: "token -i prod",
+				}, {
+					Name:  "execEnv",
+					Value: "AWS_PROFILE=ci, AWS_REGION=eu-west-1",
+				}, {
+					Name:  "contexts",
+					Value: "monitoring=prometheus,ingress=ingress-nginx",
+				}},
+			},
+		},
+		want: &ClusterResource{
+			Name:   "test-cluster-resource",
+			Type:   PipelineResourceTypeCluster,
+			URL:    "http://10.10.10.10",
+			CAData: []byte("my-cluster-cert"),
+			Exec: &ClusterExecConfig{
+				Command: "aws-iam-authenticator",
+				Args:    []string{"token", "-i", "prod"},
+				Env: []ClusterExecEnvVar{{
+					Name:  "AWS_PROFILE",
+					Value: "ci",
+				}, {
+					Name:  "AWS_REGION",
+					Value: "eu-west-1",
+				}},
+				APIVersion: "client.authentication.k8s.io/v1beta1",
+			},
+			Contexts: []ClusterContext{{
+				Name:      "monitoring",
+				Namespace: "prometheus",
+			}, {
+				Name:      "ingress",
+				Namespace: "ingress-nginx",
+			}},
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			got, err := NewClusterResource(c.resource)
@@ -178,6 +275,27 @@ func TestNewClusterResource(t *testing.T) {
 	}
 }
 
+func TestNewClusterResource_InvalidContexts(t *testing.T) {
+	r := &PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster-resource",
+		},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeCluster,
+			Params: []Param{{
+				Name:  "name",
+				Value: "test-cluster-resource",
+			}, {
+				Name:  "contexts",
+				Value: "monitoring",
+			}},
+		},
+	}
+	if _, err := NewClusterResource(r); err == nil {
+		t.Error("Expected an error for a context without a namespace")
+	}
+}
+
 func Test_ClusterResource_GetDownloadContainerSpec(t *testing.T) {
 	names.TestingSeed()
 	testcases := []struct {
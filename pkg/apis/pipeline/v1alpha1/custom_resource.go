@@ -0,0 +1,177 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+	"github.com/tektoncd/pipeline/pkg/templating"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetResourceType is a function used to retrieve the ResourceType declaring a
+// custom PipelineResourceType.
+type GetResourceType func(name string) (*ResourceType, error)
+
+// CustomResource is a PipelineResource of a type declared by a ResourceType,
+// which is fetched and pushed by the steps of that ResourceType.
+type CustomResource struct {
+	Name string               `json:"name"`
+	Type PipelineResourceType `json:"type"`
+	// Params holds the value of every param declared by the ResourceType.
+	Params         []Param `json:"params"`
+	DestinationDir string  `json:"destinationDir"`
+	// Secrets are exposed to the steps as environment variables named after
+	// their field names.
+	Secrets  []SecretParam      `json:"secrets"`
+	Download []corev1.Container `json:"download"`
+	Upload   []corev1.Container `json:"upload"`
+}
+
+// NewCustomResource creates a new resource of the custom type declared by rt to
+// pass to a Task.
+func NewCustomResource(r *PipelineResource, rt *ResourceType) (*CustomResource, error) {
+	if string(r.Spec.Type) != rt.Name {
+		return nil, xerrors.Errorf("CustomResource: Cannot create a %s resource from a %s Pipeline Resource", rt.Name, r.Spec.Type)
+	}
+	s := &CustomResource{
+		Name:     r.Name,
+		Type:     r.Spec.Type,
+		Secrets:  r.Spec.SecretParams,
+		Download: rt.Spec.Download,
+		Upload:   rt.Spec.Upload,
+	}
+	declared := map[string]bool{}
+	for _, param := range rt.Spec.Params {
+		declared[strings.ToLower(param.Name)] = true
+	}
+	values := map[string]string{}
+	for _, param := range r.Spec.Params {
+		if !declared[strings.ToLower(param.Name)] {
+			return nil, xerrors.Errorf("CustomResource: Param %q of %s resource %s is not declared by the ResourceType", param.Name, rt.Name, r.Name)
+		}
+		values[strings.ToLower(param.Name)] = param.Value
+	}
+	for _, param := range rt.Spec.Params {
+		value, ok := values[strings.ToLower(param.Name)]
+		if !ok {
+			if param.Default == "" {
+				return nil, xerrors.Errorf("CustomResource: Missing param %q of %s resource %s", param.Name, rt.Name, r.Name)
+			}
+			value = param.Default
+		}
+		s.Params = append(s.Params, Param{Name: param.Name, Value: value})
+	}
+	return s, nil
+}
+
+// GetName returns the name of the resource
+func (s CustomResource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, the name of its ResourceType
+func (s CustomResource) GetType() PipelineResourceType {
+	return s.Type
+}
+
+// GetParams returns the resource params
+func (s CustomResource) GetParams() []Param { return s.Params }
+
+// Replacements is used for template replacement on a CustomResource inside of
+// a Taskrun, and in the steps of its ResourceType.
+func (s *CustomResource) Replacements() map[string]string {
+	replacements := map[string]string{
+		"name": s.Name,
+		"type": string(s.Type),
+		"path": s.DestinationDir,
+	}
+	for _, p := range s.Params {
+		replacements[p.Name] = p.Value
+	}
+	return replacements
+}
+
+// SetDestinationDirectory sets the directory the resource is fetched to and
+// pushed from.
+func (s *CustomResource) SetDestinationDirectory(dir string) {
+	s.DestinationDir = dir
+}
+
+// GetDownloadContainerSpec returns the download steps of the ResourceType,
+// after creating the destination directory.
+func (s *CustomResource) GetDownloadContainerSpec() ([]corev1.Container, error) {
+	if len(s.Download) == 0 {
+		return nil, nil
+	}
+	return append([]corev1.Container{CreateDirContainer(s.Name, s.DestinationDir)}, s.containers("fetch", s.Download)...), nil
+}
+
+// GetUploadContainerSpec returns the upload steps of the ResourceType.
+func (s *CustomResource) GetUploadContainerSpec() ([]corev1.Container, error) {
+	return s.containers("upload", s.Upload), nil
+}
+
+func (s *CustomResource) containers(prefix string, steps []corev1.Container) []corev1.Container {
+	replacements := map[string]string{}
+	for k, v := range s.Replacements() {
+		replacements["resource."+k] = v
+	}
+	var env []corev1.EnvVar
+	for _, secret := range s.Secrets {
+		env = append(env, corev1.EnvVar{
+			Name: secret.FieldName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.SecretName},
+					Key:                  secret.SecretKey,
+				},
+			},
+		})
+	}
+
+	var containers []corev1.Container
+	for _, step := range steps {
+		c := *step.DeepCopy()
+		name := fmt.Sprintf("%s-%s", prefix, s.Name)
+		if c.Name != "" {
+			name += "-" + c.Name
+		}
+		c.Name = names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(name)
+		c.Image = templating.ApplyReplacements(c.Image, replacements)
+		for i, cmd := range c.Command {
+			c.Command[i] = templating.ApplyReplacements(cmd, replacements)
+		}
+		for i, arg := range c.Args {
+			c.Args[i] = templating.ApplyReplacements(arg, replacements)
+		}
+		for i, e := range c.Env {
+			c.Env[i].Value = templating.ApplyReplacements(e.Value, replacements)
+		}
+		c.Env = append(c.Env, env...)
+		if c.WorkingDir == "" {
+			c.WorkingDir = workspaceDir
+		} else {
+			c.WorkingDir = templating.ApplyReplacements(c.WorkingDir, replacements)
+		}
+		containers = append(containers, c)
+	}
+	return containers
+}
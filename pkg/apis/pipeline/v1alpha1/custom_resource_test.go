@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/names"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var helmResourceType = &ResourceType{
+	ObjectMeta: metav1.ObjectMeta{Name: "helm.example.com"},
+	Spec: ResourceTypeSpec{
+		Params: []ResourceTypeParam{{
+			Name: "chart",
+		}, {
+			Name:    "version",
+			Default: "latest",
+		}},
+		Download: []corev1.Container{{
+			Name:    "pull",
+			Image:   "alpine/helm:${resource.version}",
+			Command: []string{"helm"},
+			Args:    []string{"pull", "${resource.chart}", "--version", "${resource.version}", "--untar", "--untardir", "${resource.path}"},
+		}},
+		Upload: []corev1.Container{{
+			Image:      "alpine/helm",
+			Command:    []string{"helm"},
+			Args:       []string{"push", "."},
+			WorkingDir: "${resource.path}",
+		}},
+	},
+}
+
+func helmPipelineResource(params ...Param) *PipelineResource {
+	return &PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-chart"},
+		Spec: PipelineResourceSpec{
+			Type:   "helm.example.com",
+			Params: params,
+			SecretParams: []SecretParam{{
+				FieldName:  "HELM_REPO_PASSWORD",
+				SecretName: "helm-repo",
+				SecretKey:  "password",
+			}},
+		},
+	}
+}
+
+func getHelmResourceType(name string) (*ResourceType, error) {
+	if name != helmResourceType.Name {
+		return nil, xerrors.Errorf("resourcetype %q not found", name)
+	}
+	return helmResourceType, nil
+}
+
+func TestResourceFromType_Custom(t *testing.T) {
+	got, err := ResourceFromType(helmPipelineResource(Param{Name: "chart", Value: "stable/mysql"}), getHelmResourceType)
+	if err != nil {
+		t.Fatalf("ResourceFromType() = %v", err)
+	}
+	want := &CustomResource{
+		Name: "my-chart",
+		Type: "helm.example.com",
+		Params: []Param{
+			{Name: "chart", Value: "stable/mysql"},
+			{Name: "version", Value: "latest"},
+		},
+		Secrets:  helmPipelineResource().Spec.SecretParams,
+		Download: helmResourceType.Spec.Download,
+		Upload:   helmResourceType.Spec.Upload,
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestResourceFromType_CustomInvalid(t *testing.T) {
+	for _, c := range []struct {
+		desc            string
+		resource        *PipelineResource
+		getResourceType GetResourceType
+	}{{
+		desc:     "not supported",
+		resource: helmPipelineResource(Param{Name: "chart", Value: "stable/mysql"}),
+	}, {
+		desc: "unknown type",
+		resource: &PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-chart"},
+			Spec:       PipelineResourceSpec{Type: "kustomize.example.com"},
+		},
+		getResourceType: getHelmResourceType,
+	}, {
+		desc:            "missing param",
+		resource:        helmPipelineResource(Param{Name: "version", Value: "1.0.0"}),
+		getResourceType: getHelmResourceType,
+	}, {
+		desc:            "undeclared param",
+		resource:        helmPipelineResource(Param{Name: "chart", Value: "stable/mysql"}, Param{Name: "repo", Value: "stable"}),
+		getResourceType: getHelmResourceType,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if _, err := ResourceFromType(c.resource, c.getResourceType); err == nil {
+				t.Error("Expected error creating custom resource")
+			}
+		})
+	}
+}
+
+func TestCustomResource_GetContainerSpecs(t *testing.T) {
+	names.TestingSeed()
+	r, err := NewCustomResource(helmPipelineResource(Param{Name: "chart", Value: "stable/mysql"}, Param{Name: "version", Value: "1.0.0"}), helmResourceType)
+	if err != nil {
+		t.Fatalf("NewCustomResource() = %v", err)
+	}
+	r.SetDestinationDirectory("/workspace/chart")
+	env := []corev1.EnvVar{{
+		Name: "HELM_REPO_PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "helm-repo"},
+				Key:                  "password",
+			},
+		},
+	}}
+
+	wantDownload := []corev1.Container{{
+		Name:    "create-dir-my-chart-9l9zj",
+		Image:   "override-with-bash-noop:latest",
+		Command: []string{"/ko-app/bash"},
+		Args:    []string{"-args", "mkdir -p /workspace/chart"},
+	}, {
+		Name:       "fetch-my-chart-pull-mz4c7",
+		Image:      "alpine/helm:1.0.0",
+		Command:    []string{"helm"},
+		Args:       []string{"pull", "stable/mysql", "--version", "1.0.0", "--untar", "--untardir", "/workspace/chart"},
+		Env:        env,
+		WorkingDir: workspaceDir,
+	}}
+	gotDownload, err := r.GetDownloadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetDownloadContainerSpec() = %v", err)
+	}
+	if d := cmp.Diff(wantDownload, gotDownload); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+
+	wantUpload := []corev1.Container{{
+		Name:       "upload-my-chart-mssqb",
+		Image:      "alpine/helm",
+		Command:    []string{"helm"},
+		Args:       []string{"push", "."},
+		Env:        env,
+		WorkingDir: "/workspace/chart",
+	}}
+	gotUpload, err := r.GetUploadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetUploadContainerSpec() = %v", err)
+	}
+	if d := cmp.Diff(wantUpload, gotUpload); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+
+	// The steps of the ResourceType are shared, so must not be modified.
+	if got := helmResourceType.Spec.Download[0].Args[1]; got != "${resource.chart}" {
+		t.Errorf("Expected the ResourceType to be left untouched, got arg %q", got)
+	}
+}
+
+func TestCustomResource_Replacements(t *testing.T) {
+	r, err := NewCustomResource(helmPipelineResource(Param{Name: "chart", Value: "stable/mysql"}), helmResourceType)
+	if err != nil {
+		t.Fatalf("NewCustomResource() = %v", err)
+	}
+	r.SetDestinationDirectory("/workspace/chart")
+	want := map[string]string{
+		"name":    "my-chart",
+		"type":    "helm.example.com",
+		"path":    "/workspace/chart",
+		"chart":   "stable/mysql",
+		"version": "latest",
+	}
+	if d := cmp.Diff(want, r.Replacements()); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"flag"
+	"strconv"
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/names"
@@ -27,8 +28,17 @@ import (
 
 const workspaceDir = "/workspace"
 
+const (
+	// GitPushPolicyFastForward only pushes a git output if it fast-forwards
+	// the branch.
+	GitPushPolicyFastForward = "fastForward"
+	// GitPushPolicyForce overwrites the branch a git output is pushed to.
+	GitPushPolicyForce = "force"
+)
+
 var (
 	gitSource = "git-source"
+	gitSink   = "git-sink"
 	// The container with Git that we use to implement the Git source step.
 	gitImage = flag.String("git-image", "override-with-git:latest",
 		"The container image containing our Git binary.")
@@ -43,7 +53,30 @@ type GitResource struct {
 	// Git revision (branch, tag, commit SHA or ref) to clone.  See
 	// https://git-scm.com/docs/gitrevisions#_specifying_revisions for more
 	// information.
-	Revision   string `json:"revision"`
+	Revision string `json:"revision"`
+	// Depth of the history to fetch, 0 fetches the full history.
+	Depth uint `json:"depth"`
+	// Submodules are fetched and checked out if true.
+	Submodules bool `json:"submodules"`
+	// Refspec holds space separated refspecs to fetch, e.g.
+	// refs/pull/*/head:refs/remotes/origin/pr/*, in which case Revision is
+	// checked out from the fetched refs.
+	Refspec string `json:"refspec"`
+	// SparseCheckoutDirectories holds comma separated directories to check
+	// out instead of the whole tree.
+	SparseCheckoutDirectories string `json:"sparseCheckoutDirectories"`
+	// SSLVerify disables verification of the server certificate if false.
+	SSLVerify bool `json:"sslVerify"`
+	// Branch is the branch the changes made to an output are committed and
+	// pushed to. Outputs are not pushed if it is empty.
+	Branch string `json:"branch"`
+	// Message of the commit pushed, it may use the variables of the Task.
+	Message     string `json:"message"`
+	AuthorName  string `json:"authorName"`
+	AuthorEmail string `json:"authorEmail"`
+	// PushPolicy is either GitPushPolicyFastForward, the default, or
+	// GitPushPolicyForce.
+	PushPolicy string `json:"pushPolicy"`
 	TargetPath string
 }
 
@@ -53,15 +86,46 @@ func NewGitResource(r *PipelineResource) (*GitResource, error) {
 		return nil, xerrors.Errorf("GitResource: Cannot create a Git resource from a %s Pipeline Resource", r.Spec.Type)
 	}
 	gitResource := GitResource{
-		Name: r.Name,
-		Type: r.Spec.Type,
+		Name:      r.Name,
+		Type:      r.Spec.Type,
+		Depth:     1,
+		SSLVerify: true,
 	}
 	for _, param := range r.Spec.Params {
+		var err error
 		switch {
 		case strings.EqualFold(param.Name, "URL"):
 			gitResource.URL = param.Value
 		case strings.EqualFold(param.Name, "Revision"):
 			gitResource.Revision = param.Value
+		case strings.EqualFold(param.Name, "Depth"):
+			var depth uint64
+			depth, err = strconv.ParseUint(param.Value, 10, 32)
+			gitResource.Depth = uint(depth)
+		case strings.EqualFold(param.Name, "Submodules"):
+			gitResource.Submodules, err = strconv.ParseBool(param.Value)
+		case strings.EqualFold(param.Name, "Refspec"):
+			gitResource.Refspec = param.Value
+		case strings.EqualFold(param.Name, "SparseCheckoutDirectories"):
+			gitResource.SparseCheckoutDirectories = param.Value
+		case strings.EqualFold(param.Name, "SSLVerify"):
+			gitResource.SSLVerify, err = strconv.ParseBool(param.Value)
+		case strings.EqualFold(param.Name, "Branch"):
+			gitResource.Branch = param.Value
+		case strings.EqualFold(param.Name, "Message"):
+			gitResource.Message = param.Value
+		case strings.EqualFold(param.Name, "AuthorName"):
+			gitResource.AuthorName = param.Value
+		case strings.EqualFold(param.Name, "AuthorEmail"):
+			gitResource.AuthorEmail = param.Value
+		case strings.EqualFold(param.Name, "PushPolicy"):
+			if !isGitPushPolicy(param.Value) {
+				err = xerrors.Errorf("expected %s or %s", GitPushPolicyFastForward, GitPushPolicyForce)
+			}
+			gitResource.PushPolicy = param.Value
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("GitResource: Invalid %s param %q of Git resource %s: %w", param.Name, param.Value, r.Name, err)
 		}
 	}
 	// default revision to master is nothing is provided
@@ -92,11 +156,17 @@ func (s GitResource) GetParams() []Param { return []Param{} }
 // Replacements is used for template replacement on a GitResource inside of a Taskrun.
 func (s *GitResource) Replacements() map[string]string {
 	return map[string]string{
-		"name":     s.Name,
-		"type":     string(s.Type),
-		"url":      s.URL,
-		"revision": s.Revision,
-		"path":     s.TargetPath,
+		"name":                      s.Name,
+		"type":                      string(s.Type),
+		"url":                       s.URL,
+		"revision":                  s.Revision,
+		"depth":                     strconv.FormatUint(uint64(s.Depth), 10),
+		"submodules":                strconv.FormatBool(s.Submodules),
+		"refspec":                   s.Refspec,
+		"sparseCheckoutDirectories": s.SparseCheckoutDirectories,
+		"sslVerify":                 strconv.FormatBool(s.SSLVerify),
+		"branch":                    s.Branch,
+		"path":                      s.TargetPath,
 	}
 }
 
@@ -106,6 +176,25 @@ func (s *GitResource) GetDownloadContainerSpec() ([]corev1.Container, error) {
 	}
 
 	args = append(args, []string{"-path", s.TargetPath}...)
+	// The name is reported with the fetched commit in the TaskRun status.
+	args = append(args, "-name", s.Name)
+	// The defaults of git-init are only overridden when needed, so that the
+	// common case keeps a short command line.
+	if s.Depth != 1 {
+		args = append(args, "-depth", strconv.FormatUint(uint64(s.Depth), 10))
+	}
+	if s.Submodules {
+		args = append(args, "-submodules")
+	}
+	if s.Refspec != "" {
+		args = append(args, "-refspec", s.Refspec)
+	}
+	if s.SparseCheckoutDirectories != "" {
+		args = append(args, "-sparseCheckoutDirectories", s.SparseCheckoutDirectories)
+	}
+	if !s.SSLVerify {
+		args = append(args, "-sslVerify=false")
+	}
 
 	return []corev1.Container{{
 		Name:       names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(gitSource + "-" + s.Name),
@@ -120,6 +209,43 @@ func (s *GitResource) SetDestinationDirectory(path string) {
 	s.TargetPath = path
 }
 
+// GetUploadContainerSpec returns the container committing the changes made to
+// the resource and pushing them to Branch, or none if Branch is not set.
 func (s *GitResource) GetUploadContainerSpec() ([]corev1.Container, error) {
-	return nil, nil
+	if s.Branch == "" {
+		return nil, nil
+	}
+	args := []string{"-mode", "upload",
+		"-url", s.URL,
+		"-branch", s.Branch,
+		"-path", s.TargetPath,
+	}
+	// Unset values fall back to the defaults of git-init.
+	if s.Message != "" {
+		args = append(args, "-message", s.Message)
+	}
+	if s.AuthorName != "" {
+		args = append(args, "-authorName", s.AuthorName)
+	}
+	if s.AuthorEmail != "" {
+		args = append(args, "-authorEmail", s.AuthorEmail)
+	}
+	if s.PushPolicy == GitPushPolicyForce {
+		args = append(args, "-force")
+	}
+	if !s.SSLVerify {
+		args = append(args, "-sslVerify=false")
+	}
+
+	return []corev1.Container{{
+		Name:       names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(gitSink + "-" + s.Name),
+		Image:      *gitImage,
+		Command:    []string{"/ko-app/git-init"},
+		Args:       args,
+		WorkingDir: workspaceDir,
+	}}, nil
+}
+
+func isGitPushPolicy(policy string) bool {
+	return policy == GitPushPolicyFastForward || policy == GitPushPolicyForce
 }
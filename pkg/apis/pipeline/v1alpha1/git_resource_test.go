@@ -0,0 +1,266 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/names"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewGitResource(t *testing.T) {
+	for _, c := range []struct {
+		desc   string
+		params []Param
+		want   *GitResource
+	}{{
+		desc:   "defaults",
+		params: []Param{{Name: "url", Value: "git@github.com:test/test.git"}},
+		want: &GitResource{
+			Name:      "git-resource",
+			Type:      PipelineResourceTypeGit,
+			URL:       "git@github.com:test/test.git",
+			Revision:  "master",
+			Depth:     1,
+			SSLVerify: true,
+		},
+	}, {
+		desc: "all params",
+		params: []Param{
+			{Name: "url", Value: "git@github.com:test/test.git"},
+			{Name: "revision", Value: "pr/1"},
+			{Name: "depth", Value: "0"},
+			{Name: "submodules", Value: "true"},
+			{Name: "refspec", Value: "refs/pull/*/head:refs/remotes/origin/pr/*"},
+			{Name: "sparseCheckoutDirectories", Value: "docs,cmd"},
+			{Name: "sslVerify", Value: "false"},
+			{Name: "branch", Value: "release"},
+			{Name: "message", Value: "Bump version"},
+			{Name: "authorName", Value: "Robot"},
+			{Name: "authorEmail", Value: "robot@example.com"},
+			{Name: "pushPolicy", Value: "force"},
+		},
+		want: &GitResource{
+			Name:                      "git-resource",
+			Type:                      PipelineResourceTypeGit,
+			URL:                       "git@github.com:test/test.git",
+			Revision:                  "pr/1",
+			Depth:                     0,
+			Submodules:                true,
+			Refspec:                   "refs/pull/*/head:refs/remotes/origin/pr/*",
+			SparseCheckoutDirectories: "docs,cmd",
+			SSLVerify:                 false,
+			Branch:                    "release",
+			Message:                   "Bump version",
+			AuthorName:                "Robot",
+			AuthorEmail:               "robot@example.com",
+			PushPolicy:                GitPushPolicyForce,
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := NewGitResource(&PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "git-resource"},
+				Spec: PipelineResourceSpec{
+					Type:   PipelineResourceTypeGit,
+					Params: c.params,
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewGitResource() = %v", err)
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("Diff:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestNewGitResource_Invalid(t *testing.T) {
+	for _, p := range []Param{
+		{Name: "depth", Value: "shallow"},
+		{Name: "submodules", Value: "maybe"},
+		{Name: "sslVerify", Value: "sometimes"},
+		{Name: "pushPolicy", Value: "rebase"},
+	} {
+		t.Run(p.Name, func(t *testing.T) {
+			_, err := NewGitResource(&PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "git-resource"},
+				Spec: PipelineResourceSpec{
+					Type:   PipelineResourceTypeGit,
+					Params: []Param{p},
+				},
+			})
+			if err == nil {
+				t.Error("Expected error creating Git resource")
+			}
+		})
+	}
+}
+
+func TestGitResource_GetDownloadContainerSpec(t *testing.T) {
+	names.TestingSeed()
+	for _, c := range []struct {
+		desc        string
+		gitResource *GitResource
+		want        []string
+	}{{
+		desc: "defaults",
+		gitResource: &GitResource{
+			Name:       "git-resource",
+			URL:        "git@github.com:test/test.git",
+			Revision:   "master",
+			Depth:      1,
+			SSLVerify:  true,
+			TargetPath: "/workspace/src",
+		},
+		want: []string{"-url", "git@github.com:test/test.git", "-revision", "master", "-path", "/workspace/src", "-name", "git-resource"},
+	}, {
+		desc: "non default params",
+		gitResource: &GitResource{
+			Name:                      "git-resource",
+			URL:                       "git@github.com:test/test.git",
+			Revision:                  "pr/1",
+			Depth:                     0,
+			Submodules:                true,
+			Refspec:                   "refs/pull/*/head:refs/remotes/origin/pr/*",
+			SparseCheckoutDirectories: "docs,cmd",
+			TargetPath:                "/workspace/src",
+		},
+		want: []string{"-url", "git@github.com:test/test.git", "-revision", "pr/1", "-path", "/workspace/src", "-name", "git-resource",
+			"-depth", "0",
+			"-submodules",
+			"-refspec", "refs/pull/*/head:refs/remotes/origin/pr/*",
+			"-sparseCheckoutDirectories", "docs,cmd",
+			"-sslVerify=false",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := c.gitResource.GetDownloadContainerSpec()
+			if err != nil {
+				t.Fatalf("GetDownloadContainerSpec() = %v", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("Expected one container, got %d", len(got))
+			}
+			if d := cmp.Diff(c.want, got[0].Args); d != "" {
+				t.Errorf("Diff:\n%s", d)
+			}
+			if d := cmp.Diff([]string{"/ko-app/git-init"}, got[0].Command); d != "" {
+				t.Errorf("Diff:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestGitResource_GetUploadContainerSpec(t *testing.T) {
+	names.TestingSeed()
+	for _, c := range []struct {
+		desc        string
+		gitResource *GitResource
+		want        []string
+	}{{
+		desc: "defaults",
+		gitResource: &GitResource{
+			Name:       "git-resource",
+			URL:        "git@github.com:test/test.git",
+			Branch:     "release",
+			SSLVerify:  true,
+			TargetPath: "/workspace/src",
+		},
+		want: []string{"-mode", "upload",
+			"-url", "git@github.com:test/test.git",
+			"-branch", "release",
+			"-path", "/workspace/src",
+		},
+	}, {
+		desc: "all params",
+		gitResource: &GitResource{
+			Name:        "git-resource",
+			URL:         "git@github.com:test/test.git",
+			Branch:      "release",
+			Message:     "Bump to ${inputs.params.version}",
+			AuthorName:  "Robot",
+			AuthorEmail: "robot@example.com",
+			PushPolicy:  GitPushPolicyForce,
+			TargetPath:  "/workspace/src",
+		},
+		want: []string{"-mode", "upload",
+			"-url", "git@github.com:test/test.git",
+			"-branch", "release",
+			"-path", "/workspace/src",
+			"-message", "Bump to ${inputs.params.version}",
+			"-authorName", "Robot",
+			"-authorEmail", "robot@example.com",
+			"-force",
+			"-sslVerify=false",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := c.gitResource.GetUploadContainerSpec()
+			if err != nil {
+				t.Fatalf("GetUploadContainerSpec() = %v", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("Expected 1 container, got %d", len(got))
+			}
+			if d := cmp.Diff(c.want, got[0].Args); d != "" {
+				t.Errorf("Diff:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestGitResource_GetUploadContainerSpec_NoBranch(t *testing.T) {
+	r := &GitResource{Name: "git-resource", URL: "git@github.com:test/test.git"}
+	got, err := r.GetUploadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetUploadContainerSpec() = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Expected no containers without a branch, got %v", got)
+	}
+}
+
+func TestGitResource_Replacements(t *testing.T) {
+	r := &GitResource{
+		Name:       "git-resource",
+		Type:       PipelineResourceTypeGit,
+		URL:        "git@github.com:test/test.git",
+		Revision:   "master",
+		Depth:      1,
+		Submodules: true,
+		TargetPath: "/workspace/src",
+	}
+	want := map[string]string{
+		"name":                      "git-resource",
+		"type":                      "git",
+		"url":                       "git@github.com:test/test.git",
+		"revision":                  "master",
+		"depth":                     "1",
+		"submodules":                "true",
+		"refspec":                   "",
+		"sparseCheckoutDirectories": "",
+		"sslVerify":                 "false",
+		"branch":                    "",
+		"path":                      "/workspace/src",
+	}
+	if d := cmp.Diff(want, r.Replacements()); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
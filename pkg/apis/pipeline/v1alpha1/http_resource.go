@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// authorizationField is the SecretParam field name of the value of the
+	// Authorization header sent to the server.
+	authorizationField = "authorization"
+	// authorizationEnv is the environment variable the header is passed in.
+	authorizationEnv = "AUTHORIZATION"
+)
+
+var (
+	httpImage = flag.String("http-image", "override-with-http:latest",
+		"The container image containing our HTTP binary.")
+
+	// httpFormats are the formats of the content of a HTTP resource, empty
+	// being detected from the URL.
+	httpFormats = map[string]bool{"": true, "file": true, "tar": true, "tar.gz": true, "zip": true}
+	// httpChecksum matches the supported <algorithm>:<hex digest> checksums.
+	httpChecksum = regexp.MustCompile(`^(sha256:[0-9a-fA-F]{64}|sha512:[0-9a-fA-F]{128})$`)
+)
+
+// HTTPResource is a file or archive at a URL which is downloaded, verified and
+// unpacked into the workspace as an input, and to which the workspace is
+// uploaded with a HTTP PUT as an output.
+type HTTPResource struct {
+	Name string               `json:"name"`
+	Type PipelineResourceType `json:"type"`
+	URL  string               `json:"url"`
+	// Checksum is the <algorithm>:<hex digest> the downloaded content must
+	// match, sha256 and sha512 being supported.
+	Checksum string `json:"checksum"`
+	// Format is one of file, tar, tar.gz and zip, detected from the URL when
+	// empty.
+	Format         string `json:"format"`
+	DestinationDir string `json:"destinationDir"`
+	// Secrets holds the field name and corresponding secret of the
+	// Authorization header.
+	Secrets []SecretParam `json:"secrets"`
+}
+
+// NewHTTPResource creates a new HTTP resource to pass to a Task
+func NewHTTPResource(r *PipelineResource) (*HTTPResource, error) {
+	if r.Spec.Type != PipelineResourceTypeHTTP {
+		return nil, xerrors.Errorf("HTTPResource: Cannot create a HTTP resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	s := &HTTPResource{
+		Name:    r.Name,
+		Type:    r.Spec.Type,
+		Secrets: r.Spec.SecretParams,
+	}
+	for _, param := range r.Spec.Params {
+		switch {
+		case strings.EqualFold(param.Name, "URL"):
+			s.URL = param.Value
+		case strings.EqualFold(param.Name, "Checksum"):
+			s.Checksum = param.Value
+		case strings.EqualFold(param.Name, "Format"):
+			s.Format = param.Value
+		}
+	}
+	if !isHTTPURL(s.URL) {
+		return nil, xerrors.Errorf("HTTPResource: Need a http or https URL to be specified in order to create HTTP resource %s", r.Name)
+	}
+	if s.Checksum != "" && !httpChecksum.MatchString(s.Checksum) {
+		return nil, xerrors.Errorf("HTTPResource: Invalid checksum %q of HTTP resource %s, expected sha256:<hex digest> or sha512:<hex digest>", s.Checksum, r.Name)
+	}
+	if !httpFormats[s.Format] {
+		return nil, xerrors.Errorf("HTTPResource: Unsupported format %q of HTTP resource %s, expected file, tar, tar.gz or zip", s.Format, r.Name)
+	}
+	for _, secret := range s.Secrets {
+		if !strings.EqualFold(secret.FieldName, authorizationField) {
+			return nil, xerrors.Errorf("HTTPResource: Unsupported secret field %q for HTTP resource %s, only %s is supported", secret.FieldName, r.Name, authorizationField)
+		}
+	}
+	return s, nil
+}
+
+// GetName returns the name of the resource
+func (s HTTPResource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "http"
+func (s HTTPResource) GetType() PipelineResourceType {
+	return PipelineResourceTypeHTTP
+}
+
+// GetParams returns the resource params
+func (s HTTPResource) GetParams() []Param { return []Param{} }
+
+// Replacements is used for template replacement on a HTTPResource inside of a Taskrun.
+func (s *HTTPResource) Replacements() map[string]string {
+	return map[string]string{
+		"name":     s.Name,
+		"type":     string(s.Type),
+		"url":      s.URL,
+		"checksum": s.Checksum,
+		"format":   s.Format,
+		"path":     s.DestinationDir,
+	}
+}
+
+// SetDestinationDirectory sets the directory the content is downloaded to and
+// uploaded from.
+func (s *HTTPResource) SetDestinationDirectory(dir string) {
+	s.DestinationDir = dir
+}
+
+// GetDownloadContainerSpec returns the containers downloading the content of
+// the URL to the destination directory.
+func (s *HTTPResource) GetDownloadContainerSpec() ([]corev1.Container, error) {
+	if s.DestinationDir == "" {
+		return nil, xerrors.Errorf("HTTPResource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	return []corev1.Container{
+		CreateDirContainer(s.Name, s.DestinationDir),
+		s.container(fmt.Sprintf("fetch-%s", s.Name), "download"),
+	}, nil
+}
+
+// GetUploadContainerSpec returns the container uploading the destination
+// directory to the URL.
+func (s *HTTPResource) GetUploadContainerSpec() ([]corev1.Container, error) {
+	if s.DestinationDir == "" {
+		return nil, xerrors.Errorf("HTTPResource: Expect Destination Directory param to be set: %s", s.Name)
+	}
+	return []corev1.Container{s.container(fmt.Sprintf("upload-%s", s.Name), "upload")}, nil
+}
+
+func (s *HTTPResource) container(name, operation string) corev1.Container {
+	args := []string{"-operation", operation, "-url", s.URL, "-path", s.DestinationDir}
+	if s.Format != "" {
+		args = append(args, "-format", s.Format)
+	}
+	// The checksum is only known for the content being downloaded.
+	if s.Checksum != "" && operation == "download" {
+		args = append(args, "-checksum", s.Checksum)
+	}
+	var env []corev1.EnvVar
+	for _, secret := range s.Secrets {
+		env = append(env, corev1.EnvVar{
+			Name: authorizationEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.SecretName},
+					Key:                  secret.SecretKey,
+				},
+			},
+		})
+	}
+	return corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(name),
+		Image:   *httpImage,
+		Command: []string{"/ko-app/http"},
+		Args:    args,
+		Env:     env,
+	}
+}
+
+func isHTTPURL(rawURL string) bool {
+	u, err := url.ParseRequestURI(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
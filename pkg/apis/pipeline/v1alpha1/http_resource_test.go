@@ -0,0 +1,187 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testSHA256 = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestNewHTTPResource(t *testing.T) {
+	r := &PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-http",
+		},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeHTTP,
+			Params: []Param{{
+				Name:  "url",
+				Value: "https://example.com/release.tar.gz",
+			}, {
+				Name:  "checksum",
+				Value: testSHA256,
+			}, {
+				Name:  "format",
+				Value: "tar.gz",
+			}},
+			SecretParams: []SecretParam{{
+				FieldName:  "authorization",
+				SecretName: "release-token",
+				SecretKey:  "header",
+			}},
+		},
+	}
+	want := &HTTPResource{
+		Name:     "test-http",
+		Type:     PipelineResourceTypeHTTP,
+		URL:      "https://example.com/release.tar.gz",
+		Checksum: testSHA256,
+		Format:   "tar.gz",
+		Secrets:  r.Spec.SecretParams,
+	}
+
+	got, err := ResourceFromType(r, nil)
+	if err != nil {
+		t.Fatalf("ResourceFromType() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestNewHTTPResource_Invalid(t *testing.T) {
+	for _, r := range []*PipelineResource{{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-resource"},
+		Spec:       PipelineResourceSpec{Type: PipelineResourceTypeGit},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "no-url"},
+		Spec:       PipelineResourceSpec{Type: PipelineResourceTypeHTTP},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "relative-url"},
+		Spec: PipelineResourceSpec{
+			Type:   PipelineResourceTypeHTTP,
+			Params: []Param{{Name: "url", Value: "/release.tar.gz"}},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "short-checksum"},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeHTTP,
+			Params: []Param{
+				{Name: "url", Value: "https://example.com/release.tar.gz"},
+				{Name: "checksum", Value: "sha256:2cf24dba"},
+			},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "bad-format"},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeHTTP,
+			Params: []Param{
+				{Name: "url", Value: "https://example.com/release.rar"},
+				{Name: "format", Value: "rar"},
+			},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "bad-secret"},
+		Spec: PipelineResourceSpec{
+			Type:         PipelineResourceTypeHTTP,
+			Params:       []Param{{Name: "url", Value: "https://example.com/release.tar.gz"}},
+			SecretParams: []SecretParam{{FieldName: "password", SecretName: "s", SecretKey: "k"}},
+		},
+	}} {
+		t.Run(r.Name, func(t *testing.T) {
+			if _, err := NewHTTPResource(r); err == nil {
+				t.Error("Expected error creating HTTP resource")
+			}
+		})
+	}
+}
+
+func TestHTTPResource_GetContainerSpecs(t *testing.T) {
+	names.TestingSeed()
+	r := &HTTPResource{
+		Name:           "test-http",
+		Type:           PipelineResourceTypeHTTP,
+		URL:            "https://example.com/release.tar.gz",
+		Checksum:       testSHA256,
+		DestinationDir: "/workspace/release",
+		Secrets: []SecretParam{{
+			FieldName:  "authorization",
+			SecretName: "release-token",
+			SecretKey:  "header",
+		}},
+	}
+	env := []corev1.EnvVar{{
+		Name: "AUTHORIZATION",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "release-token"},
+				Key:                  "header",
+			},
+		},
+	}}
+
+	wantDownload := []corev1.Container{{
+		Name:    "create-dir-test-http-9l9zj",
+		Image:   "override-with-bash-noop:latest",
+		Command: []string{"/ko-app/bash"},
+		Args:    []string{"-args", "mkdir -p /workspace/release"},
+	}, {
+		Name:    "fetch-test-http-mz4c7",
+		Image:   "override-with-http:latest",
+		Command: []string{"/ko-app/http"},
+		Args:    []string{"-operation", "download", "-url", "https://example.com/release.tar.gz", "-path", "/workspace/release", "-checksum", testSHA256},
+		Env:     env,
+	}}
+	gotDownload, err := r.GetDownloadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetDownloadContainerSpec() = %v", err)
+	}
+	if d := cmp.Diff(wantDownload, gotDownload); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+
+	wantUpload := []corev1.Container{{
+		Name:    "upload-test-http-mssqb",
+		Image:   "override-with-http:latest",
+		Command: []string{"/ko-app/http"},
+		Args:    []string{"-operation", "upload", "-url", "https://example.com/release.tar.gz", "-path", "/workspace/release"},
+		Env:     env,
+	}}
+	gotUpload, err := r.GetUploadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetUploadContainerSpec() = %v", err)
+	}
+	if d := cmp.Diff(wantUpload, gotUpload); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestHTTPResource_GetContainerSpecs_NoDestination(t *testing.T) {
+	r := &HTTPResource{Name: "test-http", URL: "https://example.com/release.tar.gz"}
+	if _, err := r.GetDownloadContainerSpec(); err == nil {
+		t.Error("Expected error getting download containers without a destination directory")
+	}
+	if _, err := r.GetUploadContainerSpec(); err == nil {
+		t.Error("Expected error getting upload containers without a destination directory")
+	}
+}
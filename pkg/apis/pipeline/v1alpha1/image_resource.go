@@ -18,12 +18,19 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"flag"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/names"
 	"golang.org/x/xerrors"
 	corev1 "k8s.io/api/core/v1"
 )
 
+var (
+	imagePushImage = flag.String("imagepush-image", "override-with-imagepush:latest",
+		"The container image containing our image push binary.")
+)
+
 // NewImageResource creates a new ImageResource from a PipelineResource.
 func NewImageResource(r *PipelineResource) (*ImageResource, error) {
 	if r.Spec.Type != PipelineResourceTypeImage {
@@ -53,6 +60,9 @@ type ImageResource struct {
 	URL            string               `json:"url"`
 	Digest         string               `json:"digest"`
 	OutputImageDir string
+	// PushOutputImage is set when the OCI image layout written to
+	// OutputImageDir by the Task is pushed to URL after the steps ran.
+	PushOutputImage bool `json:"pushOutputImage,omitempty"`
 }
 
 // GetName returns the name of the resource
@@ -78,9 +88,25 @@ func (s *ImageResource) Replacements() map[string]string {
 	}
 }
 
-// GetUploadContainerSpec returns the spec for the upload container
+// GetUploadContainerSpec returns the spec for the upload container, which
+// pushes the OCI image layout written to OutputImageDir to URL when
+// PushOutputImage is set.
 func (s *ImageResource) GetUploadContainerSpec() ([]corev1.Container, error) {
-	return nil, nil
+	if !s.PushOutputImage {
+		return nil, nil
+	}
+	if s.URL == "" {
+		return nil, xerrors.Errorf("ImageResource: Expect URL param to be set to push %s", s.Name)
+	}
+	if s.OutputImageDir == "" {
+		return nil, xerrors.Errorf("ImageResource: Expect OutputImageDir to be set to push %s", s.Name)
+	}
+	return []corev1.Container{{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("image-push-" + s.Name),
+		Image:   *imagePushImage,
+		Command: []string{"/ko-app/imagepush"},
+		Args:    []string{"-name", s.Name, "-url", s.URL, "-path", s.OutputImageDir},
+	}}, nil
 }
 
 // GetDownloadContainerSpec returns the spec for the download container
@@ -31,6 +31,22 @@ type PipelineSpec struct {
 	// Params declares a list of input parameters that must be supplied when
 	// this Pipeline is run.
 	Params []PipelineParam `json:"params,omitempty"`
+	// RunRetention overrides when the completed PipelineRuns of the Pipeline
+	// are deleted, which defaults to the configuration of the cluster.
+	// +optional
+	RunRetention *RunRetention `json:"runRetention,omitempty"`
+}
+
+// RunRetention specifies when completed runs are deleted, with their TaskRuns
+// and pods. A run is deleted once either limit is reached.
+type RunRetention struct {
+	// TTL is how long a run is kept after it completed.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Keep is how many of the most recently completed runs are kept, at
+	// least one.
+	// +optional
+	Keep *int32 `json:"keep,omitempty"`
 }
 
 // PipelineStatus does not contain anything because Pipelines on their own
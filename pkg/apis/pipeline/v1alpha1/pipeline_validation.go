@@ -146,6 +146,24 @@ func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
 		return err
 	}
 
+	if ps.RunRetention != nil {
+		if err := ps.RunRetention.Validate(ctx, "spec.runRetention"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the run retention at path.
+func (r *RunRetention) Validate(ctx context.Context, path string) *apis.FieldError {
+	if r.TTL != nil && r.TTL.Duration <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", r.TTL.Duration.String()), fmt.Sprintf("%s.ttl", path))
+	}
+	// Keeping no run would delete the runs as soon as they complete.
+	if r.Keep != nil && *r.Keep <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be > 0", *r.Keep), fmt.Sprintf("%s.keep", path))
+	}
 	return nil
 }
 
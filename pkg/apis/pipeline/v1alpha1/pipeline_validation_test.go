@@ -19,12 +19,16 @@ package v1alpha1_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	tb "github.com/tektoncd/pipeline/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPipelineSpec_Validate_Error(t *testing.T) {
+	negativeKeep := int32(-1)
+	zeroKeep := int32(0)
 	tests := []struct {
 		name string
 		p    *v1alpha1.Pipeline
@@ -111,6 +115,27 @@ func TestPipelineSpec_Validate_Error(t *testing.T) {
 				tb.PipelineTask("bar", "bar", tb.RunAfter("foo")),
 			)),
 		},
+		{
+			name: "negative run retention ttl",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineRunRetention(&v1alpha1.RunRetention{TTL: &metav1.Duration{Duration: -time.Hour}}),
+			)),
+		},
+		{
+			name: "negative run retention keep",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineRunRetention(&v1alpha1.RunRetention{Keep: &negativeKeep}),
+			)),
+		},
+		{
+			name: "zero run retention keep",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineRunRetention(&v1alpha1.RunRetention{Keep: &zeroKeep}),
+			)),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -122,6 +147,7 @@ func TestPipelineSpec_Validate_Error(t *testing.T) {
 }
 
 func TestPipelineSpec_Validate_Valid(t *testing.T) {
+	keep := int32(10)
 	tests := []struct {
 		name string
 		p    *v1alpha1.Pipeline
@@ -172,6 +198,13 @@ func TestPipelineSpec_Validate_Valid(t *testing.T) {
 					tb.PipelineTaskParam("a-param", "${input.workspace.${baz}}")),
 			)),
 		},
+		{
+			name: "valid run retention",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineRunRetention(&v1alpha1.RunRetention{TTL: &metav1.Duration{Duration: time.Hour}, Keep: &keep}),
+			)),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -18,10 +18,13 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/knative/pkg/apis"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func (r *PipelineResource) Validate(ctx context.Context) *apis.FieldError {
@@ -36,40 +39,14 @@ func (rs *PipelineResourceSpec) Validate(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(rs, &PipelineResourceSpec{}) {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
+	// Params referencing the params of PipelineRuns and TaskRuns can only be
+	// checked once the reconcilers have resolved them.
+	if len(rs.ParamReferences()) > 0 {
+		return validatePipelineResourceType(rs.Type)
+	}
 	if rs.Type == PipelineResourceTypeCluster {
-		var usernameFound, cadataFound, nameFound bool
-		for _, param := range rs.Params {
-			switch {
-			case strings.EqualFold(param.Name, "URL"):
-				if err := validateURL(param.Value, "URL"); err != nil {
-					return err
-				}
-			case strings.EqualFold(param.Name, "Username"):
-				usernameFound = true
-			case strings.EqualFold(param.Name, "CAData"):
-				cadataFound = true
-			case strings.EqualFold(param.Name, "name"):
-				nameFound = true
-			}
-		}
-
-		for _, secret := range rs.SecretParams {
-			switch {
-			case strings.EqualFold(secret.FieldName, "Username"):
-				usernameFound = true
-			case strings.EqualFold(secret.FieldName, "CAData"):
-				cadataFound = true
-			}
-		}
-
-		if !nameFound {
-			return apis.ErrMissingField("name param")
-		}
-		if !usernameFound {
-			return apis.ErrMissingField("username param")
-		}
-		if !cadataFound {
-			return apis.ErrMissingField("CAData param")
+		if err := validateClusterResource(rs); err != nil {
+			return err
 		}
 	}
 	if rs.Type == PipelineResourceTypeStorage {
@@ -95,13 +72,124 @@ func (rs *PipelineResourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if rs.Type == PipelineResourceTypeGit {
+		for _, param := range rs.Params {
+			switch {
+			case strings.EqualFold(param.Name, "Depth"):
+				if _, err := strconv.ParseUint(param.Value, 10, 32); err != nil {
+					return apis.ErrInvalidValue(param.Value, "spec.params.depth")
+				}
+			case strings.EqualFold(param.Name, "Submodules"):
+				if _, err := strconv.ParseBool(param.Value); err != nil {
+					return apis.ErrInvalidValue(param.Value, "spec.params.submodules")
+				}
+			case strings.EqualFold(param.Name, "SSLVerify"):
+				if _, err := strconv.ParseBool(param.Value); err != nil {
+					return apis.ErrInvalidValue(param.Value, "spec.params.sslVerify")
+				}
+			case strings.EqualFold(param.Name, "PushPolicy"):
+				if !isGitPushPolicy(param.Value) {
+					return apis.ErrInvalidValue(param.Value, "spec.params.pushPolicy")
+				}
+			}
+		}
+	}
+
+	if rs.Type == PipelineResourceTypePullRequest {
+		var url string
+		for _, param := range rs.Params {
+			if strings.EqualFold(param.Name, "URL") {
+				url = param.Value
+			}
+		}
+		if url == "" {
+			return apis.ErrMissingField("spec.params.url")
+		}
+		if err := validateURL(url, "spec.params.url"); err != nil {
+			return err
+		}
+	}
+
+	if rs.Type == PipelineResourceTypeHTTP {
+		var url string
+		for _, param := range rs.Params {
+			switch {
+			case strings.EqualFold(param.Name, "URL"):
+				url = param.Value
+			case strings.EqualFold(param.Name, "Checksum"):
+				if !httpChecksum.MatchString(param.Value) {
+					return apis.ErrInvalidValue(param.Value, "spec.params.checksum")
+				}
+			case strings.EqualFold(param.Name, "Format"):
+				if !httpFormats[param.Value] {
+					return apis.ErrInvalidValue(param.Value, "spec.params.format")
+				}
+			}
+		}
+		if url == "" {
+			return apis.ErrMissingField("spec.params.url")
+		}
+		if !isHTTPURL(url) {
+			return apis.ErrInvalidValue(url, "spec.params.url")
+		}
+	}
+
+	if rs.Type == PipelineResourceTypeCloudEvent {
+		var targetURI string
+		for _, param := range rs.Params {
+			if strings.EqualFold(param.Name, "TargetURI") {
+				targetURI = param.Value
+			}
+		}
+		if targetURI == "" {
+			return apis.ErrMissingField("spec.params.targetURI")
+		}
+		if !isHTTPURL(targetURI) {
+			return apis.ErrInvalidValue(targetURI, "spec.params.targetURI")
+		}
+	}
+
+	if rs.Type == PipelineResourceTypeChart {
+		var url, version string
+		for _, param := range rs.Params {
+			switch {
+			case strings.EqualFold(param.Name, "URL"):
+				url = param.Value
+			case strings.EqualFold(param.Name, "Version"):
+				version = param.Value
+			}
+		}
+		if url == "" {
+			return apis.ErrMissingField("spec.params.url")
+		}
+		if !isChartURL(url) {
+			return apis.ErrInvalidValue(url, "spec.params.url")
+		}
+		if version == "" {
+			return apis.ErrMissingField("spec.params.version")
+		}
+		for _, secret := range rs.SecretParams {
+			if _, ok := chartSecretEnvs[strings.ToLower(secret.FieldName)]; !ok {
+				return apis.ErrInvalidValue(secret.FieldName, "spec.secrets.fieldName")
+			}
+		}
+	}
+
+	return validatePipelineResourceType(rs.Type)
+}
+
+func validatePipelineResourceType(resourceType PipelineResourceType) *apis.FieldError {
+	// Custom types can only be resolved by the controller.
+	if IsCustomResourceType(resourceType) {
+		return nil
+	}
 	for _, allowedType := range AllResourceTypes {
-		if allowedType == rs.Type {
+		if allowedType == resourceType {
 			return nil
 		}
 	}
 
-	return apis.ErrInvalidValue("spec.type", string(rs.Type))
+	return apis.ErrInvalidValue("spec.type", string(resourceType))
 }
 
 func allowedStorageType(gotType string) bool {
@@ -110,6 +198,160 @@ func allowedStorageType(gotType string) bool {
 		return true
 	case string(PipelineResourceTypeBuildGCS):
 		return true
+	case string(PipelineResourceTypeS3):
+		return true
 	}
 	return false
 }
+
+// validateClusterResource checks a cluster resource has a name, a CA
+// certificate and a single way of authenticating its user.
+func validateClusterResource(rs *PipelineResourceSpec) *apis.FieldError {
+	var usernameFound, cadataFound, nameFound, tokenFound, passwordFound, certFound, keyFound, execFound, execOptionFound bool
+	var name, contexts string
+	for _, param := range rs.Params {
+		switch {
+		case strings.EqualFold(param.Name, "URL"):
+			if err := validateURL(param.Value, "URL"); err != nil {
+				return err
+			}
+		case strings.EqualFold(param.Name, "Username"):
+			usernameFound = true
+		case strings.EqualFold(param.Name, "CAData"):
+			cadataFound = true
+		case strings.EqualFold(param.Name, "name"):
+			nameFound = true
+			name = param.Value
+		case strings.EqualFold(param.Name, "Token"):
+			tokenFound = true
+		case strings.EqualFold(param.Name, "Password"):
+			passwordFound = true
+		case strings.EqualFold(param.Name, "ClientCertificateData"):
+			certFound = true
+		case strings.EqualFold(param.Name, "ClientKeyData"):
+			keyFound = true
+		case strings.EqualFold(param.Name, "ExecCommand"):
+			execFound = param.Value != ""
+		case strings.EqualFold(param.Name, "ExecArgs"), strings.EqualFold(param.Name, "ExecAPIVersion"):
+			execOptionFound = true
+		case strings.EqualFold(param.Name, "ExecEnv"):
+			execOptionFound = true
+			if _, err := parseClusterExecEnv(param.Value); err != nil {
+				return apis.ErrInvalidValue(param.Value, "execEnv param")
+			}
+		case strings.EqualFold(param.Name, "Namespace"):
+			if errs := validation.IsDNS1123Label(param.Value); len(errs) > 0 {
+				return apis.ErrInvalidValue(param.Value, "namespace param")
+			}
+		case strings.EqualFold(param.Name, "Contexts"):
+			contexts = param.Value
+		}
+	}
+
+	for _, secret := range rs.SecretParams {
+		switch {
+		case strings.EqualFold(secret.FieldName, "Username"):
+			usernameFound = true
+		case strings.EqualFold(secret.FieldName, "CAData"):
+			cadataFound = true
+		case strings.EqualFold(secret.FieldName, "Token"):
+			tokenFound = true
+		case strings.EqualFold(secret.FieldName, "Password"):
+			passwordFound = true
+		case strings.EqualFold(secret.FieldName, "ClientCertificateData"):
+			certFound = true
+		case strings.EqualFold(secret.FieldName, "ClientKeyData"):
+			keyFound = true
+		}
+	}
+
+	if !nameFound {
+		return apis.ErrMissingField("name param")
+	}
+	if err := validateClusterContexts(name, contexts); err != nil {
+		return err
+	}
+	if certFound && !keyFound {
+		return apis.ErrMissingField("clientKeyData param")
+	}
+	if keyFound && !certFound {
+		return apis.ErrMissingField("clientCertificateData param")
+	}
+	if execOptionFound && !execFound {
+		return apis.ErrMissingField("execCommand param")
+	}
+	// A token takes precedence over a password, but the user can't be
+	// authenticated with a token or password together with a client
+	// certificate or a credential plugin.
+	var authPaths []string
+	if tokenFound || passwordFound {
+		authPaths = append(authPaths, "token param", "password param")
+	}
+	if certFound {
+		authPaths = append(authPaths, "clientCertificateData param")
+	}
+	if execFound {
+		authPaths = append(authPaths, "execCommand param")
+	}
+	if (tokenFound || passwordFound) && (certFound || execFound) || certFound && execFound {
+		return apis.ErrMultipleOneOf(authPaths...)
+	}
+	if !usernameFound && !certFound && !execFound {
+		return apis.ErrMissingField("username param")
+	}
+	if !cadataFound {
+		return apis.ErrMissingField("CAData param")
+	}
+	return nil
+}
+
+// validateClusterContexts checks the additional contexts of a cluster resource
+// are well formed, in valid namespaces and have different names than each
+// other and than the context named after the cluster.
+func validateClusterContexts(cluster, value string) *apis.FieldError {
+	contexts, err := parseClusterContexts(value)
+	if err != nil {
+		return apis.ErrInvalidValue(value, "contexts param")
+	}
+	names := map[string]bool{cluster: true}
+	for _, c := range contexts {
+		if errs := validation.IsDNS1123Label(c.Namespace); len(errs) > 0 {
+			return apis.ErrInvalidValue(c.Namespace, "contexts param")
+		}
+		if names[c.Name] {
+			return apis.ErrMultipleOneOf("contexts param")
+		}
+		names[c.Name] = true
+	}
+	return nil
+}
+
+// validateResourceBindings validates that
+//	1. resource is not declared more than once
+//	2. if both resource reference and resource spec is defined at the same time
+//	3. at least resource ref or resource spec is defined
+func validateResourceBindings(ctx context.Context, resources []PipelineResourceBinding, path string) *apis.FieldError {
+	encountered := map[string]struct{}{}
+	for _, r := range resources {
+		// We should provide only one binding for each declared resource.
+		name := strings.ToLower(r.Name)
+		if _, ok := encountered[name]; ok {
+			return apis.ErrMultipleOneOf(path)
+		}
+		encountered[name] = struct{}{}
+		// Check that both resource ref and resource Spec are not present
+		if r.ResourceRef.Name != "" && r.ResourceSpec != nil {
+			return apis.ErrDisallowedFields(fmt.Sprintf("%s.ResourceRef", path), fmt.Sprintf("%s.ResourceSpec", path))
+		}
+		// Check that one of resource ref and resource Spec is present
+		if r.ResourceRef.Name == "" && r.ResourceSpec == nil {
+			return apis.ErrMissingField(fmt.Sprintf("%s.ResourceRef", path), fmt.Sprintf("%s.ResourceSpec", path))
+		}
+		if r.ResourceSpec != nil {
+			if err := r.ResourceSpec.Validate(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
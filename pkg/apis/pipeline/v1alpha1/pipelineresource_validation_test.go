@@ -201,6 +201,282 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 			},
 			want: apis.ErrMissingField("spec.params.location"),
+		}, {
+			name: "git with negative depth",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "git-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeGit,
+					Params: []Param{{
+						Name:  "url",
+						Value: "https://github.com/tektoncd/pipeline.git",
+					}, {
+						Name:  "depth",
+						Value: "-1",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("-1", "spec.params.depth"),
+		}, {
+			name: "git with invalid submodules",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "git-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeGit,
+					Params: []Param{{
+						Name:  "submodules",
+						Value: "maybe",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("maybe", "spec.params.submodules"),
+		}, {
+			name: "git with invalid sslVerify",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "git-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeGit,
+					Params: []Param{{
+						Name:  "sslVerify",
+						Value: "no-thanks",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("no-thanks", "spec.params.sslVerify"),
+		}, {
+			name: "git with invalid pushPolicy",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "git-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeGit,
+					Params: []Param{{
+						Name:  "pushPolicy",
+						Value: "rebase",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("rebase", "spec.params.pushPolicy"),
+		}, {
+			name: "pull request without url",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pr-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypePullRequest,
+					Params: []Param{{
+						Name:  "provider",
+						Value: "github",
+					}},
+				},
+			},
+			want: apis.ErrMissingField("spec.params.url"),
+		}, {
+			name: "http without url",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "http-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeHTTP,
+					Params: []Param{{
+						Name:  "format",
+						Value: "zip",
+					}},
+				},
+			},
+			want: apis.ErrMissingField("spec.params.url"),
+		}, {
+			name: "http with ftp url",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "http-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeHTTP,
+					Params: []Param{{
+						Name:  "url",
+						Value: "ftp://example.com/release.zip",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("ftp://example.com/release.zip", "spec.params.url"),
+		}, {
+			name: "http with invalid checksum",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "http-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeHTTP,
+					Params: []Param{{
+						Name:  "checksum",
+						Value: "md5:5d41402abc4b2a76b9719d911017c592",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("md5:5d41402abc4b2a76b9719d911017c592", "spec.params.checksum"),
+		}, {
+			name: "http with invalid format",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "http-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeHTTP,
+					Params: []Param{{
+						Name:  "format",
+						Value: "rar",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("rar", "spec.params.format"),
+		}, {
+			name: "chart without url",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "chart-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type:   PipelineResourceTypeChart,
+					Params: []Param{{Name: "version", Value: "1.2.3"}},
+				},
+			},
+			want: apis.ErrMissingField("spec.params.url"),
+		}, {
+			name: "chart with git url",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "chart-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeChart,
+					Params: []Param{
+						{Name: "url", Value: "git@github.com:example/charts.git"},
+						{Name: "version", Value: "1.2.3"},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("git@github.com:example/charts.git", "spec.params.url"),
+		}, {
+			name: "chart without version",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "chart-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type:   PipelineResourceTypeChart,
+					Params: []Param{{Name: "url", Value: "oci://registry.example.com/charts"}},
+				},
+			},
+			want: apis.ErrMissingField("spec.params.version"),
+		}, {
+			name: "chart with unsupported secret",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "chart-resource",
+				},
+				Spec: PipelineResourceSpec{
+					Type: PipelineResourceTypeChart,
+					Params: []Param{
+						{Name: "url", Value: "https://charts.example.com"},
+						{Name: "version", Value: "1.2.3"},
+					},
+					SecretParams: []SecretParam{{FieldName: "token", SecretName: "s", SecretKey: "k"}},
+				},
+			},
+			want: apis.ErrInvalidValue("token", "spec.secrets.fieldName"),
+		}, {
+			name: "cluster with client certificate without key",
+			res:  clusterResource(Param{Name: "clientCertificateData", Value: "Y2VydAo="}),
+			want: apis.ErrMissingField("clientKeyData param"),
+		}, {
+			name: "cluster with client key without certificate",
+			res: withSecrets(clusterResource(), SecretParam{
+				FieldName:  "clientKeyData",
+				SecretName: "cluster-creds",
+				SecretKey:  "key",
+			}),
+			want: apis.ErrMissingField("clientCertificateData param"),
+		}, {
+			name: "cluster with token and client certificate",
+			res: withSecrets(clusterResource(
+				Param{Name: "clientCertificateData", Value: "Y2VydAo="},
+				Param{Name: "clientKeyData", Value: "a2V5Cg=="},
+			), SecretParam{
+				FieldName:  "token",
+				SecretName: "cluster-creds",
+				SecretKey:  "token",
+			}),
+			want: apis.ErrMultipleOneOf("token param", "password param", "clientCertificateData param"),
+		}, {
+			name: "cluster with client certificate and credential plugin",
+			res: clusterResource(
+				Param{Name: "clientCertificateData", Value: "Y2VydAo="},
+				Param{Name: "clientKeyData", Value: "a2V5Cg=="},
+				Param{Name: "execCommand", Value: "aws-iam-authenticator"},
+			),
+			want: apis.ErrMultipleOneOf("clientCertificateData param", "execCommand param"),
+		}, {
+			name: "cluster with credential plugin args without command",
+			res: clusterResource(
+				Param{Name: "username", Value: "admin"},
+				Param{Name: "execArgs", Value: "token -i prod"},
+			),
+			want: apis.ErrMissingField("execCommand param"),
+		}, {
+			name: "cluster with invalid credential plugin env",
+			res: clusterResource(
+				Param{Name: "execCommand", Value: "aws-iam-authenticator"},
+				Param{Name: "execEnv", Value: "=ci"},
+			),
+			want: apis.ErrInvalidValue("=ci", "execEnv param"),
+		}, {
+			name: "cluster with invalid namespace",
+			res: clusterResource(
+				Param{Name: "username", Value: "admin"},
+				Param{Name: "namespace", Value: "Not_A_Namespace"},
+			),
+			want: apis.ErrInvalidValue("Not_A_Namespace", "namespace param"),
+		}, {
+			name: "cluster with context without namespace",
+			res: clusterResource(
+				Param{Name: "username", Value: "admin"},
+				Param{Name: "contexts", Value: "monitoring"},
+			),
+			want: apis.ErrInvalidValue("monitoring", "contexts param"),
+		}, {
+			name: "cluster with context named after the cluster",
+			res: clusterResource(
+				Param{Name: "username", Value: "admin"},
+				Param{Name: "contexts", Value: "test-cluster-resource=monitoring"},
+			),
+			want: apis.ErrMultipleOneOf("contexts param"),
+		}, {
+			name: "cloud event without target uri",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "cloud-event"},
+				Spec:       PipelineResourceSpec{Type: PipelineResourceTypeCloudEvent},
+			},
+			want: apis.ErrMissingField("spec.params.targetURI"),
+		}, {
+			name: "cloud event with relative target uri",
+			res: PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "cloud-event"},
+				Spec: PipelineResourceSpec{
+					Type:   PipelineResourceTypeCloudEvent,
+					Params: []Param{{Name: "targetURI", Value: "/sink"}},
+				},
+			},
+			want: apis.ErrInvalidValue("/sink", "spec.params.targetURI"),
 		}, {
 			name: "invalid resoure type",
 			res: PipelineResource{
@@ -224,6 +500,36 @@ func TestResourceValidation_Invalid(t *testing.T) {
 	}
 }
 
+// clusterResource returns a cluster resource with a name, url and CA
+// certificate, and params.
+func clusterResource(params ...Param) PipelineResource {
+	return PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster-resource",
+			Namespace: "foo",
+		},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeCluster,
+			Params: append([]Param{{
+				Name:  "name",
+				Value: "test-cluster-resource",
+			}, {
+				Name:  "url",
+				Value: "http://10.10.10.10",
+			}, {
+				Name:  "cadata",
+				Value: "bXktY2x1c3Rlci1jZXJ0Cg",
+			}}, params...),
+		},
+	}
+}
+
+// withSecrets returns r populated from secrets.
+func withSecrets(r PipelineResource, secrets ...SecretParam) PipelineResource {
+	r.Spec.SecretParams = secrets
+	return r
+}
+
 func TestClusterResourceValidation_Valid(t *testing.T) {
 	res := &PipelineResource{
 		ObjectMeta: metav1.ObjectMeta{
@@ -256,6 +562,95 @@ func TestClusterResourceValidation_Valid(t *testing.T) {
 	}
 }
 
+func TestClusterResourceValidation_ValidAuthentication(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		res  PipelineResource
+	}{{
+		name: "client certificate from secrets",
+		res: withSecrets(clusterResource(Param{Name: "namespace", Value: "deploy"}), SecretParam{
+			FieldName:  "clientCertificateData",
+			SecretName: "cluster-creds",
+			SecretKey:  "cert",
+		}, SecretParam{
+			FieldName:  "clientKeyData",
+			SecretName: "cluster-creds",
+			SecretKey:  "key",
+		}),
+	}, {
+		name: "credential plugin with contexts",
+		res: clusterResource(
+			Param{Name: "execCommand", Value: "aws-iam-authenticator"},
+			Param{Name: "execArgs", Value: "token -i prod"},
+			Param{Name: "execEnv", Value: "AWS_PROFILE=ci"},
+			Param{Name: "contexts", Value: "monitoring=prometheus,ingress=ingress-nginx"},
+		),
+	}, {
+		name: "token and password",
+		res: clusterResource(
+			Param{Name: "username", Value: "admin"},
+			Param{Name: "password", Value: "pass"},
+			Param{Name: "token", Value: "my-token"},
+		),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.res.Validate(context.Background()); err != nil {
+				t.Errorf("Unexpected PipelineResource.Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCustomResourceValidation_Valid(t *testing.T) {
+	res := &PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-chart",
+			Namespace: "foo",
+		},
+		Spec: PipelineResourceSpec{
+			Type: "helm.example.com",
+			Params: []Param{{
+				Name:  "chart",
+				Value: "stable/mysql",
+			}},
+		},
+	}
+	if err := res.Validate(context.Background()); err != nil {
+		t.Errorf("Unexpected PipelineResource.Validate() error = %v", err)
+	}
+}
+
+func TestParameterizedResourceValidation(t *testing.T) {
+	res := &PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source",
+			Namespace: "foo",
+		},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeGit,
+			Params: []Param{{
+				Name:  "url",
+				Value: "https://github.com/tektoncd/pipeline",
+			}, {
+				Name:  "revision",
+				Value: "${params.revision}",
+			}, {
+				Name:  "depth",
+				Value: "${params.depth}",
+			}},
+		},
+	}
+	if err := res.Validate(context.Background()); err != nil {
+		t.Errorf("Unexpected PipelineResource.Validate() error = %v", err)
+	}
+
+	res.Spec.Type = "not-a-type"
+	want := apis.ErrInvalidValue("spec.type", "not-a-type")
+	if d := cmp.Diff(want.Error(), res.Validate(context.Background()).Error()); d != "" {
+		t.Errorf("PipelineResource.Validate() (-want, +got) = %v", d)
+	}
+}
+
 func TestAllowedGCSStorageType(t *testing.T) {
 	tests := []struct {
 		name        string
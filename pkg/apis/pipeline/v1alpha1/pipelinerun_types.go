@@ -24,6 +24,7 @@ import (
 	"github.com/knative/pkg/apis"
 	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -68,6 +69,55 @@ type PipelineRunSpec struct {
 	// If specified, the pod's scheduling constraints
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PodTemplate holds pod-level configuration, such as the security
+	// context or image pull secrets, for the pods that run the Pipeline's TaskRuns.
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+	// ArtifactRetention overrides how long the artifact storage of the
+	// PipelineRun is kept once it is done, which defaults to the policy
+	// configured for the cluster.
+	// +optional
+	ArtifactRetention *ArtifactRetention `json:"artifactRetention,omitempty"`
+	// ArtifactQuota is the maximum size of the artifacts the TaskRuns of the
+	// PipelineRun may store, which defaults to the quota configured for the
+	// cluster. The PipelineRun fails once its artifacts exceed it.
+	// +optional
+	ArtifactQuota *resource.Quantity `json:"artifactQuota,omitempty"`
+	// TTL is how long the PipelineRun is kept once it completed before it is
+	// deleted with its TaskRuns, which defaults to the TTL of the runs of its
+	// Pipeline.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// ArtifactRetentionPolicy decides whether the artifact storage of a
+// PipelineRun is kept once it is done.
+type ArtifactRetentionPolicy string
+
+const (
+	// ArtifactRetentionDelete deletes the artifact storage as soon as the
+	// PipelineRun is done.
+	ArtifactRetentionDelete ArtifactRetentionPolicy = "Delete"
+	// ArtifactRetentionKeepOnFailure keeps the artifact storage of failed
+	// PipelineRuns for the TTL and deletes the others as soon as they are done.
+	ArtifactRetentionKeepOnFailure ArtifactRetentionPolicy = "KeepOnFailure"
+	// ArtifactRetentionKeep keeps the artifact storage of all PipelineRuns for
+	// the TTL.
+	ArtifactRetentionKeep ArtifactRetentionPolicy = "Keep"
+)
+
+// ArtifactRetention specifies how long the artifact storage of a PipelineRun,
+// its PersistentVolumeClaim or its prefix of the bucket, is kept once it is
+// done.
+type ArtifactRetention struct {
+	// Policy decides whether the storage is kept.
+	// +optional
+	Policy ArtifactRetentionPolicy `json:"policy,omitempty"`
+	// TTL is how long kept storage is kept after the PipelineRun completed.
+	// When it is unset, a kept PersistentVolumeClaim is only deleted with the
+	// PipelineRun and a kept prefix of the bucket is never deleted.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
 }
 
 // PipelineRunSpecStatus defines the pipelinerun spec status the user can provide
@@ -117,6 +167,21 @@ type PipelineRunStatus struct {
 	// map of PipelineRunTaskRunStatus with the taskRun name as the key
 	// +optional
 	TaskRuns map[string]*PipelineRunTaskRunStatus `json:"taskRuns,omitempty"`
+
+	// ArtifactStorage reports the artifacts stored by the TaskRuns.
+	// +optional
+	ArtifactStorage *ArtifactStorageStatus `json:"artifactStorage,omitempty"`
+}
+
+// ArtifactStorageStatus reports the size of the artifacts stored by the
+// TaskRuns of a PipelineRun, as reported by their artifact copy steps.
+type ArtifactStorageStatus struct {
+	// Size is the total size in bytes of the artifacts stored.
+	Size int64 `json:"size"`
+	// Resources is the size in bytes of the artifacts stored for each
+	// output resource, by name.
+	// +optional
+	Resources map[string]int64 `json:"resources,omitempty"`
 }
 
 // PipelineRunTaskRunStatus contains the name of the PipelineTask for this TaskRun and the TaskRun's Status
@@ -42,6 +42,10 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		return apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name")
 	}
 
+	if err := validateResourceBindings(ctx, ps.Resources, "spec.resources"); err != nil {
+		return err
+	}
+
 	// check for results
 	if ps.Results != nil {
 		if err := ps.Results.Validate(ctx, "spec.results"); err != nil {
@@ -56,5 +60,32 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ps.ArtifactRetention != nil {
+		if err := ps.ArtifactRetention.Validate(ctx, "spec.artifactRetention"); err != nil {
+			return err
+		}
+	}
+
+	if ps.ArtifactQuota != nil && ps.ArtifactQuota.Sign() <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", ps.ArtifactQuota.String()), "spec.artifactQuota")
+	}
+
+	if ps.TTL != nil && ps.TTL.Duration <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", ps.TTL.Duration.String()), "spec.ttl")
+	}
+
+	return nil
+}
+
+// Validate validates the artifact retention at path.
+func (r *ArtifactRetention) Validate(ctx context.Context, path string) *apis.FieldError {
+	switch r.Policy {
+	case "", ArtifactRetentionDelete, ArtifactRetentionKeepOnFailure, ArtifactRetentionKeep:
+	default:
+		return apis.ErrInvalidValue(string(r.Policy), fmt.Sprintf("%s.policy", path))
+	}
+	if r.TTL != nil && r.TTL.Duration <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", r.TTL.Duration.String()), fmt.Sprintf("%s.ttl", path))
+	}
 	return nil
 }
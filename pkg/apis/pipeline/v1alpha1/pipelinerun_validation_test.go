@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/knative/pkg/apis"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -76,6 +77,144 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 				},
 			},
 			want: apis.ErrInvalidValue("-48h0m0s should be > 0", "spec.timeout"),
+		}, {
+			name: "resource bound twice",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					Resources: []PipelineResourceBinding{{
+						Name:        "source",
+						ResourceRef: PipelineResourceRef{Name: "source-repo"},
+					}, {
+						Name:        "source",
+						ResourceRef: PipelineResourceRef{Name: "other-repo"},
+					}},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.resources"),
+		}, {
+			name: "resource with both ref and spec",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					Resources: []PipelineResourceBinding{{
+						Name:        "source",
+						ResourceRef: PipelineResourceRef{Name: "source-repo"},
+						ResourceSpec: &PipelineResourceSpec{
+							Type: PipelineResourceTypeGit,
+						},
+					}},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.resources.ResourceRef", "spec.resources.ResourceSpec"),
+		}, {
+			name: "resource without ref or spec",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					Resources: []PipelineResourceBinding{{
+						Name: "source",
+					}},
+				},
+			},
+			want: apis.ErrMissingField("spec.resources.ResourceRef", "spec.resources.ResourceSpec"),
+		}, {
+			name: "invalid embedded resource",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					Resources: []PipelineResourceBinding{{
+						Name: "source",
+						ResourceSpec: &PipelineResourceSpec{
+							Type: PipelineResourceTypeGit,
+							Params: []Param{{
+								Name:  "depth",
+								Value: "shallow",
+							}},
+						},
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("shallow", "spec.params.depth"),
+		}, {
+			name: "invalid artifact retention policy",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					ArtifactRetention: &ArtifactRetention{Policy: "KeepForever"},
+				},
+			},
+			want: apis.ErrInvalidValue("KeepForever", "spec.artifactRetention.policy"),
+		}, {
+			name: "negative artifact retention ttl",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					ArtifactRetention: &ArtifactRetention{
+						Policy: ArtifactRetentionKeep,
+						TTL:    &metav1.Duration{Duration: -time.Hour},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("-1h0m0s should be > 0", "spec.artifactRetention.ttl"),
+		}, {
+			name: "zero artifact quota",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					ArtifactQuota: resource.NewQuantity(0, resource.BinarySI),
+				},
+			},
+			want: apis.ErrInvalidValue("0 should be > 0", "spec.artifactQuota"),
+		}, {
+			name: "negative ttl",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					TTL: &metav1.Duration{Duration: -time.Hour},
+				},
+			},
+			want: apis.ErrInvalidValue("-1h0m0s should be > 0", "spec.ttl"),
 		},
 	}
 
@@ -102,6 +241,25 @@ func TestPipelineRun_Validate(t *testing.T) {
 				URL:  "http://www.google.com",
 				Type: "gcs",
 			},
+			Resources: []PipelineResourceBinding{{
+				Name:        "source",
+				ResourceRef: PipelineResourceRef{Name: "source-repo"},
+			}, {
+				Name: "image",
+				ResourceSpec: &PipelineResourceSpec{
+					Type: PipelineResourceTypeImage,
+					Params: []Param{{
+						Name:  "url",
+						Value: "gcr.io/${params.project}/app",
+					}},
+				},
+			}},
+			ArtifactRetention: &ArtifactRetention{
+				Policy: ArtifactRetentionKeepOnFailure,
+				TTL:    &metav1.Duration{Duration: 24 * time.Hour},
+			},
+			ArtifactQuota: resource.NewQuantity(1<<30, resource.BinarySI),
+			TTL:           &metav1.Duration{Duration: 7 * 24 * time.Hour},
 		},
 	}
 	if err := tr.Validate(context.Background()); err != nil {
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodTemplate holds pod-level configuration applied to the pod that runs a
+// TaskRun.
+type PodTemplate struct {
+	// SecurityContext holds pod-level security attributes and common
+	// container settings.
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+	// If specified, indicates the pod's priority. The priority class must
+	// exist in the cluster.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// RuntimeClassName refers to a RuntimeClass object in the node.k8s.io
+	// group, which should be used to run the pod.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// Set DNS policy for the pod. Defaults to "ClusterFirst".
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// Specifies the DNS parameters of the pod, merged with the
+	// configuration generated from DNSPolicy.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// HostAliases is a list of hosts and IPs that will be injected into the
+	// pod's hosts file.
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+	// ImagePullSecrets is a list of references to secrets in the same
+	// namespace to use for pulling the images of the pod.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// MergePodTemplateWithDefault returns a PodTemplate with the fields that are
+// not set in tpl taken from defaultTpl. Either argument may be nil.
+func MergePodTemplateWithDefault(tpl, defaultTpl *PodTemplate) *PodTemplate {
+	switch {
+	case defaultTpl == nil:
+		return tpl
+	case tpl == nil:
+		return defaultTpl
+	}
+	merged := tpl.DeepCopy()
+	if merged.SecurityContext == nil {
+		merged.SecurityContext = defaultTpl.SecurityContext
+	}
+	if merged.PriorityClassName == "" {
+		merged.PriorityClassName = defaultTpl.PriorityClassName
+	}
+	if merged.RuntimeClassName == nil {
+		merged.RuntimeClassName = defaultTpl.RuntimeClassName
+	}
+	if merged.DNSPolicy == "" {
+		merged.DNSPolicy = defaultTpl.DNSPolicy
+	}
+	if merged.DNSConfig == nil {
+		merged.DNSConfig = defaultTpl.DNSConfig
+	}
+	if merged.HostAliases == nil {
+		merged.HostAliases = defaultTpl.HostAliases
+	}
+	if merged.ImagePullSecrets == nil {
+		merged.ImagePullSecrets = defaultTpl.ImagePullSecrets
+	}
+	return merged
+}
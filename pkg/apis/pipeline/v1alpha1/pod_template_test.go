@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMergePodTemplateWithDefault(t *testing.T) {
+	runtimeClass := "gvisor"
+	defaultTpl := &v1alpha1.PodTemplate{
+		PriorityClassName: "low",
+		RuntimeClassName:  &runtimeClass,
+		ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "default-creds"}},
+	}
+	for _, tc := range []struct {
+		name       string
+		tpl        *v1alpha1.PodTemplate
+		defaultTpl *v1alpha1.PodTemplate
+		want       *v1alpha1.PodTemplate
+	}{{
+		name: "no templates",
+	}, {
+		name:       "default only",
+		defaultTpl: defaultTpl,
+		want:       defaultTpl,
+	}, {
+		name: "template only",
+		tpl:  &v1alpha1.PodTemplate{PriorityClassName: "high"},
+		want: &v1alpha1.PodTemplate{PriorityClassName: "high"},
+	}, {
+		name: "template overrides default",
+		tpl: &v1alpha1.PodTemplate{
+			PriorityClassName: "high",
+			DNSPolicy:         corev1.DNSNone,
+			ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "my-creds"}},
+		},
+		defaultTpl: defaultTpl,
+		want: &v1alpha1.PodTemplate{
+			PriorityClassName: "high",
+			RuntimeClassName:  &runtimeClass,
+			DNSPolicy:         corev1.DNSNone,
+			ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "my-creds"}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := v1alpha1.MergePodTemplateWithDefault(tc.tpl, tc.defaultTpl)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("MergePodTemplateWithDefault() diff -want, +got: %v", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	prSource = "pr-source"
+	// authTokenField is the SecretParam field name of the SCM API token.
+	authTokenField = "authToken"
+	// authTokenEnv is the environment variable the token is passed in.
+	authTokenEnv = "AUTH_TOKEN"
+)
+
+var (
+	prImage = flag.String("pr-image", "override-with-pr:latest",
+		"The container image containing our PR binary.")
+)
+
+// PullRequestResource is an endpoint from which to get data which is required
+// by a Task for context, e.g. the labels and comments of a pull request, and to
+// which edits of that data are pushed back.
+type PullRequestResource struct {
+	Name string               `json:"name"`
+	Type PipelineResourceType `json:"type"`
+	// URL is the web URL of the pull request.
+	URL string `json:"url"`
+	// Provider is the SCM provider, guessed from the URL when empty.
+	Provider string `json:"provider"`
+	// APIURL overrides the API endpoint of the provider.
+	APIURL         string `json:"apiURL"`
+	DestinationDir string `json:"destinationDir"`
+	// Secrets holds the field name and corresponding secret of the API token.
+	Secrets []SecretParam `json:"secrets"`
+}
+
+// NewPullRequestResource create a new pull request resource to pass to a Task
+func NewPullRequestResource(r *PipelineResource) (*PullRequestResource, error) {
+	if r.Spec.Type != PipelineResourceTypePullRequest {
+		return nil, xerrors.Errorf("PullRequestResource: Cannot create a PR resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	pr := &PullRequestResource{
+		Name:    r.Name,
+		Type:    r.Spec.Type,
+		Secrets: r.Spec.SecretParams,
+	}
+	for _, param := range r.Spec.Params {
+		switch {
+		case strings.EqualFold(param.Name, "URL"):
+			pr.URL = param.Value
+		case strings.EqualFold(param.Name, "Provider"):
+			pr.Provider = param.Value
+		case strings.EqualFold(param.Name, "APIURL"):
+			pr.APIURL = param.Value
+		}
+	}
+	for _, secret := range pr.Secrets {
+		if !strings.EqualFold(secret.FieldName, authTokenField) {
+			return nil, xerrors.Errorf("PullRequestResource: Unsupported secret field %q for PR resource %s, only %s is supported", secret.FieldName, r.Name, authTokenField)
+		}
+	}
+	return pr, nil
+}
+
+// GetName returns the name of the resource
+func (s PullRequestResource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "pullRequest"
+func (s PullRequestResource) GetType() PipelineResourceType {
+	return PipelineResourceTypePullRequest
+}
+
+// GetParams returns the resource params
+func (s PullRequestResource) GetParams() []Param { return []Param{} }
+
+// Replacements is used for template replacement on a PullRequestResource inside of a Taskrun.
+func (s *PullRequestResource) Replacements() map[string]string {
+	return map[string]string{
+		"name":     s.Name,
+		"type":     string(s.Type),
+		"url":      s.URL,
+		"provider": s.Provider,
+		"path":     s.DestinationDir,
+	}
+}
+
+// SetDestinationDirectory sets the directory the pull request is downloaded to
+// and uploaded from.
+func (s *PullRequestResource) SetDestinationDirectory(dir string) {
+	s.DestinationDir = dir
+}
+
+// GetDownloadContainerSpec returns the container downloading the pull request
+// to the destination directory.
+func (s *PullRequestResource) GetDownloadContainerSpec() ([]corev1.Container, error) {
+	if s.DestinationDir == "" {
+		return nil, xerrors.Errorf("PullRequestResource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	return []corev1.Container{s.container(prSource+"-"+s.Name, "download")}, nil
+}
+
+// GetUploadContainerSpec returns the container pushing the changes made to the
+// pull request in the destination directory back to the provider.
+func (s *PullRequestResource) GetUploadContainerSpec() ([]corev1.Container, error) {
+	if s.DestinationDir == "" {
+		return nil, xerrors.Errorf("PullRequestResource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	return []corev1.Container{s.container(fmt.Sprintf("pr-sink-%s", s.Name), "upload")}, nil
+}
+
+func (s *PullRequestResource) container(name, mode string) corev1.Container {
+	args := []string{"-url", s.URL, "-path", s.DestinationDir, "-mode", mode}
+	if s.Provider != "" {
+		args = append(args, "-provider", s.Provider)
+	}
+	if s.APIURL != "" {
+		args = append(args, "-api-url", s.APIURL)
+	}
+	var env []corev1.EnvVar
+	for _, secret := range s.Secrets {
+		env = append(env, corev1.EnvVar{
+			Name: authTokenEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.SecretName},
+					Key:                  secret.SecretKey,
+				},
+			},
+		})
+	}
+	return corev1.Container{
+		Name:       names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(name),
+		Image:      *prImage,
+		Command:    []string{"/ko-app/pullrequest-init"},
+		Args:       args,
+		Env:        env,
+		WorkingDir: workspaceDir,
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewPullRequestResource(t *testing.T) {
+	pr := &PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-pr",
+		},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypePullRequest,
+			Params: []Param{{
+				Name:  "url",
+				Value: "https://github.example.com/tektoncd/pipeline/pull/1",
+			}, {
+				Name:  "provider",
+				Value: "github",
+			}, {
+				Name:  "apiURL",
+				Value: "https://github.example.com/api/v3",
+			}},
+			SecretParams: []SecretParam{{
+				FieldName:  "authToken",
+				SecretName: "github-token",
+				SecretKey:  "token",
+			}},
+		},
+	}
+	want := &PullRequestResource{
+		Name:     "test-pr",
+		Type:     PipelineResourceTypePullRequest,
+		URL:      "https://github.example.com/tektoncd/pipeline/pull/1",
+		Provider: "github",
+		APIURL:   "https://github.example.com/api/v3",
+		Secrets:  pr.Spec.SecretParams,
+	}
+
+	got, err := ResourceFromType(pr, nil)
+	if err != nil {
+		t.Fatalf("ResourceFromType() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestNewPullRequestResource_Invalid(t *testing.T) {
+	for _, pr := range []*PipelineResource{{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-resource"},
+		Spec:       PipelineResourceSpec{Type: PipelineResourceTypeGit},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "bad-secret"},
+		Spec: PipelineResourceSpec{
+			Type:         PipelineResourceTypePullRequest,
+			Params:       []Param{{Name: "url", Value: "https://github.com/tektoncd/pipeline/pull/1"}},
+			SecretParams: []SecretParam{{FieldName: "password", SecretName: "s", SecretKey: "k"}},
+		},
+	}} {
+		t.Run(pr.Name, func(t *testing.T) {
+			if _, err := NewPullRequestResource(pr); err == nil {
+				t.Error("Expected error creating pull request resource")
+			}
+		})
+	}
+}
+
+func TestPullRequest_GetContainerSpecs(t *testing.T) {
+	names.TestingSeed()
+	r := &PullRequestResource{
+		Name:           "test-pr",
+		Type:           PipelineResourceTypePullRequest,
+		URL:            "https://github.com/tektoncd/pipeline/pull/1",
+		DestinationDir: "/workspace/pr",
+		Secrets: []SecretParam{{
+			FieldName:  "authToken",
+			SecretName: "github-token",
+			SecretKey:  "token",
+		}},
+	}
+	env := []corev1.EnvVar{{
+		Name: "AUTH_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "github-token"},
+				Key:                  "token",
+			},
+		},
+	}}
+
+	wantDownload := []corev1.Container{{
+		Name:       "pr-source-test-pr-9l9zj",
+		Image:      "override-with-pr:latest",
+		Command:    []string{"/ko-app/pullrequest-init"},
+		Args:       []string{"-url", "https://github.com/tektoncd/pipeline/pull/1", "-path", "/workspace/pr", "-mode", "download"},
+		Env:        env,
+		WorkingDir: workspaceDir,
+	}}
+	gotDownload, err := r.GetDownloadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetDownloadContainerSpec() = %v", err)
+	}
+	if d := cmp.Diff(wantDownload, gotDownload); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+
+	wantUpload := []corev1.Container{{
+		Name:       "pr-sink-test-pr-mz4c7",
+		Image:      "override-with-pr:latest",
+		Command:    []string{"/ko-app/pullrequest-init"},
+		Args:       []string{"-url", "https://github.com/tektoncd/pipeline/pull/1", "-path", "/workspace/pr", "-mode", "upload"},
+		Env:        env,
+		WorkingDir: workspaceDir,
+	}}
+	gotUpload, err := r.GetUploadContainerSpec()
+	if err != nil {
+		t.Fatalf("GetUploadContainerSpec() = %v", err)
+	}
+	if d := cmp.Diff(wantUpload, gotUpload); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestPullRequest_GetContainerSpecsWithoutDestinationDir(t *testing.T) {
+	r := &PullRequestResource{
+		Name: "test-pr",
+		Type: PipelineResourceTypePullRequest,
+		URL:  "https://github.com/tektoncd/pipeline/pull/1",
+	}
+	if _, err := r.GetDownloadContainerSpec(); err == nil {
+		t.Error("Expected error getting the download containers without a destination directory")
+	}
+	if _, err := r.GetUploadContainerSpec(); err == nil {
+		t.Error("Expected error getting the upload containers without a destination directory")
+	}
+}
@@ -58,6 +58,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PipelineRunList{},
 		&PipelineResource{},
 		&PipelineResourceList{},
+		&StepTemplate{},
+		&StepTemplateList{},
+		&ClusterStepTemplate{},
+		&ClusterStepTemplateList{},
+		&ResourceType{},
+		&ResourceTypeList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
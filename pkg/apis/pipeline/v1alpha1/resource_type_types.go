@@ -0,0 +1,92 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/knative/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceTypeSpec defines how PipelineResources of a custom type are fetched
+// as inputs and pushed as outputs.
+type ResourceTypeSpec struct {
+	// Params declares the params PipelineResources of the type can set. They
+	// are available to the steps and to Tasks as ${resource.<name>} and
+	// ${inputs.resources.<resource>.<name>} respectively.
+	// +optional
+	Params []ResourceTypeParam `json:"params,omitempty"`
+	// Download are the steps fetching an input resource into
+	// ${resource.path}.
+	// +optional
+	Download []corev1.Container `json:"download,omitempty"`
+	// Upload are the steps pushing an output resource from ${resource.path}.
+	// +optional
+	Upload []corev1.Container `json:"upload,omitempty"`
+}
+
+// ResourceTypeParam declares a param of the PipelineResources of a custom
+// type.
+type ResourceTypeParam struct {
+	// Name is the name of the param.
+	Name string `json:"name"`
+	// Description is an informational description of what the param
+	// represents.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Default is the value of the param if the PipelineResource does not set
+	// it. Params without a default must be set.
+	// +optional
+	Default string `json:"default,omitempty"`
+}
+
+// Check that ResourceType may be validated and defaulted.
+var _ apis.Validatable = (*ResourceType)(nil)
+var _ apis.Defaultable = (*ResourceType)(nil)
+
+// +genclient
+// +genclient:noStatus
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceType declares a custom PipelineResourceType, named after the
+// ResourceType, which PipelineResources in any namespace of the cluster can
+// use without changes to the controller.
+type ResourceType struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the ResourceType from the client
+	// +optional
+	Spec ResourceTypeSpec `json:"spec,omitempty"`
+}
+
+// SetDefaults sets any defaults for the ResourceType.
+func (t *ResourceType) SetDefaults(ctx context.Context) {}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceTypeList contains a list of ResourceType
+type ResourceTypeList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceType `json:"items"`
+}
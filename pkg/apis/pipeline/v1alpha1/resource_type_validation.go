@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/knative/pkg/apis"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// reservedResourceTypeParams are the replacements every custom resource has.
+var reservedResourceTypeParams = map[string]bool{"name": true, "type": true, "path": true}
+
+func (t *ResourceType) Validate(ctx context.Context) *apis.FieldError {
+	// Unlike other objects, the name is a type referred to by
+	// PipelineResources, which must contain a dot to tell it apart from the
+	// built-in types, e.g. helm.example.com.
+	if !IsCustomResourceType(PipelineResourceType(t.Name)) {
+		return apis.ErrInvalidValue(t.Name, "name").ViaField("metadata")
+	}
+	return t.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks that the params of a ResourceTypeSpec can be replaced in its
+// steps and that it has steps to run.
+func (ts *ResourceTypeSpec) Validate(ctx context.Context) *apis.FieldError {
+	if len(ts.Download) == 0 && len(ts.Upload) == 0 {
+		return apis.ErrMissingOneOf("download", "upload")
+	}
+	seen := map[string]bool{}
+	for i, p := range ts.Params {
+		switch {
+		case p.Name == "":
+			return apis.ErrMissingField(fmt.Sprintf("params[%d].name", i))
+		case reservedResourceTypeParams[p.Name]:
+			return apis.ErrInvalidValue(p.Name, fmt.Sprintf("params[%d].name", i))
+		case seen[strings.ToLower(p.Name)]:
+			return apis.ErrMultipleOneOf(fmt.Sprintf("params[%d].name", i))
+		}
+		seen[strings.ToLower(p.Name)] = true
+	}
+	for i, step := range ts.Download {
+		if step.Image == "" {
+			return apis.ErrMissingField(fmt.Sprintf("download[%d].image", i))
+		}
+	}
+	for i, step := range ts.Upload {
+		if step.Image == "" {
+			return apis.ErrMissingField(fmt.Sprintf("upload[%d].image", i))
+		}
+	}
+	return nil
+}
+
+// IsCustomResourceType returns true if t can name a ResourceType, that is if it
+// is a DNS subdomain with at least one dot.
+func IsCustomResourceType(t PipelineResourceType) bool {
+	return strings.Contains(string(t), ".") && len(validation.IsDNS1123Subdomain(string(t))) == 0
+}
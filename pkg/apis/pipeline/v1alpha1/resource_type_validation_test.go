@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceType_Validate(t *testing.T) {
+	if err := helmResourceType.Validate(context.Background()); err != nil {
+		t.Errorf("ResourceType.Validate() = %v", err)
+	}
+}
+
+func TestResourceType_Validate_Error(t *testing.T) {
+	steps := []corev1.Container{{Image: "alpine"}}
+	for _, c := range []struct {
+		desc string
+		rt   *ResourceType
+		want *apis.FieldError
+	}{{
+		desc: "name without a dot",
+		rt: &ResourceType{
+			ObjectMeta: metav1.ObjectMeta{Name: "helm"},
+			Spec:       ResourceTypeSpec{Download: steps},
+		},
+		want: apis.ErrInvalidValue("helm", "metadata.name"),
+	}, {
+		desc: "no steps",
+		rt: &ResourceType{
+			ObjectMeta: metav1.ObjectMeta{Name: "helm.example.com"},
+		},
+		want: apis.ErrMissingOneOf("spec.download", "spec.upload"),
+	}, {
+		desc: "reserved param",
+		rt: &ResourceType{
+			ObjectMeta: metav1.ObjectMeta{Name: "helm.example.com"},
+			Spec: ResourceTypeSpec{
+				Params:   []ResourceTypeParam{{Name: "path"}},
+				Download: steps,
+			},
+		},
+		want: apis.ErrInvalidValue("path", "spec.params[0].name"),
+	}, {
+		desc: "duplicate param",
+		rt: &ResourceType{
+			ObjectMeta: metav1.ObjectMeta{Name: "helm.example.com"},
+			Spec: ResourceTypeSpec{
+				Params:   []ResourceTypeParam{{Name: "chart"}, {Name: "Chart"}},
+				Download: steps,
+			},
+		},
+		want: apis.ErrMultipleOneOf("spec.params[1].name"),
+	}, {
+		desc: "step without image",
+		rt: &ResourceType{
+			ObjectMeta: metav1.ObjectMeta{Name: "helm.example.com"},
+			Spec: ResourceTypeSpec{
+				Download: steps,
+				Upload:   []corev1.Container{{Name: "push"}},
+			},
+		},
+		want: apis.ErrMissingField("spec.upload[0].image"),
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			err := c.rt.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", c.rt)
+			}
+			if d := cmp.Diff(c.want.Error(), err.Error()); d != "" {
+				t.Errorf("Diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestIsCustomResourceType(t *testing.T) {
+	for rt, want := range map[PipelineResourceType]bool{
+		"helm.example.com":  true,
+		"git":               false,
+		"not-supported":     false,
+		"Helm.example.com":  false,
+		".example.com":      false,
+		"helm.example.com.": false,
+	} {
+		if got := IsCustomResourceType(rt); got != want {
+			t.Errorf("IsCustomResourceType(%q) = %v, expected %v", rt, got, want)
+		}
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"regexp"
+
 	"github.com/knative/pkg/apis"
 	"golang.org/x/xerrors"
 	corev1 "k8s.io/api/core/v1"
@@ -40,10 +42,22 @@ const (
 
 	// PipelineResourceTypeCluster indicates that this source is a k8s cluster Image.
 	PipelineResourceTypeCluster PipelineResourceType = "cluster"
+
+	// PipelineResourceTypePullRequest indicates that this source is a SCM Pull Request.
+	PipelineResourceTypePullRequest PipelineResourceType = "pullRequest"
+
+	// PipelineResourceTypeHTTP indicates that this source is a file or archive at a HTTP URL.
+	PipelineResourceTypeHTTP PipelineResourceType = "http"
+
+	// PipelineResourceTypeCloudEvent indicates that this output is a target a CloudEvent is sent to when the TaskRun finishes.
+	PipelineResourceTypeCloudEvent PipelineResourceType = "cloudEvent"
+
+	// PipelineResourceTypeChart indicates that this source is a Helm chart of a chart repository or OCI registry.
+	PipelineResourceTypeChart PipelineResourceType = "chart"
 )
 
 // AllResourceTypes can be used for validation to check if a provided Resource type is one of the known types.
-var AllResourceTypes = []PipelineResourceType{PipelineResourceTypeGit, PipelineResourceTypeStorage, PipelineResourceTypeImage, PipelineResourceTypeCluster}
+var AllResourceTypes = []PipelineResourceType{PipelineResourceTypeGit, PipelineResourceTypeStorage, PipelineResourceTypeImage, PipelineResourceTypeCluster, PipelineResourceTypePullRequest, PipelineResourceTypeHTTP, PipelineResourceTypeCloudEvent, PipelineResourceTypeChart}
 
 // PipelineResourceInterface interface to be implemented by different PipelineResource types
 type PipelineResourceInterface interface {
@@ -72,6 +86,22 @@ type PipelineResourceSpec struct {
 	SecretParams []SecretParam `json:"secrets,omitempty"`
 }
 
+// paramReference matches the references to the params of PipelineRuns and
+// TaskRuns, e.g. ${params.revision}, which the values of the params of a
+// PipelineResourceSpec may contain.
+var paramReference = regexp.MustCompile(`\$\{params\.[_a-zA-Z][_a-zA-Z0-9.-]*\}`)
+
+// ParamReferences returns the references to the params of PipelineRuns and
+// TaskRuns in the params of the spec, which are resolved by the reconcilers
+// before the resource is used.
+func (rs *PipelineResourceSpec) ParamReferences() []string {
+	var refs []string
+	for _, param := range rs.Params {
+		refs = append(refs, paramReference.FindAllString(param.Value, -1)...)
+	}
+	return refs
+}
+
 // PipelineResourceStatus does not contain anything because Resources on their own
 // do not have a status, they just hold data which is later used by PipelineRuns
 // and TaskRuns.
@@ -109,7 +139,13 @@ type PipelineResourceBinding struct {
 	Name string `json:"name,omitempty"`
 	// ResourceRef is a reference to the instance of the actual PipelineResource
 	// that should be used
+	// no more than one of the ResourceRef and ResourceSpec may be specified.
+	// +optional
 	ResourceRef PipelineResourceRef `json:"resourceRef,omitempty"`
+	// ResourceSpec is the spec of a PipelineResource embedded in the
+	// PipelineRun.
+	// +optional
+	ResourceSpec *PipelineResourceSpec `json:"resourceSpec,omitempty"`
 }
 
 // TaskResourceBinding points to the PipelineResource that
@@ -125,12 +161,41 @@ type TaskResourceBinding struct {
 	ResourceSpec *PipelineResourceSpec `json:"resourceSpec,omitempty"`
 	// +optional
 	Paths []string `json:"paths,omitempty"`
+	// Artifacts are the artifacts stored at Paths by previous TaskRuns, whose
+	// checksum is verified when they are copied.
+	// +optional
+	Artifacts []ArtifactResult `json:"artifacts,omitempty"`
+}
+
+// ArtifactResult describes an artifact a TaskRun stored in the artifact
+// storage of its PipelineRun.
+type ArtifactResult struct {
+	// Name of the output resource the artifact is a copy of.
+	Name string `json:"name"`
+	// Path of the artifact in the artifact storage, as in the Paths of
+	// TaskResourceBindings.
+	Path string `json:"path"`
+	// Checksum is the sha256 digest of the archive of the artifact.
+	Checksum string `json:"checksum"`
+	// Size is the size in bytes of the archive of the artifact.
+	// +optional
+	Size int64 `json:"size,omitempty"`
 }
 
-// PipelineResourceResult used to export the image name and digest as json
+// PipelineResourceResult used to export the image name and digest as json,
+// or the commit fetched for a git resource.
 type PipelineResourceResult struct {
 	Name   string `json:"name"`
 	Digest string `json:"digest"`
+	// Commit is the SHA the revision of a git resource resolved to.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+	// Ref is the revision of a git resource that was fetched.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+	// URL is the repository of a git resource.
+	// +optional
+	URL string `json:"url,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -144,7 +209,9 @@ type PipelineResourceList struct {
 }
 
 // ResourceFromType returns a PipelineResourceInterface from a PipelineResource's type.
-func ResourceFromType(r *PipelineResource) (PipelineResourceInterface, error) {
+// Custom types are created from the ResourceType returned by getResourceType,
+// which may be nil if custom types are not supported.
+func ResourceFromType(r *PipelineResource, getResourceType GetResourceType) (PipelineResourceInterface, error) {
 	switch r.Spec.Type {
 	case PipelineResourceTypeGit:
 		return NewGitResource(r)
@@ -154,6 +221,21 @@ func ResourceFromType(r *PipelineResource) (PipelineResourceInterface, error) {
 		return NewClusterResource(r)
 	case PipelineResourceTypeStorage:
 		return NewStorageResource(r)
+	case PipelineResourceTypePullRequest:
+		return NewPullRequestResource(r)
+	case PipelineResourceTypeHTTP:
+		return NewHTTPResource(r)
+	case PipelineResourceTypeCloudEvent:
+		return NewCloudEventResource(r)
+	case PipelineResourceTypeChart:
+		return NewChartResource(r)
+	}
+	if IsCustomResourceType(r.Spec.Type) && getResourceType != nil {
+		rt, err := getResourceType(string(r.Spec.Type))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get ResourceType %s: %w", r.Spec.Type, err)
+		}
+		return NewCustomResource(r, rt)
 	}
 	return nil, xerrors.Errorf("%s is an invalid or unimplemented PipelineResource", r.Spec.Type)
 }
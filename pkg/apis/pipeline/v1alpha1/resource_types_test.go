@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPipelineResourceSpec_ParamReferences(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		params []Param
+		want   []string
+	}{{
+		name: "no references",
+		params: []Param{{
+			Name:  "url",
+			Value: "https://github.com/tektoncd/pipeline",
+		}},
+	}, {
+		name: "references",
+		params: []Param{{
+			Name:  "url",
+			Value: "https://github.com/${params.org}/${params.repo}",
+		}, {
+			Name:  "revision",
+			Value: "${params.revision}",
+		}},
+		want: []string{"${params.org}", "${params.repo}", "${params.revision}"},
+	}, {
+		name: "references to inputs and resources",
+		params: []Param{{
+			Name:  "revision",
+			Value: "${inputs.params.revision}",
+		}, {
+			Name:  "url",
+			Value: "${resources.source.url}",
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := &PipelineResourceSpec{Type: PipelineResourceTypeGit, Params: tc.params}
+			if d := cmp.Diff(tc.want, rs.ParamReferences()); d != "" {
+				t.Errorf("ParamReferences() (-want, +got) = %v", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	s3Image = flag.String("s3-image", "override-with-s3-image:latest", "The container image containing our S3 helper binary")
+)
+
+// S3Resource is a location in an S3-compatible object store, such as AWS S3
+// or MinIO, from which to get artifacts or to which to upload them.
+type S3Resource struct {
+	Name     string               `json:"name"`
+	Type     PipelineResourceType `json:"type"`
+	Location string               `json:"location"`
+	// Endpoint is the URL of the object store. Empty means AWS S3.
+	Endpoint string `json:"endpoint"`
+	Region   string `json:"region"`
+	// PathStyle addresses the bucket as a path of the endpoint instead of
+	// as a subdomain.
+	PathStyle      bool   `json:"pathStyle"`
+	TypeDir        bool   `json:"typeDir"`
+	DestinationDir string `json:"destinationDir"`
+	//Secret holds a struct to indicate a field name and corresponding secret name to populate it
+	Secrets []SecretParam `json:"secrets"`
+}
+
+// NewS3Resource creates a new S3 resource to pass to a Task
+func NewS3Resource(r *PipelineResource) (*S3Resource, error) {
+	if r.Spec.Type != PipelineResourceTypeStorage {
+		return nil, xerrors.Errorf("S3Resource: Cannot create an S3 resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	s := &S3Resource{
+		Name:    r.Name,
+		Type:    r.Spec.Type,
+		Secrets: r.Spec.SecretParams,
+	}
+	for _, param := range r.Spec.Params {
+		switch {
+		case strings.EqualFold(param.Name, "Location"):
+			s.Location = param.Value
+		case strings.EqualFold(param.Name, "Endpoint"):
+			s.Endpoint = param.Value
+		case strings.EqualFold(param.Name, "Region"):
+			s.Region = param.Value
+		case strings.EqualFold(param.Name, "PathStyle"):
+			s.PathStyle = strings.EqualFold(param.Value, "true")
+		case strings.EqualFold(param.Name, "Dir"):
+			s.TypeDir = true // if dir flag is present then its a dir
+		}
+	}
+
+	if !strings.HasPrefix(s.Location, "s3://") || len(s.Location) == len("s3://") {
+		return nil, xerrors.Errorf("S3Resource: Need Location of the form s3://bucket/path to be specified in order to create S3 resource %s", r.Name)
+	}
+	for _, secret := range s.Secrets {
+		switch secret.FieldName {
+		case "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN":
+		default:
+			return nil, xerrors.Errorf("S3Resource: Unsupported secret field %q for S3 resource %s", secret.FieldName, r.Name)
+		}
+	}
+	return s, nil
+}
+
+// GetName returns the name of the resource
+func (s S3Resource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "storage"
+func (s S3Resource) GetType() PipelineResourceType {
+	return PipelineResourceTypeStorage
+}
+
+// GetParams get params
+func (s *S3Resource) GetParams() []Param { return []Param{} }
+
+// GetSecretParams returns the resource secret params
+func (s *S3Resource) GetSecretParams() []SecretParam { return s.Secrets }
+
+// Replacements is used for template replacement on an S3Resource inside of a Taskrun.
+func (s *S3Resource) Replacements() map[string]string {
+	return map[string]string{
+		"name":     s.Name,
+		"type":     string(s.Type),
+		"location": s.Location,
+		"endpoint": s.Endpoint,
+		"region":   s.Region,
+		"path":     s.DestinationDir,
+	}
+}
+
+// SetDestinationDirectory sets the destination directory at runtime like where is the resource going to be copied to
+func (s *S3Resource) SetDestinationDirectory(destDir string) { s.DestinationDir = destDir }
+
+// GetUploadContainerSpec gets container spec for s3 resource to be uploaded
+// with the credentials taken from the secret params.
+func (s *S3Resource) GetUploadContainerSpec() ([]corev1.Container, error) {
+	if s.DestinationDir == "" {
+		return nil, xerrors.Errorf("S3Resource: Expect Destination Directory param to be set: %s", s.Name)
+	}
+	return []corev1.Container{{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("upload-%s", s.Name)),
+		Image:   *s3Image,
+		Command: []string{"/ko-app/s3"},
+		Args:    s.args("upload"),
+		Env:     s.envVars(),
+	}}, nil
+}
+
+// GetDownloadContainerSpec returns an array of container specs to download s3 storage objects
+func (s *S3Resource) GetDownloadContainerSpec() ([]corev1.Container, error) {
+	if s.DestinationDir == "" {
+		return nil, xerrors.Errorf("S3Resource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	return []corev1.Container{
+		CreateDirContainer(s.Name, s.DestinationDir), {
+			Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("fetch-%s", s.Name)),
+			Image:   *s3Image,
+			Command: []string{"/ko-app/s3"},
+			Args:    s.args("download"),
+			Env:     s.envVars(),
+		}}, nil
+}
+
+func (s *S3Resource) args(operation string) []string {
+	args := []string{"-operation", operation, "-location", s.Location, "-path", s.DestinationDir}
+	if s.Endpoint != "" {
+		args = append(args, "-endpoint", s.Endpoint)
+	}
+	if s.Region != "" {
+		args = append(args, "-region", s.Region)
+	}
+	if s.PathStyle {
+		args = append(args, "-path-style")
+	}
+	if s.TypeDir {
+		args = append(args, "-dir")
+	}
+	return args
+}
+
+func (s *S3Resource) envVars() []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+	for _, secret := range s.Secrets {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: secret.FieldName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.SecretName},
+					Key:                  secret.SecretKey,
+				},
+			},
+		})
+	}
+	return envVars
+}
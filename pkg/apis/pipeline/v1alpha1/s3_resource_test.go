@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Valid_NewS3Resource(t *testing.T) {
+	pr := &PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "s3-resource",
+		},
+		Spec: PipelineResourceSpec{
+			Type: PipelineResourceTypeStorage,
+			Params: []Param{{
+				Name:  "type",
+				Value: "s3",
+			}, {
+				Name:  "location",
+				Value: "s3://bucket/path",
+			}, {
+				Name:  "endpoint",
+				Value: "http://minio:9000",
+			}, {
+				Name:  "region",
+				Value: "eu-west-1",
+			}, {
+				Name:  "pathStyle",
+				Value: "true",
+			}, {
+				Name:  "dir",
+				Value: "anything",
+			}},
+			SecretParams: []SecretParam{{
+				SecretKey:  "accesskey",
+				SecretName: "minio-creds",
+				FieldName:  "AWS_ACCESS_KEY_ID",
+			}},
+		},
+	}
+	expected := &S3Resource{
+		Name:      "s3-resource",
+		Type:      PipelineResourceTypeStorage,
+		Location:  "s3://bucket/path",
+		Endpoint:  "http://minio:9000",
+		Region:    "eu-west-1",
+		PathStyle: true,
+		TypeDir:   true,
+		Secrets: []SecretParam{{
+			SecretKey:  "accesskey",
+			SecretName: "minio-creds",
+			FieldName:  "AWS_ACCESS_KEY_ID",
+		}},
+	}
+
+	r, err := NewStorageResource(pr)
+	if err != nil {
+		t.Fatalf("Unexpected error creating S3 resource: %s", err)
+	}
+	if d := cmp.Diff(expected, r); d != "" {
+		t.Errorf("Mismatch of S3 resource: %s", d)
+	}
+}
+
+func Test_Invalid_NewS3Resource(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		params []Param
+		secret SecretParam
+	}{{
+		name:   "gcs location",
+		params: []Param{{Name: "type", Value: "s3"}, {Name: "location", Value: "gs://bucket"}},
+	}, {
+		name:   "no bucket",
+		params: []Param{{Name: "type", Value: "s3"}, {Name: "location", Value: "s3://"}},
+	}, {
+		name:   "unsupported secret field",
+		params: []Param{{Name: "type", Value: "s3"}, {Name: "location", Value: "s3://bucket"}},
+		secret: SecretParam{FieldName: "GOOGLE_APPLICATION_CREDENTIALS", SecretName: "creds", SecretKey: "key"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "s3-resource"},
+				Spec: PipelineResourceSpec{
+					Type:   PipelineResourceTypeStorage,
+					Params: tc.params,
+				},
+			}
+			if tc.secret.FieldName != "" {
+				pr.Spec.SecretParams = []SecretParam{tc.secret}
+			}
+			if _, err := NewStorageResource(pr); err == nil {
+				t.Error("Expected error creating S3 resource")
+			}
+		})
+	}
+}
+
+func Test_S3GetContainerSpecs(t *testing.T) {
+	names.TestingSeed()
+	s3Resource := &S3Resource{
+		Name:           "s3-valid",
+		Location:       "s3://bucket/path",
+		Endpoint:       "http://minio:9000",
+		PathStyle:      true,
+		TypeDir:        true,
+		DestinationDir: "/workspace",
+		Secrets: []SecretParam{{
+			SecretName: "minio-creds",
+			FieldName:  "AWS_SECRET_ACCESS_KEY",
+			SecretKey:  "secretkey",
+		}},
+	}
+	env := []corev1.EnvVar{{
+		Name: "AWS_SECRET_ACCESS_KEY",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "minio-creds"},
+				Key:                  "secretkey",
+			},
+		},
+	}}
+
+	wantDownload := []corev1.Container{{
+		Name:    "create-dir-s3-valid-9l9zj",
+		Image:   "override-with-bash-noop:latest",
+		Command: []string{"/ko-app/bash"},
+		Args:    []string{"-args", "mkdir -p /workspace"},
+	}, {
+		Name:    "fetch-s3-valid-mz4c7",
+		Image:   "override-with-s3-image:latest",
+		Command: []string{"/ko-app/s3"},
+		Args:    []string{"-operation", "download", "-location", "s3://bucket/path", "-path", "/workspace", "-endpoint", "http://minio:9000", "-path-style", "-dir"},
+		Env:     env,
+	}}
+	gotDownload, err := s3Resource.GetDownloadContainerSpec()
+	if err != nil {
+		t.Fatalf("Unexpected error getting download containers: %v", err)
+	}
+	if d := cmp.Diff(wantDownload, gotDownload); d != "" {
+		t.Errorf("Error mismatch between download containers spec: %s", d)
+	}
+
+	wantUpload := []corev1.Container{{
+		Name:    "upload-s3-valid-mssqb",
+		Image:   "override-with-s3-image:latest",
+		Command: []string{"/ko-app/s3"},
+		Args:    []string{"-operation", "upload", "-location", "s3://bucket/path", "-path", "/workspace", "-endpoint", "http://minio:9000", "-path-style", "-dir"},
+		Env:     env,
+	}}
+	gotUpload, err := s3Resource.GetUploadContainerSpec()
+	if err != nil {
+		t.Fatalf("Unexpected error getting upload containers: %v", err)
+	}
+	if d := cmp.Diff(wantUpload, gotUpload); d != "" {
+		t.Errorf("Error mismatch between upload containers spec: %s", d)
+	}
+
+	if _, err := (&S3Resource{Name: "s3-invalid", Location: "s3://bucket"}).GetUploadContainerSpec(); err == nil {
+		t.Error("Expected error getting upload containers without destination directory")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/knative/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StepTemplateKind defines the type of StepTemplate used by a Task.
+type StepTemplateKind string
+
+const (
+	// NamespacedStepTemplateKind indicates that the step template type has a namespaced scope.
+	NamespacedStepTemplateKind StepTemplateKind = "StepTemplate"
+	// ClusterStepTemplateKind indicates that the step template type has a cluster scope.
+	ClusterStepTemplateKind StepTemplateKind = "ClusterStepTemplate"
+)
+
+// StepTemplateSpec defines the desired state of a StepTemplate.
+type StepTemplateSpec struct {
+	// Container is merged into the steps the template is applied to, using
+	// the same strategic merge semantics as a Task's ContainerTemplate. Fields
+	// set on the step itself take precedence.
+	Container corev1.Container `json:"container"`
+}
+
+// StepTemplateRef can be used to refer to a specific instance of a
+// StepTemplate or ClusterStepTemplate from a Task.
+type StepTemplateRef struct {
+	// Name of the referent; More info: http://kubernetes.io/docs/user-guide/identifiers#names
+	Name string `json:"name"`
+	// StepTemplateKind indicates the kind of the step template, namespaced or cluster scoped.
+	// +optional
+	Kind StepTemplateKind `json:"kind,omitempty"`
+	// Steps are the names of the steps the template is applied to. If
+	// empty, the template is applied to every step of the Task.
+	// +optional
+	Steps []string `json:"steps,omitempty"`
+}
+
+// AppliesTo returns true if the referenced template should be merged into
+// the step with the given name.
+func (r StepTemplateRef) AppliesTo(stepName string) bool {
+	if len(r.Steps) == 0 {
+		return true
+	}
+	for _, s := range r.Steps {
+		if s == stepName {
+			return true
+		}
+	}
+	return false
+}
+
+// Check that StepTemplate and ClusterStepTemplate may be validated and defaulted.
+var _ apis.Validatable = (*StepTemplate)(nil)
+var _ apis.Defaultable = (*StepTemplate)(nil)
+var _ apis.Validatable = (*ClusterStepTemplate)(nil)
+var _ apis.Defaultable = (*ClusterStepTemplate)(nil)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StepTemplate holds container settings that can be shared by the steps of
+// many Tasks in the same namespace.
+type StepTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the StepTemplate from the client
+	// +optional
+	Spec StepTemplateSpec `json:"spec,omitempty"`
+}
+
+// SetDefaults sets any defaults for the StepTemplate.
+func (t *StepTemplate) SetDefaults(ctx context.Context) {}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StepTemplateList contains a list of StepTemplate
+type StepTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StepTemplate `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterStepTemplate is a StepTemplate with a cluster scope. ClusterStepTemplates
+// can be referenced by Tasks in any namespace of the cluster.
+type ClusterStepTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the ClusterStepTemplate from the client
+	// +optional
+	Spec StepTemplateSpec `json:"spec,omitempty"`
+}
+
+// SetDefaults sets any defaults for the ClusterStepTemplate.
+func (t *ClusterStepTemplate) SetDefaults(ctx context.Context) {}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterStepTemplateList contains a list of ClusterStepTemplate
+type ClusterStepTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterStepTemplate `json:"items"`
+}
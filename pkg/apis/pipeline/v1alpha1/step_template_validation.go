@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/knative/pkg/apis"
+)
+
+func (t *StepTemplate) Validate(ctx context.Context) *apis.FieldError {
+	if err := validateObjectMetadata(t.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return t.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (t *ClusterStepTemplate) Validate(ctx context.Context) *apis.FieldError {
+	if err := validateObjectMetadata(t.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return t.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks that the container of a StepTemplateSpec can be merged
+// into a step. The name identifies a step, so it must come from the Task.
+func (ts *StepTemplateSpec) Validate(ctx context.Context) *apis.FieldError {
+	if ts.Container.Name != "" {
+		return apis.ErrDisallowedFields("container.name")
+	}
+	return nil
+}
+
+// Validate checks that a StepTemplateRef names a template of a known kind
+// and only refers to steps that exist in steps.
+func (r StepTemplateRef) Validate(stepNames map[string]struct{}) *apis.FieldError {
+	if r.Name == "" {
+		return apis.ErrMissingField("name")
+	}
+	switch r.Kind {
+	case "", NamespacedStepTemplateKind, ClusterStepTemplateKind:
+	default:
+		return apis.ErrInvalidValue(string(r.Kind), "kind")
+	}
+	for _, s := range r.Steps {
+		if _, ok := stepNames[s]; !ok {
+			return apis.ErrInvalidValue(s, "steps")
+		}
+	}
+	return nil
+}
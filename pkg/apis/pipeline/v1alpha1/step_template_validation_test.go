@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStepTemplate_Validate(t *testing.T) {
+	st := &StepTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "go"},
+		Spec: StepTemplateSpec{Container: corev1.Container{
+			Image:      "golang",
+			WorkingDir: "/workspace",
+		}},
+	}
+	if err := st.Validate(context.Background()); err != nil {
+		t.Errorf("StepTemplate.Validate() = %v", err)
+	}
+}
+
+func TestClusterStepTemplate_Validate_Error(t *testing.T) {
+	st := &ClusterStepTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "go"},
+		Spec: StepTemplateSpec{Container: corev1.Container{
+			Name:  "build",
+			Image: "golang",
+		}},
+	}
+	err := st.Validate(context.Background())
+	if err == nil {
+		t.Fatalf("Expected an error, got nothing for %v", st)
+	}
+	if err.Error() != "must not set the field(s): spec.container.name" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
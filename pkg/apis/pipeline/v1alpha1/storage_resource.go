@@ -28,6 +28,9 @@ const (
 	// PipelineResourceTypeGCS indicates that resource source is a GCS blob/directory.
 	PipelineResourceTypeGCS      PipelineResourceType = "gcs"
 	PipelineResourceTypeBuildGCS PipelineResourceType = "build-gcs"
+	// PipelineResourceTypeS3 indicates that resource source is an object or
+	// prefix in an S3-compatible object store.
+	PipelineResourceTypeS3 PipelineResourceType = "s3"
 )
 
 // PipelineResourceInterface interface to be implemented by different PipelineResource types
@@ -48,6 +51,8 @@ func NewStorageResource(r *PipelineResource) (PipelineStorageResourceInterface,
 				return NewGCSResource(r)
 			case strings.EqualFold(param.Value, string(PipelineResourceTypeBuildGCS)):
 				return NewBuildGCSResource(r)
+			case strings.EqualFold(param.Value, string(PipelineResourceTypeS3)):
+				return NewS3Resource(r)
 			default:
 				return nil, xerrors.Errorf("%s is an invalid or unimplemented PipelineStorageResource", param.Value)
 			}
@@ -36,4 +36,9 @@ func (ts *TaskSpec) SetDefaults(ctx context.Context) {
 			}
 		}
 	}
+	for i, r := range ts.StepTemplates {
+		if r.Kind == "" {
+			ts.StepTemplates[i].Kind = NamespacedStepTemplateKind
+		}
+	}
 }
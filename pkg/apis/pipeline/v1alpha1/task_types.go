@@ -53,6 +53,17 @@ type TaskSpec struct {
 	// ContainerTemplate can be used as the basis for all step containers within the
 	// Task, so that the steps inherit settings on the base container.
 	ContainerTemplate *corev1.Container `json:"containerTemplate,omitempty"`
+
+	// StepTemplates are StepTemplates or ClusterStepTemplates merged into the
+	// steps of the Task, in order, on top of the ContainerTemplate. Settings
+	// on a step always take precedence over its templates.
+	// +optional
+	StepTemplates []StepTemplateRef `json:"stepTemplates,omitempty"`
+
+	// Caches are directories restored before the steps run and saved after
+	// they succeed, so that their content is reused across runs of the Task.
+	// +optional
+	Caches []TaskCache `json:"caches,omitempty"`
 }
 
 // Check that Task may be validated and defaulted.
@@ -101,6 +112,11 @@ type TaskResource struct {
 	// +optional
 	// Path to the index.json file for output container images
 	OutputImageDir string `json:"outputImageDir"`
+	// +optional
+	// PushOutputImage is set when the Task writes an OCI image layout to
+	// OutputImageDir instead of pushing the image itself, so that the layout
+	// is pushed to the URL of the output image resource after the steps ran.
+	PushOutputImage bool `json:"pushOutputImage,omitempty"`
 }
 
 // TaskParam defines arbitrary parameters needed by a task beyond typed inputs
@@ -137,6 +153,24 @@ type TestResult struct {
 	Path   string `json:"path"`
 }
 
+// TaskCache declares directories, such as downloaded dependencies, whose
+// content is cached across runs of a Task. The cache is keyed by Key and the
+// content of KeyFiles, e.g. a go.sum file, so a new cache is saved whenever
+// they change.
+type TaskCache struct {
+	// Name identifies the cache among the caches of the Task.
+	Name string `json:"name"`
+	// Paths are the absolute paths of the directories to cache.
+	Paths []string `json:"paths"`
+	// Key is added to the key of the cache, e.g. the version of a toolchain.
+	// +optional
+	Key string `json:"key,omitempty"`
+	// KeyFiles are the paths or glob patterns of the files whose content is
+	// added to the key of the cache. Relative paths are relative to /workspace.
+	// +optional
+	KeyFiles []string `json:"keyFiles,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // TaskList contains a list of Task
@@ -55,10 +55,20 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
-	if err := validateSteps(mergedSteps).ViaField("steps"); err != nil {
+	if err := validateSteps(mergedSteps, ts.StepTemplates).ViaField("steps"); err != nil {
 		return err
 	}
 
+	stepNames := map[string]struct{}{}
+	for _, s := range ts.Steps {
+		stepNames[s.Name] = struct{}{}
+	}
+	for i, r := range ts.StepTemplates {
+		if err := r.Validate(stepNames); err != nil {
+			return err.ViaFieldIndex("stepTemplates", i)
+		}
+	}
+
 	// A task doesn't have to have inputs or outputs, but if it does they must be valid.
 	// A task can't duplicate input or output names.
 
@@ -67,6 +77,13 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 			if err := validateResourceType(resource, fmt.Sprintf("taskspec.Inputs.Resources.%s.Type", resource.Name)); err != nil {
 				return err
 			}
+			if resource.PushOutputImage {
+				return apis.ErrDisallowedFields(fmt.Sprintf("taskspec.Inputs.Resources.%s.PushOutputImage", resource.Name))
+			}
+			// CloudEvents are only sent for outputs.
+			if resource.Type == PipelineResourceTypeCloudEvent {
+				return apis.ErrInvalidValue(string(resource.Type), fmt.Sprintf("taskspec.Inputs.Resources.%s.Type", resource.Name))
+			}
 		}
 		if err := checkForDuplicates(ts.Inputs.Resources, "taskspec.Inputs.Resources.Name"); err != nil {
 			return err
@@ -77,6 +94,13 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 			if err := validateResourceType(resource, fmt.Sprintf("taskspec.Outputs.Resources.%s.Type", resource.Name)); err != nil {
 				return err
 			}
+			if resource.PushOutputImage && resource.Type != PipelineResourceTypeImage {
+				return apis.ErrDisallowedFields(fmt.Sprintf("taskspec.Outputs.Resources.%s.PushOutputImage", resource.Name))
+			}
+			// The layout is pushed from OutputImageDir.
+			if resource.PushOutputImage && resource.OutputImageDir == "" {
+				return apis.ErrMissingField(fmt.Sprintf("taskspec.Outputs.Resources.%s.OutputImageDir", resource.Name))
+			}
 		}
 		if err := checkForDuplicates(ts.Outputs.Resources, "taskspec.Outputs.Resources.Name"); err != nil {
 			return err
@@ -94,6 +118,10 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if err := validateCaches(ts.Caches); err != nil {
+		return err
+	}
+
 	if err := validateInputParameterVariables(ts.Steps, ts.Inputs); err != nil {
 		return err
 	}
@@ -115,11 +143,42 @@ func ValidateVolumes(volumes []corev1.Volume) *apis.FieldError {
 	return nil
 }
 
-func validateSteps(steps []corev1.Container) *apis.FieldError {
+// validateCaches checks the caches of a Task have distinct names usable in
+// the names of containers and absolute paths, which may be templated.
+func validateCaches(caches []TaskCache) *apis.FieldError {
+	names := map[string]struct{}{}
+	for i, c := range caches {
+		if errs := validation.IsDNS1123Label(c.Name); len(errs) > 0 {
+			return apis.ErrInvalidValue(c.Name, "name").ViaFieldIndex("caches", i)
+		}
+		if _, ok := names[c.Name]; ok {
+			return apis.ErrMultipleOneOf("name").ViaFieldIndex("caches", i)
+		}
+		names[c.Name] = struct{}{}
+		if len(c.Paths) == 0 {
+			return apis.ErrMissingField("paths").ViaFieldIndex("caches", i)
+		}
+		for _, p := range c.Paths {
+			if !strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "${") {
+				return apis.ErrInvalidValue(p, "paths").ViaFieldIndex("caches", i)
+			}
+		}
+		for _, f := range c.KeyFiles {
+			if strings.TrimSpace(f) == "" {
+				return apis.ErrInvalidValue(f, "keyFiles").ViaFieldIndex("caches", i)
+			}
+		}
+	}
+	return nil
+}
+
+func validateSteps(steps []corev1.Container, templates []StepTemplateRef) *apis.FieldError {
 	// Task must not have duplicate step names.
 	names := map[string]struct{}{}
 	for _, s := range steps {
-		if s.Image == "" {
+		// The image may be provided by a step template, in which case it is
+		// only known once the templates are resolved.
+		if s.Image == "" && !hasStepTemplate(s.Name, templates) {
 			return apis.ErrMissingField("Image")
 		}
 
@@ -134,6 +193,15 @@ func validateSteps(steps []corev1.Container) *apis.FieldError {
 	return nil
 }
 
+func hasStepTemplate(stepName string, templates []StepTemplateRef) bool {
+	for _, r := range templates {
+		if r.AppliesTo(stepName) {
+			return true
+		}
+	}
+	return false
+}
+
 func validateInputParameterVariables(steps []corev1.Container, inputs *Inputs) *apis.FieldError {
 	parameterNames := map[string]struct{}{}
 	if inputs != nil {
@@ -221,6 +289,10 @@ func checkForDuplicates(resources []TaskResource, path string) *apis.FieldError
 }
 
 func validateResourceType(r TaskResource, path string) *apis.FieldError {
+	// Custom types can only be resolved by the controller.
+	if IsCustomResourceType(r.Type) {
+		return nil
+	}
 	for _, allowed := range AllResourceTypes {
 		if r.Type == allowed {
 			return nil
@@ -52,6 +52,8 @@ func TestTaskSpecValidate(t *testing.T) {
 		Outputs           *Outputs
 		BuildSteps        []corev1.Container
 		ContainerTemplate *corev1.Container
+		StepTemplates     []StepTemplateRef
+		Caches            []TaskCache
 	}
 	tests := []struct {
 		name   string
@@ -71,6 +73,17 @@ func TestTaskSpecValidate(t *testing.T) {
 			},
 			BuildSteps: validBuildSteps,
 		},
+	}, {
+		name: "valid custom resource type",
+		fields: fields{
+			Inputs: &Inputs{
+				Resources: []TaskResource{{
+					Name: "chart",
+					Type: "helm.example.com",
+				}},
+			},
+			BuildSteps: validBuildSteps,
+		},
 	}, {
 		name: "valid outputs",
 		fields: fields{
@@ -101,6 +114,19 @@ func TestTaskSpecValidate(t *testing.T) {
 			},
 			BuildSteps: validBuildSteps,
 		},
+	}, {
+		name: "output image resource pushed from a layout",
+		fields: fields{
+			Outputs: &Outputs{
+				Resources: []TaskResource{{
+					Name:            "builtimage",
+					Type:            "image",
+					OutputImageDir:  "/workspace/layout",
+					PushOutputImage: true,
+				}},
+			},
+			BuildSteps: validBuildSteps,
+		},
 	}, {
 		name: "valid template variable",
 		fields: fields{
@@ -137,6 +163,33 @@ func TestTaskSpecValidate(t *testing.T) {
 				Image: "some-image",
 			},
 		},
+	}, {
+		name: "image provided by step template",
+		fields: fields{
+			BuildSteps: []corev1.Container{{
+				Name:    "astep",
+				Command: []string{"echo"},
+			}},
+			StepTemplates: []StepTemplateRef{{
+				Name:  "shell",
+				Kind:  ClusterStepTemplateKind,
+				Steps: []string{"astep"},
+			}},
+		},
+	}, {
+		name: "caches",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Caches: []TaskCache{{
+				Name:     "go-mod",
+				Paths:    []string{"/go/pkg/mod"},
+				Key:      "go1.12",
+				KeyFiles: []string{"${inputs.resources.source.path}/go.sum"},
+			}, {
+				Name:  "maven",
+				Paths: []string{"${inputs.params.home}/.m2"},
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -145,6 +198,8 @@ func TestTaskSpecValidate(t *testing.T) {
 				Outputs:           tt.fields.Outputs,
 				Steps:             tt.fields.BuildSteps,
 				ContainerTemplate: tt.fields.ContainerTemplate,
+				StepTemplates:     tt.fields.StepTemplates,
+				Caches:            tt.fields.Caches,
 			}
 			ctx := context.Background()
 			ts.SetDefaults(ctx)
@@ -157,9 +212,11 @@ func TestTaskSpecValidate(t *testing.T) {
 
 func TestTaskSpecValidateError(t *testing.T) {
 	type fields struct {
-		Inputs     *Inputs
-		Outputs    *Outputs
-		BuildSteps []corev1.Container
+		Inputs        *Inputs
+		Outputs       *Outputs
+		BuildSteps    []corev1.Container
+		StepTemplates []StepTemplateRef
+		Caches        []TaskCache
 	}
 	tests := []struct {
 		name          string
@@ -228,6 +285,69 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `invalid value: what`,
 			Paths:   []string{"taskspec.Outputs.Resources.who.Type"},
 		},
+	}, {
+		name: "pushed input image",
+		fields: fields{
+			Inputs: &Inputs{
+				Resources: []TaskResource{{
+					Name:            "baseimage",
+					Type:            "image",
+					PushOutputImage: true,
+				}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "must not set the field(s)",
+			Paths:   []string{"taskspec.Inputs.Resources.baseimage.PushOutputImage"},
+		},
+	}, {
+		name: "pushed output which is not an image",
+		fields: fields{
+			Outputs: &Outputs{
+				Resources: []TaskResource{{
+					Name:            "source",
+					Type:            "git",
+					PushOutputImage: true,
+				}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "must not set the field(s)",
+			Paths:   []string{"taskspec.Outputs.Resources.source.PushOutputImage"},
+		},
+	}, {
+		name: "pushed output image without a layout directory",
+		fields: fields{
+			Outputs: &Outputs{
+				Resources: []TaskResource{{
+					Name:            "builtimage",
+					Type:            "image",
+					PushOutputImage: true,
+				}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"taskspec.Outputs.Resources.builtimage.OutputImageDir"},
+		},
+	}, {
+		name: "cloud event input",
+		fields: fields{
+			Inputs: &Inputs{
+				Resources: []TaskResource{{
+					Name: "notify",
+					Type: PipelineResourceTypeCloudEvent,
+				}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: cloudEvent",
+			Paths:   []string{"taskspec.Inputs.Resources.notify.Type"},
+		},
 	}, {
 		name: "duplicated inputs",
 		fields: fields{
@@ -353,13 +473,98 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `non-existent variable in "${inputs.params.foo} && ${inputs.params.inexistent}" for step arg[0]`,
 			Paths:   []string{"taskspec.steps.arg[0]"},
 		},
+	}, {
+		name: "step template without name",
+		fields: fields{
+			BuildSteps:    validBuildSteps,
+			StepTemplates: []StepTemplateRef{{}},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"stepTemplates[0].name"},
+		},
+	}, {
+		name: "step template with invalid kind",
+		fields: fields{
+			BuildSteps:    validBuildSteps,
+			StepTemplates: []StepTemplateRef{{Name: "foo", Kind: "Task"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: Task",
+			Paths:   []string{"stepTemplates[0].kind"},
+		},
+	}, {
+		name: "step template for inexistent step",
+		fields: fields{
+			BuildSteps:    validBuildSteps,
+			StepTemplates: []StepTemplateRef{{Name: "foo", Steps: []string{"otherstep"}}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: otherstep",
+			Paths:   []string{"stepTemplates[0].steps"},
+		},
+	}, {
+		name: "step without image and without step template",
+		fields: fields{
+			BuildSteps:    []corev1.Container{{Name: "mystep"}, {Name: "otherstep", Image: "myimage"}},
+			StepTemplates: []StepTemplateRef{{Name: "foo", Steps: []string{"otherstep"}}},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"steps.Image"},
+		},
+	}, {
+		name: "cache with invalid name",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Caches:     []TaskCache{{Name: "Go_Mod", Paths: []string{"/go/pkg/mod"}}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: Go_Mod",
+			Paths:   []string{"caches[0].name"},
+		},
+	}, {
+		name: "duplicate caches",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Caches: []TaskCache{
+				{Name: "deps", Paths: []string{"/go/pkg/mod"}},
+				{Name: "deps", Paths: []string{"/root/.m2"}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"caches[1].name"},
+		},
+	}, {
+		name: "cache without paths",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Caches:     []TaskCache{{Name: "deps"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"caches[0].paths"},
+		},
+	}, {
+		name: "cache with relative path",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Caches:     []TaskCache{{Name: "deps", Paths: []string{"vendor"}}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: vendor",
+			Paths:   []string{"caches[0].paths"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &TaskSpec{
-				Inputs:  tt.fields.Inputs,
-				Outputs: tt.fields.Outputs,
-				Steps:   tt.fields.BuildSteps,
+				Inputs:        tt.fields.Inputs,
+				Outputs:       tt.fields.Outputs,
+				Steps:         tt.fields.BuildSteps,
+				StepTemplates: tt.fields.StepTemplates,
+				Caches:        tt.fields.Caches,
 			}
 			err := ts.Validate(context.Background())
 			if err == nil {
@@ -16,7 +16,16 @@ limitations under the License.
 
 package v1alpha1
 
-import "context"
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultDebugTimeout is how long a step paused at a breakpoint waits to be
+// resumed when the TaskRun does not specify a debug timeout.
+const DefaultDebugTimeout = time.Hour
 
 func (tr *TaskRun) SetDefaults(ctx context.Context) {
 	tr.Spec.SetDefaults(ctx)
@@ -26,4 +35,7 @@ func (trs *TaskRunSpec) SetDefaults(ctx context.Context) {
 	if trs.TaskRef != nil && trs.TaskRef.Kind == "" {
 		trs.TaskRef.Kind = NamespacedTaskKind
 	}
+	if trs.Debug != nil && trs.Debug.Timeout == nil {
+		trs.Debug.Timeout = &metav1.Duration{Duration: DefaultDebugTimeout}
+	}
 }
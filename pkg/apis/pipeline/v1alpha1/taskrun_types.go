@@ -64,6 +64,15 @@ type TaskRunSpec struct {
 	// If specified, the pod's scheduling constraints
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PodTemplate holds pod-level configuration, such as the security
+	// context or image pull secrets, for the pod that runs the TaskRun.
+	// Fields that are not set are taken from the cluster default.
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+	// Debug holds the breakpoints at which the TaskRun's steps pause so that
+	// the pod can be inspected before it terminates.
+	// +optional
+	Debug *TaskRunDebug `json:"debug,omitempty"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -75,6 +84,50 @@ const (
 	TaskRunSpecStatusCancelled = "TaskRunCancelled"
 )
 
+// BreakpointOnFailure is the breakpoint name that pauses execution after any
+// step which fails.
+const BreakpointOnFailure = "onFailure"
+
+// TaskRunDebug defines where the steps of a TaskRun pause for debugging.
+type TaskRunDebug struct {
+	// Breakpoints are the names of the steps after which execution pauses.
+	// The special value "onFailure" pauses after any step that fails.
+	// +optional
+	Breakpoints []string `json:"breakpoints,omitempty"`
+	// Timeout is how long a paused step waits for the continue signal before
+	// resuming on its own. Defaults to 1 hour.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// PausesOnFailure returns true if execution should pause after any step
+// which fails.
+func (d *TaskRunDebug) PausesOnFailure() bool {
+	if d == nil {
+		return false
+	}
+	for _, b := range d.Breakpoints {
+		if b == BreakpointOnFailure {
+			return true
+		}
+	}
+	return false
+}
+
+// PausesAfter returns true if execution should pause after the step named
+// stepName, regardless of its outcome.
+func (d *TaskRunDebug) PausesAfter(stepName string) bool {
+	if d == nil || stepName == "" {
+		return false
+	}
+	for _, b := range d.Breakpoints {
+		if b == stepName && b != BreakpointOnFailure {
+			return true
+		}
+	}
+	return false
+}
+
 // TaskRunInputs holds the input values that this task was invoked with.
 type TaskRunInputs struct {
 	// +optional
@@ -123,6 +176,80 @@ type TaskRunStatus struct {
 	// the digest of build container images
 	// optional
 	ResourcesResult []PipelineResourceResult `json:"resourcesResult,omitempty"`
+	// Artifacts are the artifacts the TaskRun stored in the artifact storage
+	// of its PipelineRun.
+	// +optional
+	Artifacts []ArtifactResult `json:"artifacts,omitempty"`
+	// ResolvedImages are the digests the images of the steps were pinned to
+	// when the TaskRun's pod was first created. Retries reuse them so that
+	// every attempt runs the same images.
+	// +optional
+	ResolvedImages []ResolvedImage `json:"resolvedImages,omitempty"`
+	// Debug describes the step the TaskRun is paused at, if any.
+	// +optional
+	Debug *TaskRunDebugStatus `json:"debug,omitempty"`
+	// CloudEvents describe the delivery of the CloudEvents sent to the
+	// targets of the cloud event outputs when the TaskRun finishes.
+	// +optional
+	CloudEvents []CloudEventDelivery `json:"cloudEvents,omitempty"`
+}
+
+// CloudEventCondition is the state of the delivery of a CloudEvent.
+type CloudEventCondition string
+
+const (
+	// CloudEventConditionUnknown means the CloudEvent wasn't delivered yet,
+	// either because the TaskRun is still running or because the delivery
+	// will be retried.
+	CloudEventConditionUnknown CloudEventCondition = "Unknown"
+	// CloudEventConditionSent means the CloudEvent was accepted by its target.
+	CloudEventConditionSent CloudEventCondition = "Sent"
+	// CloudEventConditionFailed means every attempt to deliver the CloudEvent
+	// failed, and it won't be retried.
+	CloudEventConditionFailed CloudEventCondition = "Failed"
+)
+
+// CloudEventDelivery is the delivery of the CloudEvent sent to the target of a
+// cloud event output.
+type CloudEventDelivery struct {
+	// Target is the URI the CloudEvent is sent to.
+	Target string `json:"target"`
+	// Status is the state of the delivery.
+	Status CloudEventDeliveryState `json:"status"`
+}
+
+// CloudEventDeliveryState records the attempts to deliver a CloudEvent.
+type CloudEventDeliveryState struct {
+	// Condition is whether the CloudEvent was sent.
+	Condition CloudEventCondition `json:"condition"`
+	// SentAt is the time the CloudEvent was accepted by its target.
+	// +optional
+	SentAt *metav1.Time `json:"sentAt,omitempty"`
+	// Error is the error of the last failed attempt.
+	// +optional
+	Error string `json:"error,omitempty"`
+	// RetryCount is the number of failed attempts.
+	RetryCount int32 `json:"retryCount"`
+}
+
+// ResolvedImage records the reference by digest a step image was pinned to.
+type ResolvedImage struct {
+	// Image is the image as specified by the step.
+	Image string `json:"image"`
+	// Reference is the image referenced by digest, e.g.
+	// gcr.io/foo/bar@sha256:deadbeef.
+	Reference string `json:"reference"`
+}
+
+// TaskRunDebugStatus describes a step which is paused at a breakpoint and
+// how to inspect and resume it.
+type TaskRunDebugStatus struct {
+	// Step is the name of the step paused at a breakpoint.
+	Step string `json:"step"`
+	// AttachCommand opens a shell in the paused step's container.
+	AttachCommand string `json:"attachCommand"`
+	// ContinueCommand resumes the execution of the paused step.
+	ContinueCommand string `json:"continueCommand"`
 }
 
 // GetCondition returns the Condition matching the given type.
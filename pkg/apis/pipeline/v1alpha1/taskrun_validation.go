@@ -66,6 +66,29 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ts.Debug != nil {
+		if err := ts.Debug.Validate(ctx, "spec.debug"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the debug breakpoints are named and that the debug
+// timeout is not negative.
+func (d *TaskRunDebug) Validate(ctx context.Context, path string) *apis.FieldError {
+	if len(d.Breakpoints) == 0 {
+		return apis.ErrMissingField(fmt.Sprintf("%s.breakpoints", path))
+	}
+	for _, b := range d.Breakpoints {
+		if b == "" {
+			return apis.ErrInvalidValue(b, fmt.Sprintf("%s.breakpoints", path))
+		}
+	}
+	if d.Timeout != nil && d.Timeout.Duration < 0 {
+		return apis.ErrInvalidValue(d.Timeout.Duration.String(), fmt.Sprintf("%s.timeout", path))
+	}
 	return nil
 }
 
@@ -80,33 +103,14 @@ func (o TaskRunOutputs) Validate(ctx context.Context, path string) *apis.FieldEr
 	return validatePipelineResources(ctx, o.Resources, fmt.Sprintf("%s.Resources.Name", path))
 }
 
-// validatePipelineResources validates that
-//	1. resource is not declared more than once
-//	2. if both resource reference and resource spec is defined at the same time
-//	3. at least resource ref or resource spec is defined
+// validatePipelineResources validates the resources bound to a Task with
+// validateResourceBindings.
 func validatePipelineResources(ctx context.Context, resources []TaskResourceBinding, path string) *apis.FieldError {
-	encountered := map[string]struct{}{}
+	bindings := make([]PipelineResourceBinding, 0, len(resources))
 	for _, r := range resources {
-		// We should provide only one binding for each resource required by the Task.
-		name := strings.ToLower(r.Name)
-		if _, ok := encountered[strings.ToLower(name)]; ok {
-			return apis.ErrMultipleOneOf(path)
-		}
-		encountered[name] = struct{}{}
-		// Check that both resource ref and resource Spec are not present
-		if r.ResourceRef.Name != "" && r.ResourceSpec != nil {
-			return apis.ErrDisallowedFields(fmt.Sprintf("%s.ResourceRef", path), fmt.Sprintf("%s.ResourceSpec", path))
-		}
-		// Check that one of resource ref and resource Spec is present
-		if r.ResourceRef.Name == "" && r.ResourceSpec == nil {
-			return apis.ErrMissingField(fmt.Sprintf("%s.ResourceRef", path), fmt.Sprintf("%s.ResourceSpec", path))
-		}
-		if r.ResourceSpec != nil && r.ResourceSpec.Validate(ctx) != nil {
-			return r.ResourceSpec.Validate(ctx)
-		}
+		bindings = append(bindings, PipelineResourceBinding{Name: r.Name, ResourceRef: r.ResourceRef, ResourceSpec: r.ResourceSpec})
 	}
-
-	return nil
+	return validateResourceBindings(ctx, bindings, path)
 }
 
 func validateParameters(params []Param) *apis.FieldError {
@@ -18,6 +18,7 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/knative/pkg/apis"
@@ -113,6 +114,29 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 			wantErr: apis.ErrDisallowedFields("spec.taskspec", "spec.taskref"),
 		},
+		{
+			name: "debug without breakpoints",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Debug: &TaskRunDebug{},
+			},
+			wantErr: apis.ErrMissingField("spec.debug.breakpoints"),
+		},
+		{
+			name: "negative debug timeout",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Debug: &TaskRunDebug{
+					Breakpoints: []string{BreakpointOnFailure},
+					Timeout:     &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			wantErr: apis.ErrInvalidValue("-1m0s", "spec.debug.timeout"),
+		},
 	}
 
 	for _, ts := range tests {
@@ -141,6 +165,17 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "debug on failure",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Debug: &TaskRunDebug{
+					Breakpoints: []string{BreakpointOnFailure, "mystep"},
+				},
+			},
+		},
 	}
 
 	for _, ts := range tests {
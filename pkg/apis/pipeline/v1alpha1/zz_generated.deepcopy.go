@@ -22,6 +22,7 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -73,137 +74,129 @@ func (in *ArtifactPVC) DeepCopy() *ArtifactPVC {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildGCSResource) DeepCopyInto(out *BuildGCSResource) {
+func (in *ArtifactResult) DeepCopyInto(out *ArtifactResult) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildGCSResource.
-func (in *BuildGCSResource) DeepCopy() *BuildGCSResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactResult.
+func (in *ArtifactResult) DeepCopy() *ArtifactResult {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildGCSResource)
+	out := new(ArtifactResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterResource) DeepCopyInto(out *ClusterResource) {
+func (in *ArtifactRetention) DeepCopyInto(out *ArtifactRetention) {
 	*out = *in
-	if in.CAData != nil {
-		in, out := &in.CAData, &out.CAData
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
-	}
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
-		*out = make([]SecretParam, len(*in))
-		copy(*out, *in)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResource.
-func (in *ClusterResource) DeepCopy() *ClusterResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactRetention.
+func (in *ArtifactRetention) DeepCopy() *ArtifactRetention {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterResource)
+	out := new(ArtifactRetention)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTask) DeepCopyInto(out *ClusterTask) {
+func (in *ArtifactS3Bucket) DeepCopyInto(out *ArtifactS3Bucket) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTask.
-func (in *ClusterTask) DeepCopy() *ClusterTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactS3Bucket.
+func (in *ArtifactS3Bucket) DeepCopy() *ArtifactS3Bucket {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTask)
+	out := new(ArtifactS3Bucket)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTask) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTaskList) DeepCopyInto(out *ClusterTaskList) {
+func (in *ArtifactStorageStatus) DeepCopyInto(out *ArtifactStorageStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterTask, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTaskList.
-func (in *ClusterTaskList) DeepCopy() *ClusterTaskList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactStorageStatus.
+func (in *ArtifactStorageStatus) DeepCopy() *ArtifactStorageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTaskList)
+	out := new(ArtifactStorageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTaskList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildGCSResource) DeepCopyInto(out *BuildGCSResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildGCSResource.
+func (in *BuildGCSResource) DeepCopy() *BuildGCSResource {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(BuildGCSResource)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DAG) DeepCopyInto(out *DAG) {
+func (in *CacheStorage) DeepCopyInto(out *CacheStorage) {
 	*out = *in
-	if in.Nodes != nil {
-		in, out := &in.Nodes, &out.Nodes
-		*out = make(map[string]*Node, len(*in))
-		for key, val := range *in {
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				(*out)[key] = new(Node)
-				val.DeepCopyInto((*out)[key])
-			}
-		}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DAG.
-func (in *DAG) DeepCopy() *DAG {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheStorage.
+func (in *CacheStorage) DeepCopy() *CacheStorage {
 	if in == nil {
 		return nil
 	}
-	out := new(DAG)
+	out := new(CacheStorage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCSResource) DeepCopyInto(out *GCSResource) {
+func (in *ChartResource) DeepCopyInto(out *ChartResource) {
 	*out = *in
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
@@ -213,209 +206,217 @@ func (in *GCSResource) DeepCopyInto(out *GCSResource) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSResource.
-func (in *GCSResource) DeepCopy() *GCSResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartResource.
+func (in *ChartResource) DeepCopy() *ChartResource {
 	if in == nil {
 		return nil
 	}
-	out := new(GCSResource)
+	out := new(ChartResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitResource) DeepCopyInto(out *GitResource) {
+func (in *CloudEventDelivery) DeepCopyInto(out *CloudEventDelivery) {
 	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitResource.
-func (in *GitResource) DeepCopy() *GitResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventDelivery.
+func (in *CloudEventDelivery) DeepCopy() *CloudEventDelivery {
 	if in == nil {
 		return nil
 	}
-	out := new(GitResource)
+	out := new(CloudEventDelivery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageResource) DeepCopyInto(out *ImageResource) {
+func (in *CloudEventDeliveryState) DeepCopyInto(out *CloudEventDeliveryState) {
 	*out = *in
+	if in.SentAt != nil {
+		in, out := &in.SentAt, &out.SentAt
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageResource.
-func (in *ImageResource) DeepCopy() *ImageResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventDeliveryState.
+func (in *CloudEventDeliveryState) DeepCopy() *CloudEventDeliveryState {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageResource)
+	out := new(CloudEventDeliveryState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Inputs) DeepCopyInto(out *Inputs) {
+func (in *CloudEventResource) DeepCopyInto(out *CloudEventResource) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]TaskResource, len(*in))
-		copy(*out, *in)
-	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]TaskParam, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Inputs.
-func (in *Inputs) DeepCopy() *Inputs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventResource.
+func (in *CloudEventResource) DeepCopy() *CloudEventResource {
 	if in == nil {
 		return nil
 	}
-	out := new(Inputs)
+	out := new(CloudEventResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Node) DeepCopyInto(out *Node) {
+func (in *ClusterContext) DeepCopyInto(out *ClusterContext) {
 	*out = *in
-	in.Task.DeepCopyInto(&out.Task)
-	if in.Prev != nil {
-		in, out := &in.Prev, &out.Prev
-		*out = make([]*Node, len(*in))
-		for i := range *in {
-			if (*in)[i] == nil {
-				(*out)[i] = nil
-			} else {
-				(*out)[i] = new(Node)
-				(*in)[i].DeepCopyInto((*out)[i])
-			}
-		}
-	}
-	if in.Next != nil {
-		in, out := &in.Next, &out.Next
-		*out = make([]*Node, len(*in))
-		for i := range *in {
-			if (*in)[i] == nil {
-				(*out)[i] = nil
-			} else {
-				(*out)[i] = new(Node)
-				(*in)[i].DeepCopyInto((*out)[i])
-			}
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
-func (in *Node) DeepCopy() *Node {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterContext.
+func (in *ClusterContext) DeepCopy() *ClusterContext {
 	if in == nil {
 		return nil
 	}
-	out := new(Node)
+	out := new(ClusterContext)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Outputs) DeepCopyInto(out *Outputs) {
+func (in *ClusterExecConfig) DeepCopyInto(out *ClusterExecConfig) {
 	*out = *in
-	if in.Results != nil {
-		in, out := &in.Results, &out.Results
-		*out = make([]TestResult, len(*in))
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]TaskResource, len(*in))
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]ClusterExecEnvVar, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Outputs.
-func (in *Outputs) DeepCopy() *Outputs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExecConfig.
+func (in *ClusterExecConfig) DeepCopy() *ClusterExecConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(Outputs)
+	out := new(ClusterExecConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Param) DeepCopyInto(out *Param) {
+func (in *ClusterExecEnvVar) DeepCopyInto(out *ClusterExecEnvVar) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Param.
-func (in *Param) DeepCopy() *Param {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExecEnvVar.
+func (in *ClusterExecEnvVar) DeepCopy() *ClusterExecEnvVar {
 	if in == nil {
 		return nil
 	}
-	out := new(Param)
+	out := new(ClusterExecEnvVar)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+func (in *ClusterResource) DeepCopyInto(out *ClusterResource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.CAData != nil {
+		in, out := &in.CAData, &out.CAData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientCertificateData != nil {
+		in, out := &in.ClientCertificateData, &out.ClientCertificateData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientKeyData != nil {
+		in, out := &in.ClientKeyData, &out.ClientKeyData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ClusterExecConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Contexts != nil {
+		in, out := &in.Contexts, &out.Contexts
+		*out = make([]ClusterContext, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pipeline.
-func (in *Pipeline) DeepCopy() *Pipeline {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResource.
+func (in *ClusterResource) DeepCopy() *ClusterResource {
 	if in == nil {
 		return nil
 	}
-	out := new(Pipeline)
+	out := new(ClusterResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Pipeline) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineDeclaredResource) DeepCopyInto(out *PipelineDeclaredResource) {
+func (in *ClusterStepTemplate) DeepCopyInto(out *ClusterStepTemplate) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineDeclaredResource.
-func (in *PipelineDeclaredResource) DeepCopy() *PipelineDeclaredResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStepTemplate.
+func (in *ClusterStepTemplate) DeepCopy() *ClusterStepTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineDeclaredResource)
+	out := new(ClusterStepTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterStepTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineList) DeepCopyInto(out *PipelineList) {
+func (in *ClusterStepTemplateList) DeepCopyInto(out *ClusterStepTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Pipeline, len(*in))
+		*out = make([]ClusterStepTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -423,18 +424,18 @@ func (in *PipelineList) DeepCopyInto(out *PipelineList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineList.
-func (in *PipelineList) DeepCopy() *PipelineList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStepTemplateList.
+func (in *ClusterStepTemplateList) DeepCopy() *ClusterStepTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineList)
+	out := new(ClusterStepTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineList) DeepCopyObject() runtime.Object {
+func (in *ClusterStepTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -442,17 +443,405 @@ func (in *PipelineList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineParam) DeepCopyInto(out *PipelineParam) {
+func (in *ClusterTask) DeepCopyInto(out *ClusterTask) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineParam.
-func (in *PipelineParam) DeepCopy() *PipelineParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTask.
+func (in *ClusterTask) DeepCopy() *ClusterTask {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineParam)
+	out := new(ClusterTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTaskList) DeepCopyInto(out *ClusterTaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTaskList.
+func (in *ClusterTaskList) DeepCopy() *ClusterTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResource) DeepCopyInto(out *CustomResource) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	if in.Download != nil {
+		in, out := &in.Download, &out.Download
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Upload != nil {
+		in, out := &in.Upload, &out.Upload
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResource.
+func (in *CustomResource) DeepCopy() *CustomResource {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DAG) DeepCopyInto(out *DAG) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make(map[string]*Node, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(Node)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DAG.
+func (in *DAG) DeepCopy() *DAG {
+	if in == nil {
+		return nil
+	}
+	out := new(DAG)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSResource) DeepCopyInto(out *GCSResource) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSResource.
+func (in *GCSResource) DeepCopy() *GCSResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitResource) DeepCopyInto(out *GitResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitResource.
+func (in *GitResource) DeepCopy() *GitResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPResource) DeepCopyInto(out *HTTPResource) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPResource.
+func (in *HTTPResource) DeepCopy() *HTTPResource {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageResource) DeepCopyInto(out *ImageResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageResource.
+func (in *ImageResource) DeepCopy() *ImageResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Inputs) DeepCopyInto(out *Inputs) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]TaskResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]TaskParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Inputs.
+func (in *Inputs) DeepCopy() *Inputs {
+	if in == nil {
+		return nil
+	}
+	out := new(Inputs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Node) DeepCopyInto(out *Node) {
+	*out = *in
+	in.Task.DeepCopyInto(&out.Task)
+	if in.Prev != nil {
+		in, out := &in.Prev, &out.Prev
+		*out = make([]*Node, len(*in))
+		for i := range *in {
+			if (*in)[i] == nil {
+				(*out)[i] = nil
+			} else {
+				(*out)[i] = new(Node)
+				(*in)[i].DeepCopyInto((*out)[i])
+			}
+		}
+	}
+	if in.Next != nil {
+		in, out := &in.Next, &out.Next
+		*out = make([]*Node, len(*in))
+		for i := range *in {
+			if (*in)[i] == nil {
+				(*out)[i] = nil
+			} else {
+				(*out)[i] = new(Node)
+				(*in)[i].DeepCopyInto((*out)[i])
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
+func (in *Node) DeepCopy() *Node {
+	if in == nil {
+		return nil
+	}
+	out := new(Node)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Outputs) DeepCopyInto(out *Outputs) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TestResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]TaskResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Outputs.
+func (in *Outputs) DeepCopy() *Outputs {
+	if in == nil {
+		return nil
+	}
+	out := new(Outputs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Param) DeepCopyInto(out *Param) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Param.
+func (in *Param) DeepCopy() *Param {
+	if in == nil {
+		return nil
+	}
+	out := new(Param)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pipeline.
+func (in *Pipeline) DeepCopy() *Pipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(Pipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Pipeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineDeclaredResource) DeepCopyInto(out *PipelineDeclaredResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineDeclaredResource.
+func (in *PipelineDeclaredResource) DeepCopy() *PipelineDeclaredResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineDeclaredResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineList) DeepCopyInto(out *PipelineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Pipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineList.
+func (in *PipelineList) DeepCopy() *PipelineList {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineParam) DeepCopyInto(out *PipelineParam) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineParam.
+func (in *PipelineParam) DeepCopy() *PipelineParam {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineParam)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -463,190 +852,738 @@ func (in *PipelineRef) DeepCopyInto(out *PipelineRef) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRef.
-func (in *PipelineRef) DeepCopy() *PipelineRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRef.
+func (in *PipelineRef) DeepCopy() *PipelineRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResource) DeepCopyInto(out *PipelineResource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResource.
+func (in *PipelineResource) DeepCopy() *PipelineResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineResource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResourceBinding) DeepCopyInto(out *PipelineResourceBinding) {
+	*out = *in
+	out.ResourceRef = in.ResourceRef
+	if in.ResourceSpec != nil {
+		in, out := &in.ResourceSpec, &out.ResourceSpec
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineResourceSpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceBinding.
+func (in *PipelineResourceBinding) DeepCopy() *PipelineResourceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResourceList) DeepCopyInto(out *PipelineResourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PipelineResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceList.
+func (in *PipelineResourceList) DeepCopy() *PipelineResourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineResourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResourceRef) DeepCopyInto(out *PipelineResourceRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceRef.
+func (in *PipelineResourceRef) DeepCopy() *PipelineResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResourceResult) DeepCopyInto(out *PipelineResourceResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceResult.
+func (in *PipelineResourceResult) DeepCopy() *PipelineResourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResourceSpec) DeepCopyInto(out *PipelineResourceSpec) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretParams != nil {
+		in, out := &in.SecretParams, &out.SecretParams
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceSpec.
+func (in *PipelineResourceSpec) DeepCopy() *PipelineResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResourceStatus) DeepCopyInto(out *PipelineResourceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceStatus.
+func (in *PipelineResourceStatus) DeepCopy() *PipelineResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRun) DeepCopyInto(out *PipelineRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRun.
+func (in *PipelineRun) DeepCopy() *PipelineRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PipelineRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunList.
+func (in *PipelineRunList) DeepCopy() *PipelineRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
+	*out = *in
+	out.PipelineRef = in.PipelineRef
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PipelineResourceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		copy(*out, *in)
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Results)
+			**out = **in
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Affinity)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodTemplate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ArtifactRetention != nil {
+		in, out := &in.ArtifactRetention, &out.ArtifactRetention
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ArtifactRetention)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ArtifactQuota != nil {
+		in, out := &in.ArtifactQuota, &out.ArtifactQuota
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(resource.Quantity)
+			**out = (*in).DeepCopy()
+		}
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunSpec.
+func (in *PipelineRunSpec) DeepCopy() *PipelineRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunStatus) DeepCopyInto(out *PipelineRunStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Results)
+			**out = **in
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.TaskRuns != nil {
+		in, out := &in.TaskRuns, &out.TaskRuns
+		*out = make(map[string]*PipelineRunTaskRunStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunTaskRunStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	if in.ArtifactStorage != nil {
+		in, out := &in.ArtifactStorage, &out.ArtifactStorage
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ArtifactStorageStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunStatus.
+func (in *PipelineRunStatus) DeepCopy() *PipelineRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskRunStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTaskRunStatus.
+func (in *PipelineRunTaskRunStatus) DeepCopy() *PipelineRunTaskRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunTaskRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PipelineDeclaredResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]PipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]PipelineParam, len(*in))
+		copy(*out, *in)
+	}
+	if in.RunRetention != nil {
+		in, out := &in.RunRetention, &out.RunRetention
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(RunRetention)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSpec.
+func (in *PipelineSpec) DeepCopy() *PipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStatus) DeepCopyInto(out *PipelineStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStatus.
+func (in *PipelineStatus) DeepCopy() *PipelineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
+	*out = *in
+	out.TaskRef = in.TaskRef
+	if in.RunAfter != nil {
+		in, out := &in.RunAfter, &out.RunAfter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineTaskResources)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTask.
+func (in *PipelineTask) DeepCopy() *PipelineTask {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskInputResource.
+func (in *PipelineTaskInputResource) DeepCopy() *PipelineTaskInputResource {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRef)
+	out := new(PipelineTaskInputResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResource) DeepCopyInto(out *PipelineResource) {
+func (in *PipelineTaskOutputResource) DeepCopyInto(out *PipelineTaskOutputResource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResource.
-func (in *PipelineResource) DeepCopy() *PipelineResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskOutputResource.
+func (in *PipelineTaskOutputResource) DeepCopy() *PipelineTaskOutputResource {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResource)
+	out := new(PipelineTaskOutputResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineResource) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceBinding) DeepCopyInto(out *PipelineResourceBinding) {
+func (in *PipelineTaskParam) DeepCopyInto(out *PipelineTaskParam) {
 	*out = *in
-	out.ResourceRef = in.ResourceRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceBinding.
-func (in *PipelineResourceBinding) DeepCopy() *PipelineResourceBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskParam.
+func (in *PipelineTaskParam) DeepCopy() *PipelineTaskParam {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceBinding)
+	out := new(PipelineTaskParam)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceList) DeepCopyInto(out *PipelineResourceList) {
+func (in *PipelineTaskResources) DeepCopyInto(out *PipelineTaskResources) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PipelineResource, len(*in))
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]PipelineTaskInputResource, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]PipelineTaskOutputResource, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceList.
-func (in *PipelineResourceList) DeepCopy() *PipelineResourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskResources.
+func (in *PipelineTaskResources) DeepCopy() *PipelineTaskResources {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceList)
+	out := new(PipelineTaskResources)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineResourceList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceRef) DeepCopyInto(out *PipelineResourceRef) {
+func (in *PipelineTaskRun) DeepCopyInto(out *PipelineTaskRun) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceRef.
-func (in *PipelineResourceRef) DeepCopy() *PipelineResourceRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRun.
+func (in *PipelineTaskRun) DeepCopy() *PipelineTaskRun {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceRef)
+	out := new(PipelineTaskRun)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceResult) DeepCopyInto(out *PipelineResourceResult) {
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
 	*out = *in
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.PodSecurityContext)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.PodDNSConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceResult.
-func (in *PipelineResourceResult) DeepCopy() *PipelineResourceResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceResult)
+	out := new(PodTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceSpec) DeepCopyInto(out *PipelineResourceSpec) {
+func (in *PullRequestResource) DeepCopyInto(out *PullRequestResource) {
 	*out = *in
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]Param, len(*in))
-		copy(*out, *in)
-	}
-	if in.SecretParams != nil {
-		in, out := &in.SecretParams, &out.SecretParams
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
 		*out = make([]SecretParam, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceSpec.
-func (in *PipelineResourceSpec) DeepCopy() *PipelineResourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestResource.
+func (in *PullRequestResource) DeepCopy() *PullRequestResource {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceSpec)
+	out := new(PullRequestResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceStatus) DeepCopyInto(out *PipelineResourceStatus) {
+func (in *ResolvedImage) DeepCopyInto(out *ResolvedImage) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceStatus.
-func (in *PipelineResourceStatus) DeepCopy() *PipelineResourceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedImage.
+func (in *ResolvedImage) DeepCopy() *ResolvedImage {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceStatus)
+	out := new(ResolvedImage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRun) DeepCopyInto(out *PipelineRun) {
+func (in *ResourceType) DeepCopyInto(out *ResourceType) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRun.
-func (in *PipelineRun) DeepCopy() *PipelineRun {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceType.
+func (in *ResourceType) DeepCopy() *ResourceType {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRun)
+	out := new(ResourceType)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineRun) DeepCopyObject() runtime.Object {
+func (in *ResourceType) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -654,13 +1591,13 @@ func (in *PipelineRun) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
+func (in *ResourceTypeList) DeepCopyInto(out *ResourceTypeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PipelineRun, len(*in))
+		*out = make([]ResourceType, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -668,400 +1605,273 @@ func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunList.
-func (in *PipelineRunList) DeepCopy() *PipelineRunList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTypeList.
+func (in *ResourceTypeList) DeepCopy() *ResourceTypeList {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunList)
+	out := new(ResourceTypeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineRunList) DeepCopyObject() runtime.Object {
+func (in *ResourceTypeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
-	*out = *in
-	out.PipelineRef = in.PipelineRef
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]PipelineResourceBinding, len(*in))
-		copy(*out, *in)
-	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]Param, len(*in))
-		copy(*out, *in)
-	}
-	if in.Results != nil {
-		in, out := &in.Results, &out.Results
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(Results)
-			**out = **in
-		}
-	}
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(meta_v1.Duration)
-			**out = **in
-		}
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(v1.Affinity)
-			(*in).DeepCopyInto(*out)
-		}
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunSpec.
-func (in *PipelineRunSpec) DeepCopy() *PipelineRunSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(PipelineRunSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunStatus) DeepCopyInto(out *PipelineRunStatus) {
-	*out = *in
-	in.Status.DeepCopyInto(&out.Status)
-	if in.Results != nil {
-		in, out := &in.Results, &out.Results
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(Results)
-			**out = **in
-		}
-	}
-	if in.StartTime != nil {
-		in, out := &in.StartTime, &out.StartTime
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(meta_v1.Time)
-			(*in).DeepCopyInto(*out)
-		}
-	}
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(meta_v1.Time)
-			(*in).DeepCopyInto(*out)
-		}
-	}
-	if in.TaskRuns != nil {
-		in, out := &in.TaskRuns, &out.TaskRuns
-		*out = make(map[string]*PipelineRunTaskRunStatus, len(*in))
-		for key, val := range *in {
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				(*out)[key] = new(PipelineRunTaskRunStatus)
-				val.DeepCopyInto((*out)[key])
-			}
-		}
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunStatus.
-func (in *PipelineRunStatus) DeepCopy() *PipelineRunStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(PipelineRunStatus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus) {
+func (in *ResourceTypeParam) DeepCopyInto(out *ResourceTypeParam) {
 	*out = *in
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(TaskRunStatus)
-			(*in).DeepCopyInto(*out)
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTaskRunStatus.
-func (in *PipelineRunTaskRunStatus) DeepCopy() *PipelineRunTaskRunStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTypeParam.
+func (in *ResourceTypeParam) DeepCopy() *ResourceTypeParam {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunTaskRunStatus)
+	out := new(ResourceTypeParam)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
+func (in *ResourceTypeSpec) DeepCopyInto(out *ResourceTypeSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]PipelineDeclaredResource, len(*in))
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]ResourceTypeParam, len(*in))
 		copy(*out, *in)
 	}
-	if in.Tasks != nil {
-		in, out := &in.Tasks, &out.Tasks
-		*out = make([]PipelineTask, len(*in))
+	if in.Download != nil {
+		in, out := &in.Download, &out.Download
+		*out = make([]v1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]PipelineParam, len(*in))
-		copy(*out, *in)
+	if in.Upload != nil {
+		in, out := &in.Upload, &out.Upload
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSpec.
-func (in *PipelineSpec) DeepCopy() *PipelineSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTypeSpec.
+func (in *ResourceTypeSpec) DeepCopy() *ResourceTypeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineSpec)
+	out := new(ResourceTypeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineStatus) DeepCopyInto(out *PipelineStatus) {
+func (in *Results) DeepCopyInto(out *Results) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStatus.
-func (in *PipelineStatus) DeepCopy() *PipelineStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Results.
+func (in *Results) DeepCopy() *Results {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineStatus)
+	out := new(Results)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
+func (in *RunRetention) DeepCopyInto(out *RunRetention) {
 	*out = *in
-	out.TaskRef = in.TaskRef
-	if in.RunAfter != nil {
-		in, out := &in.RunAfter, &out.RunAfter
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
 		if *in == nil {
 			*out = nil
 		} else {
-			*out = new(PipelineTaskResources)
-			(*in).DeepCopyInto(*out)
+			*out = new(meta_v1.Duration)
+			**out = **in
 		}
 	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]Param, len(*in))
-		copy(*out, *in)
+	if in.Keep != nil {
+		in, out := &in.Keep, &out.Keep
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int32)
+			**out = **in
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTask.
-func (in *PipelineTask) DeepCopy() *PipelineTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunRetention.
+func (in *RunRetention) DeepCopy() *RunRetention {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTask)
+	out := new(RunRetention)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
+func (in *S3Resource) DeepCopyInto(out *S3Resource) {
 	*out = *in
-	if in.From != nil {
-		in, out := &in.From, &out.From
-		*out = make([]string, len(*in))
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskInputResource.
-func (in *PipelineTaskInputResource) DeepCopy() *PipelineTaskInputResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Resource.
+func (in *S3Resource) DeepCopy() *S3Resource {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskInputResource)
+	out := new(S3Resource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskOutputResource) DeepCopyInto(out *PipelineTaskOutputResource) {
+func (in *SecretParam) DeepCopyInto(out *SecretParam) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskOutputResource.
-func (in *PipelineTaskOutputResource) DeepCopy() *PipelineTaskOutputResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretParam.
+func (in *SecretParam) DeepCopy() *SecretParam {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskOutputResource)
+	out := new(SecretParam)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskParam) DeepCopyInto(out *PipelineTaskParam) {
+func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
+	in.ContainerState.DeepCopyInto(&out.ContainerState)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskParam.
-func (in *PipelineTaskParam) DeepCopy() *PipelineTaskParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepState.
+func (in *StepState) DeepCopy() *StepState {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskParam)
+	out := new(StepState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskResources) DeepCopyInto(out *PipelineTaskResources) {
+func (in *StepTemplate) DeepCopyInto(out *StepTemplate) {
 	*out = *in
-	if in.Inputs != nil {
-		in, out := &in.Inputs, &out.Inputs
-		*out = make([]PipelineTaskInputResource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Outputs != nil {
-		in, out := &in.Outputs, &out.Outputs
-		*out = make([]PipelineTaskOutputResource, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskResources.
-func (in *PipelineTaskResources) DeepCopy() *PipelineTaskResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepTemplate.
+func (in *StepTemplate) DeepCopy() *StepTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskResources)
+	out := new(StepTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskRun) DeepCopyInto(out *PipelineTaskRun) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRun.
-func (in *PipelineTaskRun) DeepCopy() *PipelineTaskRun {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StepTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(PipelineTaskRun)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Results) DeepCopyInto(out *Results) {
+func (in *StepTemplateList) DeepCopyInto(out *StepTemplateList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StepTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Results.
-func (in *Results) DeepCopy() *Results {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepTemplateList.
+func (in *StepTemplateList) DeepCopy() *StepTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(Results)
+	out := new(StepTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StepTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretParam) DeepCopyInto(out *SecretParam) {
+func (in *StepTemplateRef) DeepCopyInto(out *StepTemplateRef) {
 	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretParam.
-func (in *SecretParam) DeepCopy() *SecretParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepTemplateRef.
+func (in *StepTemplateRef) DeepCopy() *StepTemplateRef {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretParam)
+	out := new(StepTemplateRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StepState) DeepCopyInto(out *StepState) {
+func (in *StepTemplateSpec) DeepCopyInto(out *StepTemplateSpec) {
 	*out = *in
-	in.ContainerState.DeepCopyInto(&out.ContainerState)
+	in.Container.DeepCopyInto(&out.Container)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepState.
-func (in *StepState) DeepCopy() *StepState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepTemplateSpec.
+func (in *StepTemplateSpec) DeepCopy() *StepTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(StepState)
+	out := new(StepTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1093,6 +1903,32 @@ func (in *Task) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskCache) DeepCopyInto(out *TaskCache) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeyFiles != nil {
+		in, out := &in.KeyFiles, &out.KeyFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskCache.
+func (in *TaskCache) DeepCopy() *TaskCache {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskList) DeepCopyInto(out *TaskList) {
 	*out = *in
@@ -1192,6 +2028,11 @@ func (in *TaskResourceBinding) DeepCopyInto(out *TaskResourceBinding) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]ArtifactResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1233,6 +2074,52 @@ func (in *TaskRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunDebug) DeepCopyInto(out *TaskRunDebug) {
+	*out = *in
+	if in.Breakpoints != nil {
+		in, out := &in.Breakpoints, &out.Breakpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunDebug.
+func (in *TaskRunDebug) DeepCopy() *TaskRunDebug {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunDebug)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunDebugStatus) DeepCopyInto(out *TaskRunDebugStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunDebugStatus.
+func (in *TaskRunDebugStatus) DeepCopy() *TaskRunDebugStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunDebugStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunInputs) DeepCopyInto(out *TaskRunInputs) {
 	*out = *in
@@ -1386,6 +2273,24 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodTemplate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskRunDebug)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -1449,6 +2354,32 @@ func (in *TaskRunStatus) DeepCopyInto(out *TaskRunStatus) {
 		*out = make([]PipelineResourceResult, len(*in))
 		copy(*out, *in)
 	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]ArtifactResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResolvedImages != nil {
+		in, out := &in.ResolvedImages, &out.ResolvedImages
+		*out = make([]ResolvedImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskRunDebugStatus)
+			**out = **in
+		}
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = make([]CloudEventDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1506,6 +2437,20 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.StepTemplates != nil {
+		in, out := &in.StepTemplates, &out.StepTemplates
+		*out = make([]StepTemplateRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Caches != nil {
+		in, out := &in.Caches, &out.Caches
+		*out = make([]TaskCache, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
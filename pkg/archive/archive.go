@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package archive writes directories as compressed archives and keeps them
+// in buckets or directories, to carry them across Pods.
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Pack writes a compressed archive of the directories of paths to w. The
+// content of each directory is archived under its index in paths, so that it
+// can be extracted into the same path. Paths which don't exist are skipped,
+// and so are symlinks which Unpack refuses since they point outside of their
+// path, e.g. the interpreter of a virtualenv.
+func Pack(w io.Writer, paths []string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for i, dir := range paths {
+		if _, err := os.Lstat(dir); os.IsNotExist(err) {
+			continue
+		}
+		prefix := strconv.Itoa(i)
+		root := filepath.Clean(dir)
+		err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			var link string
+			if info.Mode()&os.ModeSymlink != 0 {
+				if link, err = os.Readlink(p); err != nil {
+					return err
+				}
+				if !linkWithin(root, filepath.Clean(p), link) {
+					return nil
+				}
+			} else if !info.Mode().IsRegular() && !info.IsDir() {
+				// Sockets, devices and pipes can't be restored.
+				return nil
+			}
+			hdr, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			hdr.Name = path.Join(prefix, filepath.ToSlash(rel))
+			if info.IsDir() {
+				hdr.Name += "/"
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err != nil {
+			return xerrors.Errorf("archiving %s: %w", dir, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// Unpack extracts the archive written by Pack from r into paths.
+func Unpack(r io.Reader, paths []string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return xerrors.Errorf("reading archive: %w", err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("reading archive: %w", err)
+		}
+		parts := strings.SplitN(strings.TrimPrefix(hdr.Name, "./"), "/", 2)
+		i, err := strconv.Atoi(parts[0])
+		if err != nil || i < 0 || i >= len(paths) {
+			// The paths changed since the archive was written.
+			continue
+		}
+		rel := ""
+		if len(parts) == 2 {
+			rel = parts[1]
+		}
+		if err := ExtractEntry(paths[i], rel, hdr, tr); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPackUnpack(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "src")
+	writeFiles(t, src, map[string]string{"node_modules/left-pad/index.js": "module.exports = leftPad"})
+	if err := os.MkdirAll(filepath.Join(src, "node_modules", ".bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../left-pad/index.js", filepath.Join(src, "node_modules", ".bin", "left-pad")); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Pack(&buf, []string{src, filepath.Join(dir, "missing")}); err != nil {
+		t.Fatalf("Unexpected error packing: %v", err)
+	}
+	dest := filepath.Join(dir, "dest")
+	if err := Unpack(&buf, []string{dest, filepath.Join(dir, "missing")}); err != nil {
+		t.Fatalf("Unexpected error unpacking: %v", err)
+	}
+
+	got, err := ioutil.ReadFile(filepath.Join(dest, "node_modules", ".bin", "left-pad"))
+	if err != nil {
+		t.Fatalf("Expected the symlink to be restored: %v", err)
+	}
+	if string(got) != "module.exports = leftPad" {
+		t.Errorf("Expected the content of the linked file but got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("Expected missing paths not to be created, got %v", err)
+	}
+}
+
+func TestPackSkipsLinksOutsideOfPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "src")
+	writeFiles(t, src, map[string]string{"venv/lib/site.py": "import os"})
+	for name, target := range map[string]string{
+		"venv/bin/python": "/usr/bin/python3",
+		"venv/bin/up":     "../../../outside",
+		"venv/lib64":      "lib",
+	} {
+		p := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := Pack(&buf, []string{src}); err != nil {
+		t.Fatalf("Unexpected error packing: %v", err)
+	}
+	dest := filepath.Join(dir, "dest")
+	if err := Unpack(&buf, []string{dest}); err != nil {
+		t.Fatalf("Unexpected error unpacking: %v", err)
+	}
+
+	if got, err := ioutil.ReadFile(filepath.Join(dest, "venv", "lib64", "site.py")); err != nil || string(got) != "import os" {
+		t.Errorf("Expected the link within the path to be restored, got %q, %v", got, err)
+	}
+	for _, name := range []string{"python", "up"} {
+		if _, err := os.Lstat(filepath.Join(dest, "venv", "bin", name)); !os.IsNotExist(err) {
+			t.Errorf("Expected the link %s outside of the path to be skipped, got %v", name, err)
+		}
+	}
+}
+
+func TestUnpackOutsideOfPath(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	content := []byte("evil")
+	if err := tw.WriteHeader(&tar.Header{Name: "0/../../evil", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write(content)
+	tw.Close()
+	gw.Close()
+
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := Unpack(&buf, []string{filepath.Join(dir, "a", "dest")}); err == nil {
+		t.Errorf("Expected an error extracting an entry outside of the path")
+	}
+}
+
+func TestUnpackSymlinkOutsideOfPath(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		linkname string
+	}{{
+		name:     "relative",
+		linkname: "../../outside",
+	}, {
+		name:     "absolute",
+		linkname: "/tmp",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gw := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gw)
+			if err := tw.WriteHeader(&tar.Header{Name: "0/link", Linkname: tc.linkname, Mode: 0777, Typeflag: tar.TypeSymlink}); err != nil {
+				t.Fatal(err)
+			}
+			// Written through the link if it were created.
+			content := []byte("evil")
+			if err := tw.WriteHeader(&tar.Header{Name: "0/link/evil", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+				t.Fatal(err)
+			}
+			tw.Write(content)
+			tw.Close()
+			gw.Close()
+
+			dir, err := ioutil.TempDir("", "archive")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			dest := filepath.Join(dir, "a", "dest")
+			if err := Unpack(&buf, []string{dest}); err == nil {
+				t.Errorf("Expected an error extracting a link outside of the path")
+			}
+			if _, err := os.Lstat(filepath.Join(dest, "link")); !os.IsNotExist(err) {
+				t.Errorf("Expected the link not to be created, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUnpackThroughSymlinkChain(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	// Both links point within the path on their own, but m resolves to the
+	// parent of the path through l.
+	for _, hdr := range []*tar.Header{
+		{Name: "0/x/y/l", Linkname: "../..", Mode: 0777, Typeflag: tar.TypeSymlink},
+		{Name: "0/m", Linkname: "x/y/l/..", Mode: 0777, Typeflag: tar.TypeSymlink},
+	} {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	content := []byte("evil")
+	if err := tw.WriteHeader(&tar.Header{Name: "0/m/evil", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write(content)
+	tw.Close()
+	gw.Close()
+
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := Unpack(&buf, []string{filepath.Join(dir, "a", "dest")}); err == nil {
+		t.Errorf("Expected an error extracting an entry through a link")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a", "evil")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be written outside of the path, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"golang.org/x/xerrors"
+)
+
+// checksumPrefix prefixes the hex digests returned by PutDir.
+const checksumPrefix = "sha256:"
+
+func checksum(h hash.Hash) string {
+	return checksumPrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// PutDir archives dir and stores the archive at key in store, replacing any
+// archive already stored there. It returns the checksum of the archive, which
+// GetDir verifies before extracting it, and its size in bytes.
+func PutDir(store Store, key, dir string) (string, int64, error) {
+	f, err := ioutil.TempFile("", "archive-")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	h := sha256.New()
+	if err := Pack(io.MultiWriter(f, h), []string{dir}); err != nil {
+		return "", 0, xerrors.Errorf("archiving %s: %w", dir, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+	if err := store.Put(key, f); err != nil {
+		return "", 0, err
+	}
+	return checksum(h), info.Size(), nil
+}
+
+// GetDir extracts the archive stored at key in store into dir. If sum isn't
+// empty, the archive must have that checksum: nothing is extracted from a
+// corrupt or replaced archive.
+func GetDir(store Store, key, dir, sum string) error {
+	f, err := ioutil.TempFile("", "archive-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	h := sha256.New()
+	found, err := store.Get(key, io.MultiWriter(f, h))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return xerrors.Errorf("no archive stored at %s", key)
+	}
+	if got := checksum(h); sum != "" && got != sum {
+		return xerrors.Errorf("archive %s has checksum %s but %s was expected", key, got, sum)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	// The directory is created even when the archived one was missing, as
+	// steps expect the destination of their inputs to exist.
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := Unpack(f, []string{dir}); err != nil {
+		return xerrors.Errorf("extracting %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPutGetDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "src")
+	writeFiles(t, src, map[string]string{"bin/app": "binary"})
+	store, err := NewStore(filepath.Join(dir, "store"), s3Config())
+	if err != nil {
+		t.Fatalf("Unexpected error creating store: %v", err)
+	}
+
+	sum, size, err := PutDir(store, "pr/build/out", src)
+	if err != nil {
+		t.Fatalf("Unexpected error putting dir: %v", err)
+	}
+	if !strings.HasPrefix(sum, "sha256:") {
+		t.Errorf("Expected a sha256 checksum but got %q", sum)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "store", "pr", "build", "out.tar.gz")); err != nil {
+		t.Errorf("Expected the archive to be stored under the key: %v", err)
+	} else if info.Size() != size {
+		t.Errorf("Expected the size of the archive %d but got %d", info.Size(), size)
+	}
+
+	dest := filepath.Join(dir, "dest")
+	if err := GetDir(store, "pr/build/out", dest, sum); err != nil {
+		t.Fatalf("Unexpected error getting dir: %v", err)
+	}
+	if got, err := ioutil.ReadFile(filepath.Join(dest, "bin", "app")); err != nil || string(got) != "binary" {
+		t.Errorf("Expected the archived file but got %q, err %v", got, err)
+	}
+
+	if err := GetDir(store, "pr/build/out", filepath.Join(dir, "corrupt"), "sha256:00"); err == nil {
+		t.Error("Expected an error for a checksum mismatch")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "corrupt")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be extracted on a checksum mismatch, got %v", err)
+	}
+	if err := GetDir(store, "pr/build/missing", filepath.Join(dir, "missing"), ""); err == nil {
+		t.Error("Expected an error for a missing archive")
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// ExtractEntry writes the tar entry hdr, with its content read from r, to the
+// slash separated path rel under the directory dest. Entries outside of dest,
+// entries under a symlink extracted before and symlinks pointing outside of
+// dest are refused, so that an archive can't write outside of dest. Entries
+// other than directories, regular files and symlinks are skipped.
+func ExtractEntry(dest, rel string, hdr *tar.Header, r io.Reader) error {
+	dest = filepath.Clean(dest)
+	p := filepath.Join(dest, filepath.FromSlash(rel))
+	if !within(dest, p) {
+		return xerrors.Errorf("archive entry %q is outside of %s", hdr.Name, dest)
+	}
+	if err := checkParents(dest, p); err != nil {
+		return xerrors.Errorf("archive entry %q: %w", hdr.Name, err)
+	}
+	var err error
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		err = os.MkdirAll(p, os.FileMode(hdr.Mode).Perm()|0700)
+	case tar.TypeReg, tar.TypeRegA:
+		err = writeFile(p, r, os.FileMode(hdr.Mode))
+	case tar.TypeSymlink:
+		if !linkWithin(dest, p, hdr.Linkname) {
+			return xerrors.Errorf("archive entry %q links to %q outside of %s", hdr.Name, hdr.Linkname, dest)
+		}
+		err = writeSymlink(p, hdr.Linkname)
+	default:
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("extracting %s: %w", hdr.Name, err)
+	}
+	return nil
+}
+
+// within returns true if the cleaned path p is dest or under it.
+func within(dest, p string) bool {
+	return p == dest || strings.HasPrefix(p, dest+string(os.PathSeparator))
+}
+
+// linkWithin returns true if a symlink at p to target points under dest.
+// Whether the target resolves through other links is not checked, entries are
+// never written through links instead.
+func linkWithin(dest, p, target string) bool {
+	if filepath.IsAbs(target) {
+		return false
+	}
+	return within(dest, filepath.Join(filepath.Dir(p), filepath.FromSlash(target)))
+}
+
+// checkParents returns an error if a directory between dest and p is a
+// symlink, since the link may resolve outside of dest.
+func checkParents(dest, p string) error {
+	if p == dest {
+		return nil
+	}
+	rel, err := filepath.Rel(dest, filepath.Dir(p))
+	if err != nil || rel == "." {
+		return err
+	}
+	dir := dest
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		dir = filepath.Join(dir, part)
+		info, err := os.Lstat(dir)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return xerrors.Errorf("%s is a symlink", dir)
+		}
+	}
+	return nil
+}
+
+func writeFile(p string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	// A link extracted before at p would be written through.
+	if info, err := os.Lstat(p); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(p); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeSymlink(p, target string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(target, p)
+}
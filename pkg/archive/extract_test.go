@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "extract")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "dest")
+
+	for _, e := range []struct {
+		hdr     tar.Header
+		content string
+	}{
+		{hdr: tar.Header{Name: "bin/", Mode: 0755, Typeflag: tar.TypeDir}},
+		{hdr: tar.Header{Name: "lib/tool", Mode: 0755, Typeflag: tar.TypeReg, Size: 4}, content: "tool"},
+		{hdr: tar.Header{Name: "bin/tool", Linkname: "../lib/tool", Mode: 0777, Typeflag: tar.TypeSymlink}},
+		{hdr: tar.Header{Name: "dev/null", Mode: 0666, Typeflag: tar.TypeChar}},
+	} {
+		if err := ExtractEntry(dest, e.hdr.Name, &e.hdr, strings.NewReader(e.content)); err != nil {
+			t.Fatalf("Unexpected error extracting %s: %v", e.hdr.Name, err)
+		}
+	}
+
+	got, err := ioutil.ReadFile(filepath.Join(dest, "bin", "tool"))
+	if err != nil {
+		t.Fatalf("Expected the link to be extracted: %v", err)
+	}
+	if string(got) != "tool" {
+		t.Errorf("Expected the content of the linked file but got %q", got)
+	}
+	if _, err := os.Lstat(filepath.Join(dest, "dev")); !os.IsNotExist(err) {
+		t.Errorf("Expected devices to be skipped, got %v", err)
+	}
+}
+
+func TestExtractEntryThroughSymlink(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		entries []tar.Header
+		wantErr bool
+	}{{
+		// Each link points within dest on its own, but m resolves to the
+		// parent of dest through l.
+		name: "chain",
+		entries: []tar.Header{
+			{Name: "x/y/l", Linkname: "../..", Typeflag: tar.TypeSymlink},
+			{Name: "m", Linkname: "x/y/l/..", Typeflag: tar.TypeSymlink},
+			{Name: "m/evil", Mode: 0644, Typeflag: tar.TypeReg, Size: 4},
+		},
+		wantErr: true,
+	}, {
+		// The link is replaced instead of written through.
+		name: "overwritten link",
+		entries: []tar.Header{
+			{Name: "x/y/l", Linkname: "../..", Typeflag: tar.TypeSymlink},
+			{Name: "m", Linkname: "x/y/l/../evil", Typeflag: tar.TypeSymlink},
+			{Name: "m", Mode: 0644, Typeflag: tar.TypeReg, Size: 4},
+			{Name: "x/y/l/../evil", Mode: 0644, Typeflag: tar.TypeReg, Size: 4},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "extract")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			dest := filepath.Join(dir, "a", "dest")
+
+			var extractErr error
+			for i := range tc.entries {
+				hdr := &tc.entries[i]
+				if extractErr = ExtractEntry(dest, hdr.Name, hdr, strings.NewReader("evil")); extractErr != nil {
+					break
+				}
+			}
+			if _, err := os.Stat(filepath.Join(dir, "a", "evil")); !os.IsNotExist(err) {
+				t.Errorf("Expected nothing to be written outside of dest, got %v", err)
+			}
+			if (extractErr != nil) != tc.wantErr {
+				t.Errorf("ExtractEntry() = %v, expected an error: %t", extractErr, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/s3"
+	"golang.org/x/xerrors"
+)
+
+// Store keeps archives by key.
+type Store interface {
+	// Exists returns whether an archive is stored at key.
+	Exists(key string) (bool, error)
+	// Get writes the archive stored at key to w. It returns false if there
+	// is none.
+	Get(key string, w io.Writer) (bool, error)
+	// Put stores the archive read from r at key.
+	Put(key string, r io.ReadSeeker) error
+}
+
+// NewStore returns the Store keeping archives under location: a gs:// or
+// s3:// bucket location, or the path of a directory such as a mounted
+// PersistentVolumeClaim. s3:// buckets are accessed as configured by cfg.
+func NewStore(location string, cfg s3.Config) (Store, error) {
+	switch {
+	case strings.HasPrefix(location, "gs://"):
+		return &gsutilStore{location: strings.TrimSuffix(location, "/")}, nil
+	case strings.HasPrefix(location, "s3://"):
+		bucket, prefix, err := s3.ParseLocation(location)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Bucket = bucket
+		c, err := s3.NewClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		return &s3Store{client: c, prefix: prefix}, nil
+	case filepath.IsAbs(location):
+		return &dirStore{dir: location}, nil
+	}
+	return nil, xerrors.Errorf("archive location %q must be a gs:// or s3:// location or an absolute path", location)
+}
+
+// archiveName returns the name of the archive stored at key.
+func archiveName(key string) string {
+	return key + ".tar.gz"
+}
+
+type dirStore struct {
+	dir string
+}
+
+func (s *dirStore) Exists(key string) (bool, error) {
+	_, err := os.Stat(filepath.Join(s.dir, archiveName(key)))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *dirStore) Get(key string, w io.Writer) (bool, error) {
+	f, err := os.Open(filepath.Join(s.dir, archiveName(key)))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return false, xerrors.Errorf("reading archive %s: %w", key, err)
+	}
+	return true, nil
+}
+
+func (s *dirStore) Put(key string, r io.ReadSeeker) error {
+	target := filepath.Join(s.dir, archiveName(key))
+	dir := filepath.Dir(target)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	// The archive is renamed once complete so that concurrent runs never
+	// restore a partial archive.
+	f, err := ioutil.TempFile(dir, ".tmp-"+filepath.Base(key))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return xerrors.Errorf("writing archive %s: %w", key, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), target)
+}
+
+type s3Store struct {
+	client *s3.Client
+	prefix string
+}
+
+func (s *s3Store) Exists(key string) (bool, error) {
+	name := s.prefix + archiveName(key)
+	keys, err := s.client.ListObjects(name)
+	if err != nil {
+		return false, err
+	}
+	for _, k := range keys {
+		if k == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *s3Store) Get(key string, w io.Writer) (bool, error) {
+	if exists, err := s.Exists(key); err != nil || !exists {
+		return false, err
+	}
+	if err := s.client.GetObject(s.prefix+archiveName(key), w); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *s3Store) Put(key string, r io.ReadSeeker) error {
+	return s.client.PutObject(s.prefix+archiveName(key), r)
+}
+
+// gsutilStore keeps the archives in a GCS bucket using gsutil, which is
+// authenticated the same way as for the gcs storage resources.
+type gsutilStore struct {
+	location string
+}
+
+func (s *gsutilStore) object(key string) string {
+	return s.location + "/" + archiveName(key)
+}
+
+func (s *gsutilStore) Exists(key string) (bool, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("gsutil", "-q", "stat", s.object(key))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// gsutil stat only exits with an error when the object is missing
+		// or the bucket can't be accessed, which it reports.
+		if _, ok := err.(*exec.ExitError); ok && stderr.Len() == 0 {
+			return false, nil
+		}
+		return false, xerrors.Errorf("checking %s: %s: %w", s.object(key), strings.TrimSpace(stderr.String()), err)
+	}
+	return true, nil
+}
+
+func (s *gsutilStore) Get(key string, w io.Writer) (bool, error) {
+	if exists, err := s.Exists(key); err != nil || !exists {
+		return false, err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("gsutil", "-q", "cp", s.object(key), "-")
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return false, xerrors.Errorf("downloading %s: %s: %w", s.object(key), strings.TrimSpace(stderr.String()), err)
+	}
+	return true, nil
+}
+
+func (s *gsutilStore) Put(key string, r io.ReadSeeker) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("gsutil", "-q", "cp", "-", s.object(key))
+	cmd.Stdin = r
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return xerrors.Errorf("uploading %s: %s: %w", s.object(key), strings.TrimSpace(stderr.String()), err)
+	}
+	return nil
+}
+
+// ActivateServiceAccount authenticates gsutil with the key file
+// GOOGLE_APPLICATION_CREDENTIALS points to, if any.
+func ActivateServiceAccount() error {
+	authFilePath := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if authFilePath == "" {
+		return nil
+	}
+	if out, err := exec.Command("gcloud", "auth", "activate-service-account", "--key-file", authFilePath).CombinedOutput(); err != nil {
+		return xerrors.Errorf("authenticating with gcloud: %s: %w", out, err)
+	}
+	return nil
+}
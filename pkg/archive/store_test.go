@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/s3"
+)
+
+func s3Config() s3.Config {
+	return s3.Config{PathStyle: true}
+}
+
+// fakeS3 is a minimal in-memory S3 server for a single path-style bucket.
+type fakeS3 struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	switch {
+	case r.Method == http.MethodPut:
+		b, _ := ioutil.ReadAll(r.Body)
+		f.objects[key] = b
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		type content struct {
+			Key string `xml:"Key"`
+		}
+		var result struct {
+			XMLName  xml.Name  `xml:"ListBucketResult"`
+			Contents []content `xml:"Contents"`
+		}
+		for _, k := range keys {
+			result.Contents = append(result.Contents, content{k})
+		}
+		xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodGet:
+		b, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	default:
+		http.Error(w, "NotImplemented", http.StatusNotImplemented)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	for _, tc := range []struct {
+		location string
+		want     Store
+	}{{
+		location: "gs://bucket/caches/",
+		want:     &gsutilStore{location: "gs://bucket/caches"},
+	}, {
+		location: "/cache",
+		want:     &dirStore{dir: "/cache"},
+	}} {
+		t.Run(tc.location, func(t *testing.T) {
+			got, err := NewStore(tc.location, s3Config())
+			if err != nil {
+				t.Fatalf("Unexpected error creating store: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got, cmp.AllowUnexported(gsutilStore{}, dirStore{})); d != "" {
+				t.Errorf("Unexpected store (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestNewStoreInvalid(t *testing.T) {
+	for _, location := range []string{"cache", "https://bucket/caches", "s3://"} {
+		if _, err := NewStore(location, s3Config()); err == nil {
+			t.Errorf("Expected an error for location %q", location)
+		}
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	cfg := s3Config()
+	cfg.Endpoint = server.URL
+	store, err := NewStore("s3://bucket/caches/ns", cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error creating store: %v", err)
+	}
+
+	if exists, err := store.Exists("deps-1"); err != nil || exists {
+		t.Fatalf("Expected no archive but got exists %t, err %v", exists, err)
+	}
+	if err := store.Put("deps-1", bytes.NewReader([]byte("archive"))); err != nil {
+		t.Fatalf("Unexpected error putting archive: %v", err)
+	}
+	if _, ok := fake.objects["caches/ns/deps-1.tar.gz"]; !ok {
+		t.Errorf("Expected the archive under the location prefix but got %v", fake.objects)
+	}
+	// A key that is a prefix of a stored key must not be found.
+	if exists, err := store.Exists("deps"); err != nil || exists {
+		t.Errorf("Expected no archive for a prefix of a key but got exists %t, err %v", exists, err)
+	}
+	var buf bytes.Buffer
+	if found, err := store.Get("deps-1", &buf); err != nil || !found {
+		t.Fatalf("Expected the archive to be found but got found %t, err %v", found, err)
+	}
+	if buf.String() != "archive" {
+		t.Errorf("Expected the stored archive but got %q", buf.String())
+	}
+	if found, err := store.Get("deps-2", &buf); err != nil || found {
+		t.Errorf("Expected a missing archive but got found %t, err %v", found, err)
+	}
+}
@@ -186,6 +186,38 @@ func TestInitializeArtifactStorageWithConfigMap(t *testing.T) {
 			}},
 		},
 		storagetype: "bucket",
+	}, {
+		desc: "valid s3 bucket",
+		configMap: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: system.GetNamespace(),
+				Name:      v1alpha1.BucketConfigName,
+			},
+			Data: map[string]string{
+				v1alpha1.BucketLocationKey:           "s3://fake-bucket/artifacts",
+				v1alpha1.BucketEndpointKey:           "http://minio:9000",
+				v1alpha1.BucketRegionKey:             "eu-west-1",
+				v1alpha1.BucketPathStyleKey:          "true",
+				v1alpha1.BucketCredentialsSecretName: "minio-creds",
+			},
+		},
+		pipelinerun: pipelinerun,
+		expectedArtifactStorage: &v1alpha1.ArtifactS3Bucket{
+			Location:  "s3://fake-bucket/artifacts",
+			Endpoint:  "http://minio:9000",
+			Region:    "eu-west-1",
+			PathStyle: true,
+			Secrets: []v1alpha1.SecretParam{{
+				FieldName:  "AWS_ACCESS_KEY_ID",
+				SecretKey:  "AWS_ACCESS_KEY_ID",
+				SecretName: "minio-creds",
+			}, {
+				FieldName:  "AWS_SECRET_ACCESS_KEY",
+				SecretKey:  "AWS_SECRET_ACCESS_KEY",
+				SecretName: "minio-creds",
+			}},
+		},
+		storagetype: "s3bucket",
 	}, {
 		desc: "location empty",
 		configMap: &corev1.ConfigMap{
@@ -378,6 +410,101 @@ func TestInitializeArtifactStorageWithoutConfigMap(t *testing.T) {
 	}
 }
 
+func TestInitializeArtifactStorageWithNamespaceConfigMaps(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	for _, c := range []struct {
+		desc                    string
+		configMaps              []*corev1.ConfigMap
+		expectedArtifactStorage ArtifactStorageInterface
+	}{{
+		desc: "namespace pvc overriding the cluster",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: PvcConfigName},
+			Data:       map[string]string{PvcSizeKey: "10Gi"},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: PvcConfigName},
+			Data:       map[string]string{PvcStorageClassNameKey: "fast"},
+		}},
+		expectedArtifactStorage: &v1alpha1.ArtifactPVC{
+			Name: "pipelineruntest",
+			PersistentVolumeClaim: func() *corev1.PersistentVolumeClaim {
+				pvc := GetPersistentVolumeClaim("10Gi")
+				storageClassName := "fast"
+				pvc.Spec.StorageClassName = &storageClassName
+				return pvc
+			}(),
+		},
+	}, {
+		desc: "namespace pvc mounted directly",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: PvcConfigName},
+			Data:       map[string]string{PvcAccessModeKey: "ReadWriteMany", PvcMountDirectKey: "true"},
+		}},
+		expectedArtifactStorage: &v1alpha1.ArtifactPVC{
+			Name: "pipelineruntest",
+			PersistentVolumeClaim: func() *corev1.PersistentVolumeClaim {
+				pvc := GetPersistentVolumeClaim(DefaultPvcSize)
+				pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+				return pvc
+			}(),
+			AccessMode:  corev1.ReadWriteMany,
+			MountDirect: true,
+		},
+	}, {
+		desc: "namespace bucket overriding the cluster",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: v1alpha1.BucketConfigName},
+			Data: map[string]string{
+				v1alpha1.BucketLocationKey:              "gs://cluster-bucket",
+				v1alpha1.BucketServiceAccountSecretName: "cluster-secret",
+				v1alpha1.BucketServiceAccountSecretKey:  "sakey",
+			},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: v1alpha1.BucketConfigName},
+			Data: map[string]string{
+				v1alpha1.BucketLocationKey:              "gs://team-bucket",
+				v1alpha1.BucketServiceAccountSecretName: "team-secret",
+				v1alpha1.BucketServiceAccountSecretKey:  "sakey",
+			},
+		}},
+		expectedArtifactStorage: &v1alpha1.ArtifactBucket{
+			Location: "gs://team-bucket",
+			Secrets: []v1alpha1.SecretParam{{
+				FieldName:  "GOOGLE_APPLICATION_CREDENTIALS",
+				SecretName: "team-secret",
+				SecretKey:  "sakey",
+			}},
+		},
+	}, {
+		desc: "namespace bucket overriding the cluster pvc",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: v1alpha1.BucketConfigName},
+			Data: map[string]string{
+				v1alpha1.BucketLocationKey: "gs://team-bucket",
+			},
+		}},
+		expectedArtifactStorage: &v1alpha1.ArtifactBucket{
+			Location: "gs://team-bucket",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			for _, cm := range c.configMaps {
+				if _, err := fakekubeclient.CoreV1().ConfigMaps(cm.Namespace).Create(cm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			artifactStorage, err := InitializeArtifactStorage(pipelinerun, fakekubeclient, logger)
+			if err != nil {
+				t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
+			}
+			if diff := cmp.Diff(c.expectedArtifactStorage, artifactStorage, quantityComparer); diff != "" {
+				t.Errorf("Unexpected artifact storage (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	for _, c := range []struct {
@@ -405,6 +532,20 @@ func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 				SecretName: "secret1",
 			}},
 		},
+	}, {
+		desc: "valid s3 bucket",
+		configMap: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: system.GetNamespace(),
+				Name:      v1alpha1.BucketConfigName,
+			},
+			Data: map[string]string{
+				v1alpha1.BucketLocationKey: "s3://fake-bucket",
+			},
+		},
+		expectedArtifactStorage: &v1alpha1.ArtifactS3Bucket{
+			Location: "s3://fake-bucket",
+		},
 	}, {
 		desc: "location empty",
 		configMap: &corev1.ConfigMap{
@@ -447,7 +588,7 @@ func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 		t.Run(c.desc, func(t *testing.T) {
 			fakekubeclient := fakek8s.NewSimpleClientset(c.configMap)
 
-			artifactStorage, err := GetArtifactStorage(pipelinerun.Name, fakekubeclient, logger)
+			artifactStorage, err := GetArtifactStorage(pipelinerun.Name, pipelinerun.Namespace, fakekubeclient, logger)
 			if err != nil {
 				t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
 			}
@@ -459,10 +600,42 @@ func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 	}
 }
 
+func TestGetArtifactStorageWithInvalidS3ConfigMap(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	for _, c := range []struct {
+		desc string
+		data map[string]string
+	}{{
+		desc: "no bucket",
+		data: map[string]string{
+			v1alpha1.BucketLocationKey: "s3://",
+		},
+	}, {
+		desc: "invalid path style",
+		data: map[string]string{
+			v1alpha1.BucketLocationKey:  "s3://fake-bucket",
+			v1alpha1.BucketPathStyleKey: "sometimes",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: system.GetNamespace(),
+					Name:      v1alpha1.BucketConfigName,
+				},
+				Data: c.data,
+			})
+			if _, err := GetArtifactStorage(pipelinerun.Name, pipelinerun.Namespace, fakekubeclient, logger); err == nil {
+				t.Error("Expected error getting artifact storage from invalid S3 configuration")
+			}
+		})
+	}
+}
+
 func TestGetArtifactStorageWithoutConfigMap(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvc, err := GetArtifactStorage("pipelineruntest", fakekubeclient, logger)
+	pvc, err := GetArtifactStorage("pipelineruntest", "foo", fakekubeclient, logger)
 	if err != nil {
 		t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
 	}
@@ -497,12 +670,29 @@ func TestGetArtifactStorageWithPvcConfigMap(t *testing.T) {
 		expectedArtifactStorage: &v1alpha1.ArtifactPVC{
 			Name: "pipelineruntest",
 		},
+	}, {
+		desc: "directly mounted pvc",
+		configMap: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: system.GetNamespace(),
+				Name:      PvcConfigName,
+			},
+			Data: map[string]string{
+				PvcAccessModeKey:  "ReadWriteMany",
+				PvcMountDirectKey: "true",
+			},
+		},
+		expectedArtifactStorage: &v1alpha1.ArtifactPVC{
+			Name:        "pipelineruntest",
+			AccessMode:  corev1.ReadWriteMany,
+			MountDirect: true,
+		},
 	},
 	} {
 		t.Run(c.desc, func(t *testing.T) {
 			fakekubeclient := fakek8s.NewSimpleClientset(c.configMap)
 
-			artifactStorage, err := GetArtifactStorage(prName, fakekubeclient, logger)
+			artifactStorage, err := GetArtifactStorage(prName, "foo", fakekubeclient, logger)
 			if err != nil {
 				t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
 			}
@@ -513,3 +703,131 @@ func TestGetArtifactStorageWithPvcConfigMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGetArtifactStorageWithInvalidPvcConfigMap(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	for _, c := range []struct {
+		desc string
+		data map[string]string
+	}{{
+		desc: "invalid access mode",
+		data: map[string]string{
+			PvcAccessModeKey: "ReadOnlyMany",
+		},
+	}, {
+		desc: "invalid direct mount",
+		data: map[string]string{
+			PvcMountDirectKey: "sometimes",
+		},
+	}, {
+		desc: "quota with direct mount",
+		data: map[string]string{
+			PvcMountDirectKey: "true",
+			PvcQuotaKey:       "2Gi",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: system.GetNamespace(),
+					Name:      PvcConfigName,
+				},
+				Data: c.data,
+			})
+			if _, err := GetArtifactStorage(pipelinerun.Name, pipelinerun.Namespace, fakekubeclient, logger); err == nil {
+				t.Error("Expected error getting artifact storage from invalid PVC configuration")
+			}
+		})
+	}
+}
+
+func TestGetCoSchedulingAffinity(t *testing.T) {
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"tekton.dev/pipelineRun": "pipelineruntest"},
+		},
+		TopologyKey: "kubernetes.io/hostname",
+	}
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      "disktype",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"ssd"},
+				}},
+			}},
+		},
+	}
+	nodeRequirement := corev1.NodeSelectorRequirement{
+		Key:      "kubernetes.io/hostname",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"node-1"},
+	}
+	for _, c := range []struct {
+		desc     string
+		affinity *corev1.Affinity
+		node     string
+		want     *corev1.Affinity
+	}{{
+		desc: "no affinity",
+		want: &corev1.Affinity{
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+			},
+		},
+	}, {
+		desc: "scheduled pod",
+		node: "node-1",
+		want: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{nodeRequirement},
+					}},
+				},
+			},
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+			},
+		},
+	}, {
+		desc:     "node affinity and scheduled pod",
+		affinity: &corev1.Affinity{NodeAffinity: nodeAffinity},
+		node:     "node-1",
+		want: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0],
+							nodeRequirement,
+						},
+					}},
+				},
+			},
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+			},
+		},
+	}, {
+		desc:     "node affinity",
+		affinity: &corev1.Affinity{NodeAffinity: nodeAffinity},
+		want: &corev1.Affinity{
+			NodeAffinity: nodeAffinity,
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+			},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got := GetCoSchedulingAffinity(c.affinity, pipelinerun, c.node)
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("Unexpected affinity (-want +got): %s", d)
+			}
+			if c.affinity != nil && (c.affinity.PodAffinity != nil || len(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions) != 1) {
+				t.Error("Expected the affinity passed to be left unchanged")
+			}
+		})
+	}
+}
@@ -18,10 +18,13 @@ package artifacts
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
-	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/pipeline/pkg/s3"
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
 	corev1 "k8s.io/api/core/v1"
@@ -41,52 +44,67 @@ const (
 
 	// DefaultPvcSize is the default size of the PVC to create
 	DefaultPvcSize = "5Gi"
+
+	// PvcStorageClassNameKey is the name of the configmap entry that
+	// specifies the storage class of the PVC to create. The default storage
+	// class of the cluster is used if it is unset.
+	PvcStorageClassNameKey = "storage.class.name"
+
+	// PvcAccessModeKey is the name of the configmap entry that specifies the
+	// access mode of the PVC to create, ReadWriteOnce or ReadWriteMany.
+	PvcAccessModeKey = "access.mode"
+
+	// PvcMountDirectKey is the name of the configmap entry that specifies
+	// whether the artifacts are mounted from the PVC at the paths of the
+	// resources instead of being copied to and from it.
+	PvcMountDirectKey = "mount.direct"
+
+	// hostnameTopologyKey is the label of nodes holding their hostname.
+	hostnameTopologyKey = "kubernetes.io/hostname"
 )
 
 // ArtifactStorageInterface is an interface to define the steps to copy
 // an pipeline artifact to/from temporary storage
 type ArtifactStorageInterface interface {
 	GetCopyToStorageFromContainerSpec(name, sourcePath, destinationPath string) []corev1.Container
-	GetCopyFromStorageToContainerSpec(name, sourcePath, destinationPath string) []corev1.Container
+	GetCopyFromStorageToContainerSpec(name, sourcePath, destinationPath, checksum string) []corev1.Container
 	GetSecretsVolumes() []corev1.Volume
 	GetType() string
 	StorageBasePath(pr *v1alpha1.PipelineRun) string
 }
 
 // InitializeArtifactStorage will check if there is there is a
-// bucket configured or create a PVC
+// bucket configured for the namespace of pr or create a PVC
 func InitializeArtifactStorage(pr *v1alpha1.PipelineRun, c kubernetes.Interface, logger *zap.SugaredLogger) (ArtifactStorageInterface, error) {
-	configMap, err := c.CoreV1().ConfigMaps(system.GetNamespace()).Get(v1alpha1.BucketConfigName, metav1.GetOptions{})
+	configMap, err := getBucketConfigMap(c, pr.Namespace)
 	shouldCreatePVC, err := NeedsPVC(configMap, err, logger)
 	if err != nil {
 		return nil, err
 	}
 	if shouldCreatePVC {
-		pvc, err := createPVC(pr, c)
+		as, err := newArtifactPVC(pr.Name, pr.Namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		as.PersistentVolumeClaim, err = createPVC(pr, as.AccessMode, c)
 		if err != nil {
 			return nil, err
 		}
-		return &v1alpha1.ArtifactPVC{Name: pr.Name, PersistentVolumeClaim: pvc}, nil
+		return as, nil
 	}
 
-	return NewArtifactBucketConfigFromConfigMap(configMap)
+	return newArtifactStorageFromConfigMap(configMap)
 }
 
-// CleanupArtifactStorage will delete the PipelineRun's artifact storage PVC if it exists. The PVC is created for using
-// an output workspace or artifacts from one Task to another Task. No other PVCs will be impacted by this cleanup.
+// CleanupArtifactStorage will delete the PipelineRun's artifact storage, its PVC or its prefix of the bucket, once the
+// retention policy of the PipelineRun allows it. The PVC is created for using an output workspace or artifacts from one
+// Task to another Task. No other PVCs will be impacted by this cleanup.
 func CleanupArtifactStorage(pr *v1alpha1.PipelineRun, c kubernetes.Interface, logger *zap.SugaredLogger) error {
-	configMap, err := c.CoreV1().ConfigMaps(system.GetNamespace()).Get(v1alpha1.BucketConfigName, metav1.GetOptions{})
-	shouldCreatePVC, err := NeedsPVC(configMap, err, logger)
+	cfg, err := loadCleanupConfig(c, pr.Namespace, logger)
 	if err != nil {
 		return err
 	}
-	if shouldCreatePVC {
-		err = deletePVC(pr, c)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return cfg.cleanup(pr, c, time.Now())
 }
 
 // NeedsPVC checks if the possibly-nil config map passed to it is configured to use a bucket for artifact storage,
@@ -116,16 +134,118 @@ func NeedsPVC(configMap *corev1.ConfigMap, err error, logger *zap.SugaredLogger)
 	return false, nil
 }
 
-// GetArtifactStorage returns the storage interface to enable
-// consumer code to get a container step for copy to/from storage
-func GetArtifactStorage(prName string, c kubernetes.Interface, logger *zap.SugaredLogger) (ArtifactStorageInterface, error) {
-	configMap, err := c.CoreV1().ConfigMaps(system.GetNamespace()).Get(v1alpha1.BucketConfigName, metav1.GetOptions{})
+// GetArtifactStorage returns the storage interface of the runs of namespace
+// to enable consumer code to get a container step for copy to/from storage
+func GetArtifactStorage(prName, namespace string, c kubernetes.Interface, logger *zap.SugaredLogger) (ArtifactStorageInterface, error) {
+	configMap, err := getBucketConfigMap(c, namespace)
 	pvc, err := NeedsPVC(configMap, err, logger)
 	if err != nil {
 		return nil, xerrors.Errorf("couldn't determine if PVC was needed from config map: %w", err)
 	}
 	if pvc {
-		return &v1alpha1.ArtifactPVC{Name: prName}, nil
+		return newArtifactPVC(prName, namespace, c)
+	}
+	return newArtifactStorageFromConfigMap(configMap)
+}
+
+// newArtifactPVC returns the PVC storing the artifacts of the PipelineRun
+// prName, configured by the PVC ConfigMap applying to namespace.
+func newArtifactPVC(prName, namespace string, c kubernetes.Interface) (*v1alpha1.ArtifactPVC, error) {
+	as := &v1alpha1.ArtifactPVC{Name: prName}
+	configMap, err := getPVCConfigMap(c, namespace)
+	if err != nil || configMap == nil {
+		return as, err
+	}
+	switch mode := corev1.PersistentVolumeAccessMode(strings.TrimSpace(configMap.Data[PvcAccessModeKey])); mode {
+	case "":
+	case corev1.ReadWriteOnce, corev1.ReadWriteMany:
+		as.AccessMode = mode
+	default:
+		return nil, xerrors.Errorf("invalid %s in config map %s: %q is neither %s nor %s", PvcAccessModeKey, PvcConfigName, mode, corev1.ReadWriteOnce, corev1.ReadWriteMany)
+	}
+	if mountDirect := strings.TrimSpace(configMap.Data[PvcMountDirectKey]); mountDirect != "" {
+		as.MountDirect, err = strconv.ParseBool(mountDirect)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid %s in config map %s: %w", PvcMountDirectKey, PvcConfigName, err)
+		}
+	}
+	// Nothing reports the size of the artifacts mounted directly, so a
+	// quota would never be enforced.
+	if as.MountDirect && strings.TrimSpace(configMap.Data[PvcQuotaKey]) != "" {
+		return nil, xerrors.Errorf("invalid config map %s: %s can't be combined with %s", PvcConfigName, PvcQuotaKey, PvcMountDirectKey)
+	}
+	return as, nil
+}
+
+// GetCoSchedulingAffinity returns a copy of affinity that requires the pods
+// of the TaskRuns of pr to run on the same node as its other pods, so that
+// they can all mount its ReadWriteOnce PVC. The pods are also pinned to node,
+// if not empty, as the pod affinity can't keep the pods scheduled at the same
+// time together.
+func GetCoSchedulingAffinity(affinity *corev1.Affinity, pr *v1alpha1.PipelineRun, node string) *corev1.Affinity {
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	} else {
+		affinity = affinity.DeepCopy()
+	}
+	if affinity.PodAffinity == nil {
+		affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+	affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				pipeline.GroupName + pipeline.PipelineRunLabelKey: pr.Name,
+			},
+		},
+		TopologyKey: hostnameTopologyKey,
+	})
+	if node == "" {
+		return affinity
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	// The terms are ORed, so the node is required by each of them.
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].MatchExpressions = append(selector.NodeSelectorTerms[i].MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      hostnameTopologyKey,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{node},
+		})
+	}
+	return affinity
+}
+
+// GetCoSchedulingNode returns the node a pod of the TaskRuns of pr was
+// scheduled on, or an empty string if none was scheduled yet.
+func GetCoSchedulingNode(pr *v1alpha1.PipelineRun, c kubernetes.Interface) (string, error) {
+	pods, err := c.CoreV1().Pods(pr.Namespace).List(metav1.ListOptions{
+		LabelSelector: pipeline.GroupName + pipeline.PipelineRunLabelKey + "=" + pr.Name,
+	})
+	if err != nil {
+		return "", xerrors.Errorf("failed to list the pods of pipelinerun %s: %w", pr.Name, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" {
+			return pod.Spec.NodeName, nil
+		}
+	}
+	return "", nil
+}
+
+// newArtifactStorageFromConfigMap returns the bucket configured in the
+// supplied ConfigMap, picking the implementation from the scheme of its
+// location.
+func newArtifactStorageFromConfigMap(configMap *corev1.ConfigMap) (ArtifactStorageInterface, error) {
+	if strings.HasPrefix(strings.TrimSpace(configMap.Data[v1alpha1.BucketLocationKey]), "s3://") {
+		return NewArtifactS3BucketConfigFromConfigMap(configMap)
 	}
 	return NewArtifactBucketConfigFromConfigMap(configMap)
 }
@@ -154,17 +274,47 @@ func NewArtifactBucketConfigFromConfigMap(configMap *corev1.ConfigMap) (*v1alpha
 	return c, nil
 }
 
-func createPVC(pr *v1alpha1.PipelineRun, c kubernetes.Interface) (*corev1.PersistentVolumeClaim, error) {
+// NewArtifactS3BucketConfigFromConfigMap creates an S3-compatible Bucket from the supplied ConfigMap
+func NewArtifactS3BucketConfigFromConfigMap(configMap *corev1.ConfigMap) (*v1alpha1.ArtifactS3Bucket, error) {
+	c := &v1alpha1.ArtifactS3Bucket{
+		Location: strings.TrimSpace(configMap.Data[v1alpha1.BucketLocationKey]),
+		Endpoint: configMap.Data[v1alpha1.BucketEndpointKey],
+		Region:   configMap.Data[v1alpha1.BucketRegionKey],
+	}
+	if _, _, err := s3.ParseLocation(c.Location); err != nil {
+		return nil, xerrors.Errorf("invalid %s in config map %s: %w", v1alpha1.BucketLocationKey, v1alpha1.BucketConfigName, err)
+	}
+	if pathStyle, ok := configMap.Data[v1alpha1.BucketPathStyleKey]; ok {
+		b, err := strconv.ParseBool(pathStyle)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid %s in config map %s: %w", v1alpha1.BucketPathStyleKey, v1alpha1.BucketConfigName, err)
+		}
+		c.PathStyle = b
+	}
+	if secretName, ok := configMap.Data[v1alpha1.BucketCredentialsSecretName]; ok {
+		for _, key := range []string{s3.AccessKeyIDEnvVar, s3.SecretAccessKeyEnvVar} {
+			c.Secrets = append(c.Secrets, v1alpha1.SecretParam{
+				FieldName:  key,
+				SecretName: secretName,
+				SecretKey:  key,
+			})
+		}
+	}
+	return c, nil
+}
+
+func createPVC(pr *v1alpha1.PipelineRun, accessMode corev1.PersistentVolumeAccessMode, c kubernetes.Interface) (*corev1.PersistentVolumeClaim, error) {
 	if _, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(GetPVCName(pr), metav1.GetOptions{}); err != nil {
 		if errors.IsNotFound(err) {
 
-			configMap, err := c.CoreV1().ConfigMaps(system.GetNamespace()).Get(PvcConfigName, metav1.GetOptions{})
-			if err != nil && !errors.IsNotFound(err) {
+			configMap, err := getPVCConfigMap(c, pr.Namespace)
+			if err != nil {
 				return nil, xerrors.Errorf("failed to get PVC ConfigMap %s for %q due to error: %w", PvcConfigName, pr.Name, err)
 			}
-			var pvcSizeStr string
+			var pvcSizeStr, storageClassName string
 			if configMap != nil {
 				pvcSizeStr = configMap.Data[PvcSizeKey]
+				storageClassName = strings.TrimSpace(configMap.Data[PvcStorageClassNameKey])
 			}
 			if pvcSizeStr == "" {
 				pvcSizeStr = DefaultPvcSize
@@ -174,6 +324,12 @@ func createPVC(pr *v1alpha1.PipelineRun, c kubernetes.Interface) (*corev1.Persis
 				return nil, xerrors.Errorf("failed to create Persistent Volume spec for %q due to error: %w", pr.Name, err)
 			}
 			pvcSpec := GetPVCSpec(pr, pvcSize)
+			if accessMode != "" {
+				pvcSpec.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{accessMode}
+			}
+			if storageClassName != "" {
+				pvcSpec.Spec.StorageClassName = &storageClassName
+			}
 			pvc, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Create(pvcSpec)
 			if err != nil {
 				return nil, xerrors.Errorf("failed to claim Persistent Volume %q due to error: %w", pr.Name, err)
@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PvcCacheClaimNameKey is the name of the configmap entry that specifies the
+// PersistentVolumeClaim Task caches are stored in when no bucket is
+// configured. The claim must exist in the namespace of the TaskRuns.
+const PvcCacheClaimNameKey = "cache.claim.name"
+
+// GetCacheStorage returns where the caches of the TaskRuns of namespace are
+// stored: under caches/<namespace> of the configured bucket, or else in the
+// configured claim. It returns nil if neither is configured.
+func GetCacheStorage(namespace string, c kubernetes.Interface, logger *zap.SugaredLogger) (*v1alpha1.CacheStorage, error) {
+	configMap, err := getBucketConfigMap(c, namespace)
+	pvc, err := NeedsPVC(configMap, err, logger)
+	if err != nil {
+		return nil, err
+	}
+	if !pvc {
+		bucket, err := newArtifactStorageFromConfigMap(configMap)
+		if err != nil {
+			return nil, err
+		}
+		var s *v1alpha1.CacheStorage
+		switch b := bucket.(type) {
+		case *v1alpha1.ArtifactS3Bucket:
+			s = &v1alpha1.CacheStorage{Location: b.Location, Endpoint: b.Endpoint, Region: b.Region, PathStyle: b.PathStyle, Secrets: b.Secrets}
+		case *v1alpha1.ArtifactBucket:
+			s = &v1alpha1.CacheStorage{Location: b.Location, Secrets: b.Secrets}
+		default:
+			return nil, xerrors.Errorf("artifact storage of type %s can't store caches", bucket.GetType())
+		}
+		s.Location = strings.TrimSuffix(strings.TrimSpace(s.Location), "/") + "/caches/" + namespace
+		return s, nil
+	}
+
+	configMap, err = getPVCConfigMap(c, namespace)
+	if err != nil || configMap == nil {
+		return nil, err
+	}
+	if claimName := strings.TrimSpace(configMap.Data[PvcCacheClaimNameKey]); claimName != "" {
+		return &v1alpha1.CacheStorage{ClaimName: claimName}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	logtesting "github.com/knative/pkg/logging/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetCacheStorage(t *testing.T) {
+	for _, c := range []struct {
+		desc       string
+		configMaps []*corev1.ConfigMap
+		expected   *v1alpha1.CacheStorage
+	}{{
+		desc: "gcs bucket",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: v1alpha1.BucketConfigName},
+			Data: map[string]string{
+				v1alpha1.BucketLocationKey:              "gs://fake-bucket/",
+				v1alpha1.BucketServiceAccountSecretName: "secret1",
+				v1alpha1.BucketServiceAccountSecretKey:  "sakey",
+			},
+		}},
+		expected: &v1alpha1.CacheStorage{
+			Location: "gs://fake-bucket/caches/foo",
+			Secrets: []v1alpha1.SecretParam{{
+				FieldName:  "GOOGLE_APPLICATION_CREDENTIALS",
+				SecretName: "secret1",
+				SecretKey:  "sakey",
+			}},
+		},
+	}, {
+		desc: "s3 bucket",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: v1alpha1.BucketConfigName},
+			Data: map[string]string{
+				v1alpha1.BucketLocationKey:  "s3://fake-bucket/tekton",
+				v1alpha1.BucketEndpointKey:  "http://minio:9000",
+				v1alpha1.BucketPathStyleKey: "true",
+			},
+		}},
+		expected: &v1alpha1.CacheStorage{
+			Location:  "s3://fake-bucket/tekton/caches/foo",
+			Endpoint:  "http://minio:9000",
+			PathStyle: true,
+		},
+	}, {
+		desc: "cache claim",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: PvcConfigName},
+			Data: map[string]string{
+				PvcSizeKey:           "10Gi",
+				PvcCacheClaimNameKey: "build-cache",
+			},
+		}},
+		expected: &v1alpha1.CacheStorage{ClaimName: "build-cache"},
+	}, {
+		desc: "no cache claim",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: PvcConfigName},
+			Data:       map[string]string{PvcSizeKey: "10Gi"},
+		}},
+	}, {
+		desc: "no config maps",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			for _, cm := range c.configMaps {
+				if _, err := fakekubeclient.CoreV1().ConfigMaps(cm.Namespace).Create(cm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := GetCacheStorage("foo", fakekubeclient, logtesting.TestLogger(t))
+			if err != nil {
+				t.Fatalf("Unexpected error getting cache storage: %v", err)
+			}
+			if d := cmp.Diff(c.expected, got); d != "" {
+				t.Errorf("Unexpected cache storage (-want +got): %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// getBucketConfigMap returns the bucket ConfigMap applying to the runs of
+// namespace: the one in namespace if it configures a location, else the one
+// of the cluster. Its error is the one of getting the ConfigMap of the
+// cluster, as expected by NeedsPVC.
+func getBucketConfigMap(c kubernetes.Interface, namespace string) (*corev1.ConfigMap, error) {
+	if namespace != "" && namespace != system.GetNamespace() {
+		configMap, err := c.CoreV1().ConfigMaps(namespace).Get(v1alpha1.BucketConfigName, metav1.GetOptions{})
+		switch {
+		case err == nil && strings.TrimSpace(configMap.Data[v1alpha1.BucketLocationKey]) != "":
+			return configMap, nil
+		case err != nil && !errors.IsNotFound(err):
+			return nil, xerrors.Errorf("failed to get bucket ConfigMap %s of namespace %s: %w", v1alpha1.BucketConfigName, namespace, err)
+		}
+	}
+	return c.CoreV1().ConfigMaps(system.GetNamespace()).Get(v1alpha1.BucketConfigName, metav1.GetOptions{})
+}
+
+// getPVCConfigMap returns the PVC configuration applying to the runs of
+// namespace: the entries of the PVC ConfigMap in namespace, falling back to
+// the ones of the cluster. It returns nil if neither ConfigMap exists.
+func getPVCConfigMap(c kubernetes.Interface, namespace string) (*corev1.ConfigMap, error) {
+	configMap, err := c.CoreV1().ConfigMaps(system.GetNamespace()).Get(PvcConfigName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, xerrors.Errorf("failed to get PVC ConfigMap %s: %w", PvcConfigName, err)
+		}
+		configMap = nil
+	}
+	if namespace == "" || namespace == system.GetNamespace() {
+		return configMap, nil
+	}
+	override, err := c.CoreV1().ConfigMaps(namespace).Get(PvcConfigName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, xerrors.Errorf("failed to get PVC ConfigMap %s of namespace %s: %w", PvcConfigName, namespace, err)
+		}
+		return configMap, nil
+	}
+	if configMap == nil {
+		return override, nil
+	}
+	merged := configMap.DeepCopy()
+	if merged.Data == nil {
+		merged.Data = map[string]string{}
+	}
+	for k, v := range override.Data {
+		merged.Data[k] = v
+	}
+	return merged, nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func configMap(namespace, name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data:       data,
+	}
+}
+
+func TestGetBucketConfigMap(t *testing.T) {
+	clusterBucket := configMap(system.GetNamespace(), v1alpha1.BucketConfigName, map[string]string{
+		v1alpha1.BucketLocationKey: "gs://cluster-bucket",
+	})
+	for _, c := range []struct {
+		desc       string
+		configMaps []runtime.Object
+		namespace  string
+		want       *corev1.ConfigMap
+	}{{
+		desc:       "cluster bucket",
+		configMaps: []runtime.Object{clusterBucket},
+		namespace:  "foo",
+		want:       clusterBucket,
+	}, {
+		desc: "namespace bucket",
+		configMaps: []runtime.Object{clusterBucket, configMap("foo", v1alpha1.BucketConfigName, map[string]string{
+			v1alpha1.BucketLocationKey:           "s3://team-bucket",
+			v1alpha1.BucketCredentialsSecretName: "team-credentials",
+		})},
+		namespace: "foo",
+		want: configMap("foo", v1alpha1.BucketConfigName, map[string]string{
+			v1alpha1.BucketLocationKey:           "s3://team-bucket",
+			v1alpha1.BucketCredentialsSecretName: "team-credentials",
+		}),
+	}, {
+		desc: "namespace bucket without location",
+		configMaps: []runtime.Object{clusterBucket, configMap("foo", v1alpha1.BucketConfigName, map[string]string{
+			v1alpha1.BucketCredentialsSecretName: "team-credentials",
+		})},
+		namespace: "foo",
+		want:      clusterBucket,
+	}, {
+		desc: "bucket of another namespace",
+		configMaps: []runtime.Object{clusterBucket, configMap("bar", v1alpha1.BucketConfigName, map[string]string{
+			v1alpha1.BucketLocationKey: "gs://bar-bucket",
+		})},
+		namespace: "foo",
+		want:      clusterBucket,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := getBucketConfigMap(fakek8s.NewSimpleClientset(c.configMaps...), c.namespace)
+			if err != nil {
+				t.Fatalf("Unexpected error getting the bucket ConfigMap: %v", err)
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("Unexpected ConfigMap (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestGetPVCConfigMap(t *testing.T) {
+	clusterPVC := configMap(system.GetNamespace(), PvcConfigName, map[string]string{
+		PvcSizeKey:            "10Gi",
+		PvcRetentionPolicyKey: "Keep",
+	})
+	namespacePVC := configMap("foo", PvcConfigName, map[string]string{
+		PvcSizeKey:             "50Gi",
+		PvcStorageClassNameKey: "fast",
+	})
+	for _, c := range []struct {
+		desc       string
+		configMaps []runtime.Object
+		want       map[string]string
+	}{{
+		desc: "none",
+	}, {
+		desc:       "cluster",
+		configMaps: []runtime.Object{clusterPVC},
+		want:       clusterPVC.Data,
+	}, {
+		desc:       "namespace",
+		configMaps: []runtime.Object{namespacePVC},
+		want:       namespacePVC.Data,
+	}, {
+		desc:       "namespace overriding the cluster",
+		configMaps: []runtime.Object{clusterPVC, namespacePVC},
+		want: map[string]string{
+			PvcSizeKey:             "50Gi",
+			PvcStorageClassNameKey: "fast",
+			PvcRetentionPolicyKey:  "Keep",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			kubeclient := fakek8s.NewSimpleClientset(c.configMaps...)
+			got, err := getPVCConfigMap(kubeclient, "foo")
+			if err != nil {
+				t.Fatalf("Unexpected error getting the PVC ConfigMap: %v", err)
+			}
+			if c.want == nil {
+				if got != nil {
+					t.Errorf("Expected no ConfigMap, got %v", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("Expected a ConfigMap, got none")
+			}
+			if d := cmp.Diff(c.want, got.Data); d != "" {
+				t.Errorf("Unexpected data (-want +got): %s", d)
+			}
+			// The ConfigMap of the cluster must not be modified.
+			if cm, err := kubeclient.CoreV1().ConfigMaps(system.GetNamespace()).Get(PvcConfigName, metav1.GetOptions{}); err == nil {
+				if d := cmp.Diff(clusterPVC.Data, cm.Data); d != "" {
+					t.Errorf("The ConfigMap of the cluster was modified (-want +got): %s", d)
+				}
+			}
+		})
+	}
+}
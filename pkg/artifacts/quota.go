@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PvcQuotaKey is the name of the configmap entry that specifies the default
+// maximum size of the artifacts stored by the TaskRuns of a PipelineRun, as a
+// quantity such as 2Gi. The size is unlimited if unset.
+const PvcQuotaKey = "quota"
+
+// GetArtifactQuota returns the maximum size of the artifacts the TaskRuns of
+// pr may store: its own, capped at the one configured for its namespace, else
+// the configured one. It returns nil if the size is unlimited.
+func GetArtifactQuota(pr *v1alpha1.PipelineRun, c kubernetes.Interface) (*resource.Quantity, error) {
+	configured, err := getConfiguredArtifactQuota(c, pr.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	// A PipelineRun can lower the quota of its namespace but not raise it.
+	if q := pr.Spec.ArtifactQuota; q != nil && (configured == nil || q.Cmp(*configured) < 0) {
+		return q, nil
+	}
+	return configured, nil
+}
+
+// getConfiguredArtifactQuota returns the quota configured for namespace, nil
+// if it is unlimited.
+func getConfiguredArtifactQuota(c kubernetes.Interface, namespace string) (*resource.Quantity, error) {
+	configMap, err := getPVCConfigMap(c, namespace)
+	if err != nil || configMap == nil {
+		return nil, err
+	}
+	quota := strings.TrimSpace(configMap.Data[PvcQuotaKey])
+	if quota == "" {
+		return nil, nil
+	}
+	q, err := resource.ParseQuantity(quota)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid %s in config map %s: %w", PvcQuotaKey, PvcConfigName, err)
+	}
+	if q.Sign() <= 0 {
+		return nil, xerrors.Errorf("invalid %s in config map %s: %s should be > 0", PvcQuotaKey, PvcConfigName, quota)
+	}
+	return &q, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetArtifactQuota(t *testing.T) {
+	for _, c := range []struct {
+		desc       string
+		quota      *resource.Quantity
+		configMaps []*corev1.ConfigMap
+		want       *resource.Quantity
+	}{{
+		desc: "unlimited",
+	}, {
+		desc:       "cluster quota",
+		configMaps: []*corev1.ConfigMap{pvcConfigMap(map[string]string{PvcQuotaKey: "2Gi"})},
+		want:       resource.NewQuantity(2<<30, resource.BinarySI),
+	}, {
+		desc: "namespace quota",
+		configMaps: []*corev1.ConfigMap{pvcConfigMap(map[string]string{PvcQuotaKey: "2Gi"}), {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: PvcConfigName},
+			Data:       map[string]string{PvcQuotaKey: "500Mi"},
+		}},
+		want: resource.NewQuantity(500<<20, resource.BinarySI),
+	}, {
+		desc:       "pipelinerun quota",
+		quota:      resource.NewQuantity(1<<30, resource.BinarySI),
+		configMaps: []*corev1.ConfigMap{pvcConfigMap(map[string]string{PvcQuotaKey: "2Gi"})},
+		want:       resource.NewQuantity(1<<30, resource.BinarySI),
+	}, {
+		desc:       "pipelinerun quota above the configured quota",
+		quota:      resource.NewQuantity(4<<30, resource.BinarySI),
+		configMaps: []*corev1.ConfigMap{pvcConfigMap(map[string]string{PvcQuotaKey: "2Gi"})},
+		want:       resource.NewQuantity(2<<30, resource.BinarySI),
+	}, {
+		desc:  "pipelinerun quota without configured quota",
+		quota: resource.NewQuantity(4<<30, resource.BinarySI),
+		want:  resource.NewQuantity(4<<30, resource.BinarySI),
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			for _, cm := range c.configMaps {
+				if _, err := fakekubeclient.CoreV1().ConfigMaps(cm.Namespace).Create(cm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pr := &v1alpha1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "pipelineruntest"},
+				Spec:       v1alpha1.PipelineRunSpec{ArtifactQuota: c.quota},
+			}
+			got, err := GetArtifactQuota(pr, fakekubeclient)
+			if err != nil {
+				t.Fatalf("Unexpected error getting artifact quota: %v", err)
+			}
+			if d := cmp.Diff(c.want, got, quantityComparer); d != "" {
+				t.Errorf("Unexpected artifact quota (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestGetArtifactQuotaInvalid(t *testing.T) {
+	for _, quota := range []string{"lots", "0", "-1Gi"} {
+		t.Run(quota, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset(pvcConfigMap(map[string]string{PvcQuotaKey: quota}))
+			if _, err := GetArtifactQuota(pipelinerun, fakekubeclient); err == nil {
+				t.Errorf("Expected error getting artifact quota %q", quota)
+			}
+		})
+	}
+}
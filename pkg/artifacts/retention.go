@@ -0,0 +1,232 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// PvcRetentionPolicyKey is the name of the configmap entry that specifies
+	// the default ArtifactRetentionPolicy of PipelineRuns, Delete if unset.
+	PvcRetentionPolicyKey = "retention.policy"
+
+	// PvcRetentionTTLKey is the name of the configmap entry that specifies how
+	// long the artifact storage kept by the retention policy is kept, as a Go
+	// duration such as 24h.
+	PvcRetentionTTLKey = "retention.ttl"
+)
+
+// bucketCleaner is implemented by the buckets, whose artifacts are deleted by
+// the containers of a Pod as the controller has no access to them.
+type bucketCleaner interface {
+	GetDeleteContainerSpec(path string) []corev1.Container
+	GetSecretsVolumes() []corev1.Volume
+	StorageBasePath(pr *v1alpha1.PipelineRun) string
+}
+
+// cleanupConfig is the configuration of the deletion of artifact storage,
+// loaded once for all the PipelineRuns it is applied to.
+type cleanupConfig struct {
+	// bucket is the configured bucket, nil if PVCs are used.
+	bucket    bucketCleaner
+	retention v1alpha1.ArtifactRetention
+}
+
+// loadCleanupConfig loads the cleanup configuration of the PipelineRuns of
+// namespace.
+func loadCleanupConfig(c kubernetes.Interface, namespace string, logger *zap.SugaredLogger) (*cleanupConfig, error) {
+	cfg := &cleanupConfig{}
+	configMap, err := getBucketConfigMap(c, namespace)
+	shouldCreatePVC, err := NeedsPVC(configMap, err, logger)
+	if err != nil {
+		return nil, err
+	}
+	if !shouldCreatePVC {
+		as, err := newArtifactStorageFromConfigMap(configMap)
+		if err != nil {
+			return nil, err
+		}
+		bucket, ok := as.(bucketCleaner)
+		if !ok {
+			return nil, xerrors.Errorf("artifact storage of type %s can't be deleted", as.GetType())
+		}
+		cfg.bucket = bucket
+	}
+
+	configMap, err = getPVCConfigMap(c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if configMap != nil {
+		cfg.retention, err = newArtifactRetentionFromConfigMap(configMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// newArtifactRetentionFromConfigMap returns the default retention configured
+// in the supplied ConfigMap.
+func newArtifactRetentionFromConfigMap(configMap *corev1.ConfigMap) (v1alpha1.ArtifactRetention, error) {
+	r := v1alpha1.ArtifactRetention{
+		Policy: v1alpha1.ArtifactRetentionPolicy(strings.TrimSpace(configMap.Data[PvcRetentionPolicyKey])),
+	}
+	if ttl := strings.TrimSpace(configMap.Data[PvcRetentionTTLKey]); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return r, xerrors.Errorf("invalid %s in config map %s: %w", PvcRetentionTTLKey, PvcConfigName, err)
+		}
+		r.TTL = &metav1.Duration{Duration: d}
+	}
+	if err := r.Validate(context.Background(), "retention"); err != nil {
+		return r, xerrors.Errorf("invalid retention in config map %s: %w", PvcConfigName, err)
+	}
+	return r, nil
+}
+
+// artifactRetention returns the retention of the artifact storage of pr: its
+// own, completed by the default retention of its namespace.
+func (cfg *cleanupConfig) artifactRetention(pr *v1alpha1.PipelineRun) v1alpha1.ArtifactRetention {
+	r := cfg.retention
+	if pr.Spec.ArtifactRetention != nil {
+		if pr.Spec.ArtifactRetention.Policy != "" {
+			r.Policy = pr.Spec.ArtifactRetention.Policy
+		}
+		if pr.Spec.ArtifactRetention.TTL != nil {
+			r.TTL = pr.Spec.ArtifactRetention.TTL
+		}
+	}
+	if r.Policy == "" {
+		r.Policy = v1alpha1.ArtifactRetentionDelete
+	}
+	return r
+}
+
+// expired returns whether the artifact storage of pr, which is done, is to be
+// deleted at now.
+func (cfg *cleanupConfig) expired(pr *v1alpha1.PipelineRun, now time.Time) bool {
+	r := cfg.artifactRetention(pr)
+	switch {
+	case r.Policy == v1alpha1.ArtifactRetentionDelete:
+		return true
+	case r.Policy == v1alpha1.ArtifactRetentionKeepOnFailure && pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue():
+		return true
+	case r.TTL == nil:
+		return false
+	}
+	completionTime := pr.Status.CompletionTime
+	if completionTime == nil {
+		completionTime = &pr.Status.GetCondition(apis.ConditionSucceeded).LastTransitionTime.Inner
+	}
+	return !completionTime.Add(r.TTL.Duration).After(now)
+}
+
+// cleanup deletes the artifact storage of pr, which is done, if it expired
+// at now.
+func (cfg *cleanupConfig) cleanup(pr *v1alpha1.PipelineRun, c kubernetes.Interface, now time.Time) error {
+	if !cfg.expired(pr, now) {
+		return nil
+	}
+	if cfg.bucket == nil {
+		return deletePVC(pr, c)
+	}
+	return createBucketCleanupPod(pr, cfg.bucket, c)
+}
+
+// DeleteArtifactStorage deletes the artifact storage of pr, which is done,
+// regardless of its retention, before pr itself is deleted. It returns true
+// once pr can be deleted: its PVC is deleted with it by the garbage collector,
+// but its prefix of the bucket is deleted by a Pod owned by pr, which must
+// have succeeded first.
+func DeleteArtifactStorage(pr *v1alpha1.PipelineRun, c kubernetes.Interface, logger *zap.SugaredLogger) (bool, error) {
+	cfg, err := loadCleanupConfig(c, pr.Namespace, logger)
+	if err != nil {
+		return false, err
+	}
+	if cfg.bucket == nil {
+		return true, nil
+	}
+	pod, err := c.CoreV1().Pods(pr.Namespace).Get(GetBucketCleanupPodName(pr), metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		return false, createBucketCleanupPod(pr, cfg.bucket, c)
+	case err != nil:
+		return false, xerrors.Errorf("failed to get artifact cleanup Pod %q due to error: %w", GetBucketCleanupPodName(pr), err)
+	}
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, nil
+	case corev1.PodFailed:
+		// The Pod is created again by the next call.
+		if err := c.CoreV1().Pods(pr.Namespace).Delete(pod.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return false, xerrors.Errorf("failed to delete artifact cleanup Pod %q due to error: %w", pod.Name, err)
+		}
+		return false, xerrors.Errorf("artifact cleanup Pod %q failed", pod.Name)
+	}
+	return false, nil
+}
+
+// createBucketCleanupPod creates the Pod deleting the artifacts of pr from
+// bucket, unless it was already created.
+func createBucketCleanupPod(pr *v1alpha1.PipelineRun, bucket bucketCleaner, c kubernetes.Interface) error {
+	if _, err := c.CoreV1().Pods(pr.Namespace).Get(GetBucketCleanupPodName(pr), metav1.GetOptions{}); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return xerrors.Errorf("failed to get artifact cleanup Pod %q due to error: %w", GetBucketCleanupPodName(pr), err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       pr.Namespace,
+			Name:            GetBucketCleanupPodName(pr),
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels: map[string]string{
+				pipeline.GroupName + pipeline.PipelineRunLabelKey: pr.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:      corev1.RestartPolicyNever,
+			ServiceAccountName: pr.Spec.ServiceAccount,
+			Containers:         bucket.GetDeleteContainerSpec(bucket.StorageBasePath(pr)),
+			Volumes:            bucket.GetSecretsVolumes(),
+		},
+	}
+	if _, err := c.CoreV1().Pods(pr.Namespace).Create(pod); err != nil && !errors.IsAlreadyExists(err) {
+		return xerrors.Errorf("failed to create artifact cleanup Pod %q due to error: %w", pod.Name, err)
+	}
+	return nil
+}
+
+// GetBucketCleanupPodName returns the name of the Pod deleting the artifacts
+// of a PipelineRun from the bucket
+func GetBucketCleanupPodName(pr *v1alpha1.PipelineRun) string {
+	return fmt.Sprintf("%s-artifacts-cleanup", pr.Name)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	logtesting "github.com/knative/pkg/logging/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+var completionTime = time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func donePipelineRun(name string, status corev1.ConditionStatus, retention *v1alpha1.ArtifactRetention) *v1alpha1.PipelineRun {
+	pr := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: name, UID: types.UID("uid-" + name)},
+		Spec:       v1alpha1.PipelineRunSpec{ServiceAccount: "builder", ArtifactRetention: retention},
+	}
+	pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status})
+	pr.Status.CompletionTime = &metav1.Time{Time: completionTime}
+	return pr
+}
+
+func pvcConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: PvcConfigName},
+		Data:       data,
+	}
+}
+
+func TestNewArtifactRetentionFromConfigMap(t *testing.T) {
+	got, err := newArtifactRetentionFromConfigMap(pvcConfigMap(map[string]string{
+		PvcRetentionPolicyKey: "KeepOnFailure",
+		PvcRetentionTTLKey:    "24h",
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error reading retention: %v", err)
+	}
+	want := v1alpha1.ArtifactRetention{
+		Policy: v1alpha1.ArtifactRetentionKeepOnFailure,
+		TTL:    &metav1.Duration{Duration: 24 * time.Hour},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected retention (-want +got): %s", d)
+	}
+
+	for _, data := range []map[string]string{
+		{PvcRetentionPolicyKey: "KeepForever"},
+		{PvcRetentionTTLKey: "a day"},
+		{PvcRetentionTTLKey: "-1h"},
+	} {
+		if _, err := newArtifactRetentionFromConfigMap(pvcConfigMap(data)); err == nil {
+			t.Errorf("Expected an error for the config %v", data)
+		}
+	}
+}
+
+func TestArtifactStorageExpired(t *testing.T) {
+	day := &metav1.Duration{Duration: 24 * time.Hour}
+	for _, c := range []struct {
+		desc      string
+		retention v1alpha1.ArtifactRetention
+		pr        *v1alpha1.PipelineRun
+		now       time.Time
+		expired   bool
+	}{{
+		desc:    "delete by default",
+		pr:      donePipelineRun("pr", corev1.ConditionFalse, nil),
+		now:     completionTime,
+		expired: true,
+	}, {
+		desc:      "keep on failure of a successful run",
+		retention: v1alpha1.ArtifactRetention{Policy: v1alpha1.ArtifactRetentionKeepOnFailure},
+		pr:        donePipelineRun("pr", corev1.ConditionTrue, nil),
+		now:       completionTime,
+		expired:   true,
+	}, {
+		desc:      "keep on failure of a failed run without ttl",
+		retention: v1alpha1.ArtifactRetention{Policy: v1alpha1.ArtifactRetentionKeepOnFailure},
+		pr:        donePipelineRun("pr", corev1.ConditionFalse, nil),
+		now:       completionTime.Add(24 * time.Hour),
+		expired:   false,
+	}, {
+		desc:      "keep on failure of a failed run before ttl",
+		retention: v1alpha1.ArtifactRetention{Policy: v1alpha1.ArtifactRetentionKeepOnFailure, TTL: day},
+		pr:        donePipelineRun("pr", corev1.ConditionFalse, nil),
+		now:       completionTime.Add(23 * time.Hour),
+		expired:   false,
+	}, {
+		desc:      "keep on failure of a failed run after ttl",
+		retention: v1alpha1.ArtifactRetention{Policy: v1alpha1.ArtifactRetentionKeepOnFailure, TTL: day},
+		pr:        donePipelineRun("pr", corev1.ConditionFalse, nil),
+		now:       completionTime.Add(24 * time.Hour),
+		expired:   true,
+	}, {
+		desc:    "keep set by the pipelinerun",
+		pr:      donePipelineRun("pr", corev1.ConditionTrue, &v1alpha1.ArtifactRetention{Policy: v1alpha1.ArtifactRetentionKeep, TTL: day}),
+		now:     completionTime.Add(time.Hour),
+		expired: false,
+	}, {
+		desc:      "ttl overridden by the pipelinerun",
+		retention: v1alpha1.ArtifactRetention{Policy: v1alpha1.ArtifactRetentionKeep, TTL: day},
+		pr:        donePipelineRun("pr", corev1.ConditionTrue, &v1alpha1.ArtifactRetention{TTL: &metav1.Duration{Duration: time.Hour}}),
+		now:       completionTime.Add(time.Hour),
+		expired:   true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			cfg := &cleanupConfig{retention: c.retention}
+			if got := cfg.expired(c.pr, c.now); got != c.expired {
+				t.Errorf("Expected expired to be %t but got %t", c.expired, got)
+			}
+		})
+	}
+}
+
+func TestCleanupArtifactStorageKeep(t *testing.T) {
+	pr := donePipelineRun("pipelineruntest", corev1.ConditionFalse, nil)
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		pvcConfigMap(map[string]string{PvcRetentionPolicyKey: "KeepOnFailure"}),
+		GetPVCSpec(pr, persistentVolumeClaim.Spec.Resources.Requests["storage"]),
+	)
+	if err := CleanupArtifactStorage(pr, fakekubeclient, logtesting.TestLogger(t)); err != nil {
+		t.Fatalf("Error cleaning up artifact storage: %s", err)
+	}
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(GetPVCName(pr), metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the PVC of the failed PipelineRun to be kept but got %v", err)
+	}
+}
+
+func TestCleanupArtifactStorageNamespaceRetention(t *testing.T) {
+	pr := donePipelineRun("pipelineruntest", corev1.ConditionTrue, nil)
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		pvcConfigMap(map[string]string{PvcRetentionPolicyKey: "Delete"}),
+		configMap(pr.Namespace, PvcConfigName, map[string]string{PvcRetentionPolicyKey: "Keep"}),
+		GetPVCSpec(pr, persistentVolumeClaim.Spec.Resources.Requests["storage"]),
+	)
+	if err := CleanupArtifactStorage(pr, fakekubeclient, logtesting.TestLogger(t)); err != nil {
+		t.Fatalf("Error cleaning up artifact storage: %s", err)
+	}
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(GetPVCName(pr), metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the PVC to be kept by the retention of the namespace but got %v", err)
+	}
+}
+
+func TestCleanupArtifactStorageBucket(t *testing.T) {
+	names.TestingSeed()
+	pr := donePipelineRun("pipelineruntest", corev1.ConditionTrue, nil)
+	fakekubeclient := fakek8s.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: v1alpha1.BucketConfigName},
+		Data: map[string]string{
+			v1alpha1.BucketLocationKey:              "gs://fake-bucket",
+			v1alpha1.BucketServiceAccountSecretName: "secret1",
+			v1alpha1.BucketServiceAccountSecretKey:  "sakey",
+		},
+	})
+	logger := logtesting.TestLogger(t)
+	if err := CleanupArtifactStorage(pr, fakekubeclient, logger); err != nil {
+		t.Fatalf("Error cleaning up artifact storage: %s", err)
+	}
+	pod, err := fakekubeclient.CoreV1().Pods(pr.Namespace).Get(GetBucketCleanupPodName(pr), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected a Pod deleting the artifacts but got %v", err)
+	}
+	want := corev1.PodSpec{
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: "builder",
+		Containers: []corev1.Container{{
+			Name:         "artifact-delete-9l9zj",
+			Image:        "override-with-gsutil-image:latest",
+			Command:      []string{"/ko-app/gsutil"},
+			Args:         []string{"-args", "-m rm -r -f gs://fake-bucket/pipelineruntest-foo-bucket"},
+			Env:          []corev1.EnvVar{{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/var/bucketsecret/secret1/sakey"}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "volume-bucket-secret1", MountPath: "/var/bucketsecret/secret1"}},
+		}},
+		Volumes: []corev1.Volume{{
+			Name:         "volume-bucket-secret1",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "secret1"}},
+		}},
+	}
+	if d := cmp.Diff(want, pod.Spec); d != "" {
+		t.Errorf("Unexpected cleanup Pod (-want +got): %s", d)
+	}
+	if d := cmp.Diff(pr.GetOwnerReference(), pod.OwnerReferences); d != "" {
+		t.Errorf("Expected the cleanup Pod to be owned by the PipelineRun (-want +got): %s", d)
+	}
+
+	// The Pod is only created once.
+	if err := CleanupArtifactStorage(pr, fakekubeclient, logger); err != nil {
+		t.Fatalf("Error cleaning up artifact storage again: %s", err)
+	}
+	pods, err := fakekubeclient.CoreV1().Pods(pr.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods.Items) != 1 {
+		t.Errorf("Expected a single cleanup Pod but got %d", len(pods.Items))
+	}
+}
+
+func TestDeleteArtifactStorageRetriesFailedPod(t *testing.T) {
+	pr := donePipelineRun("pipelineruntest", corev1.ConditionTrue, nil)
+	fakekubeclient := fakek8s.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: v1alpha1.BucketConfigName},
+		Data: map[string]string{
+			v1alpha1.BucketLocationKey:              "gs://fake-bucket",
+			v1alpha1.BucketServiceAccountSecretName: "secret1",
+			v1alpha1.BucketServiceAccountSecretKey:  "sakey",
+		},
+	}, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pr.Namespace, Name: GetBucketCleanupPodName(pr)},
+		Status:     corev1.PodStatus{Phase: corev1.PodFailed},
+	})
+	if deleted, err := DeleteArtifactStorage(pr, fakekubeclient, logtesting.TestLogger(t)); err == nil || deleted {
+		t.Errorf("Expected an error deleting the artifact storage with a failed Pod, got %t, %v", deleted, err)
+	}
+	if _, err := fakekubeclient.CoreV1().Pods(pr.Namespace).Get(GetBucketCleanupPodName(pr), metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the failed cleanup Pod to be deleted to be created again, got %v", err)
+	}
+}
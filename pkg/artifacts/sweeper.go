@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"time"
+
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Sweeper deletes the artifact storage of the PipelineRuns that was kept by
+// their retention policy once their TTL expired.
+type Sweeper struct {
+	kubeclient        kubernetes.Interface
+	pipelineRunLister listers.PipelineRunLister
+	logger            *zap.SugaredLogger
+	now               func() time.Time
+
+	// swept holds the PipelineRuns whose artifact storage was deleted, so
+	// that it isn't looked up again.
+	swept map[types.UID]struct{}
+}
+
+// NewSweeper returns a Sweeper of the PipelineRuns listed by
+// pipelineRunLister.
+func NewSweeper(kubeclient kubernetes.Interface, pipelineRunLister listers.PipelineRunLister, logger *zap.SugaredLogger) *Sweeper {
+	return &Sweeper{
+		kubeclient:        kubeclient,
+		pipelineRunLister: pipelineRunLister,
+		logger:            logger,
+		now:               time.Now,
+		swept:             map[types.UID]struct{}{},
+	}
+}
+
+// Run sweeps every period until stopCh is closed.
+func (s *Sweeper) Run(period time.Duration, stopCh <-chan struct{}) {
+	wait.Until(s.Sweep, period, stopCh)
+}
+
+// Sweep deletes the artifact storage of the done PipelineRuns whose retention
+// expired.
+func (s *Sweeper) Sweep() {
+	prs, err := s.pipelineRunLister.List(labels.Everything())
+	if err != nil {
+		s.logger.Errorf("Failed to list PipelineRuns: %v", err)
+		return
+	}
+	now := s.now()
+	listed := map[types.UID]struct{}{}
+	// The configuration is loaded once per namespace for the sweep.
+	configs := map[string]*cleanupConfig{}
+	for _, pr := range prs {
+		listed[pr.UID] = struct{}{}
+		if _, ok := s.swept[pr.UID]; ok || !pr.IsDone() {
+			continue
+		}
+		cfg, ok := configs[pr.Namespace]
+		if !ok {
+			var err error
+			if cfg, err = loadCleanupConfig(s.kubeclient, pr.Namespace, s.logger); err != nil {
+				s.logger.Errorf("Failed to load the artifact storage configuration of namespace %s: %v", pr.Namespace, err)
+			}
+			configs[pr.Namespace] = cfg
+		}
+		if cfg == nil || !cfg.expired(pr, now) {
+			continue
+		}
+		if err := cfg.cleanup(pr, s.kubeclient, now); err != nil {
+			s.logger.Errorf("Failed to delete the artifact storage of PipelineRun %s/%s: %v", pr.Namespace, pr.Name, err)
+			continue
+		}
+		s.swept[pr.UID] = struct{}{}
+	}
+	// Forget the deleted PipelineRuns.
+	for uid := range s.swept {
+		if _, ok := listed[uid]; !ok {
+			delete(s.swept, uid)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"testing"
+	"time"
+
+	logtesting "github.com/knative/pkg/logging/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestSweeper(t *testing.T) {
+	day := &metav1.Duration{Duration: 24 * time.Hour}
+	expired := donePipelineRun("expired", corev1.ConditionFalse, &v1alpha1.ArtifactRetention{Policy: v1alpha1.ArtifactRetentionKeep, TTL: day})
+	kept := donePipelineRun("kept", corev1.ConditionFalse, &v1alpha1.ArtifactRetention{Policy: v1alpha1.ArtifactRetentionKeep, TTL: &metav1.Duration{Duration: 48 * time.Hour}})
+	running := donePipelineRun("running", corev1.ConditionUnknown, nil)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	for _, pr := range []*v1alpha1.PipelineRun{expired, kept, running} {
+		if err := indexer.Add(pr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims(pr.Namespace).Create(GetPVCSpec(pr, persistentVolumeClaim.Spec.Resources.Requests["storage"])); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewSweeper(fakekubeclient, listers.NewPipelineRunLister(indexer), logtesting.TestLogger(t))
+	s.now = func() time.Time { return completionTime.Add(36 * time.Hour) }
+	s.Sweep()
+
+	for pr, deleted := range map[*v1alpha1.PipelineRun]bool{expired: true, kept: false, running: false} {
+		_, err := fakekubeclient.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(GetPVCName(pr), metav1.GetOptions{})
+		if deleted && !errors.IsNotFound(err) {
+			t.Errorf("Expected the PVC of PipelineRun %s to be deleted but got %v", pr.Name, err)
+		} else if !deleted && err != nil {
+			t.Errorf("Expected the PVC of PipelineRun %s to be kept but got %v", pr.Name, err)
+		}
+	}
+	if _, ok := s.swept[expired.UID]; !ok {
+		t.Errorf("Expected PipelineRun %s to be swept", expired.Name)
+	}
+
+	// Deleted PipelineRuns are forgotten.
+	if err := indexer.Delete(expired); err != nil {
+		t.Fatal(err)
+	}
+	s.Sweep()
+	if len(s.swept) != 0 {
+		t.Errorf("Expected the deleted PipelineRun to be forgotten but got %v", s.swept)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cache saves and restores the directories Tasks declare as caches,
+// as archives keyed by the content of files such as go.sum, so that their
+// content is reused across runs.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/archive"
+	"golang.org/x/xerrors"
+)
+
+// Key returns the key of the cache called name: the name followed by the
+// digest of key and of the content of the files matching the patterns of
+// files, so that the key changes whenever any of them does.
+func Key(name, key string, files []string) (string, error) {
+	h := sha256.New()
+	io.WriteString(h, key)
+	for _, pattern := range files {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", xerrors.Errorf("invalid key file pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return "", xerrors.Errorf("no key file matches %q", pattern)
+		}
+		sort.Strings(matches)
+		for _, m := range matches {
+			if err := hashFile(h, m); err != nil {
+				return "", err
+			}
+		}
+	}
+	return name + "-" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return xerrors.Errorf("reading key file: %w", err)
+	}
+	defer f.Close()
+	// The path is hashed too so that renaming a file changes the key.
+	io.WriteString(w, "\x00"+p+"\x00")
+	if _, err := io.Copy(w, f); err != nil {
+		return xerrors.Errorf("reading key file %s: %w", p, err)
+	}
+	return nil
+}
+
+// Restore extracts the archive stored at key in store into paths. It returns
+// false, leaving paths as they are, if no archive is stored at key.
+func Restore(store archive.Store, key string, paths []string) (bool, error) {
+	f, err := ioutil.TempFile("", "cache-")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	found, err := store.Get(key, f)
+	if err != nil || !found {
+		return false, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	if err := archive.Unpack(f, paths); err != nil {
+		return false, xerrors.Errorf("extracting cache %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// Save archives paths and stores the archive at key in store. As the key
+// identifies the content the archive was made for, nothing is stored and
+// false is returned if an archive is already stored at key.
+func Save(store archive.Store, key string, paths []string) (bool, error) {
+	exists, err := store.Exists(key)
+	if err != nil || exists {
+		return false, err
+	}
+	f, err := ioutil.TempFile("", "cache-")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := archive.Pack(f, paths); err != nil {
+		return false, xerrors.Errorf("archiving cache %s: %w", key, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	if err := store.Put(key, f); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/archive"
+	"github.com/tektoncd/pipeline/pkg/s3"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{
+		"a/go.sum": "golang.org/x/xerrors v0.0.0",
+		"b/go.sum": "github.com/google/go-cmp v0.3.0",
+	})
+	files := []string{filepath.Join(dir, "*", "go.sum")}
+
+	key, err := Key("go-mod", "go1.12", files)
+	if err != nil {
+		t.Fatalf("Unexpected error computing key: %v", err)
+	}
+	if !strings.HasPrefix(key, "go-mod-") || len(key) != len("go-mod-")+64 {
+		t.Errorf("Expected key named after the cache with a sha256 digest but got %s", key)
+	}
+	if again, _ := Key("go-mod", "go1.12", files); again != key {
+		t.Errorf("Expected the same key for the same content but got %s and %s", key, again)
+	}
+	if other, _ := Key("go-mod", "go1.13", files); other == key {
+		t.Errorf("Expected the key to change with the key string")
+	}
+	writeFiles(t, dir, map[string]string{"b/go.sum": "github.com/google/go-cmp v0.3.1"})
+	if other, _ := Key("go-mod", "go1.12", files); other == key {
+		t.Errorf("Expected the key to change with the content of the key files")
+	}
+
+	if _, err := Key("go-mod", "", []string{filepath.Join(dir, "missing.sum")}); err == nil {
+		t.Errorf("Expected an error for key files matching nothing")
+	}
+}
+
+func TestSaveRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := archive.NewStore(filepath.Join(dir, "store"), s3.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating store: %v", err)
+	}
+	src := []string{filepath.Join(dir, "src", "mod"), filepath.Join(dir, "src", "build")}
+	writeFiles(t, src[0], map[string]string{"cache/download/list": "v0.3.0"})
+	writeFiles(t, src[1], map[string]string{"00/abc-d": "object"})
+
+	if found, err := Restore(store, "deps-1", src); err != nil || found {
+		t.Fatalf("Expected a cache miss but got found %t, err %v", found, err)
+	}
+	if saved, err := Save(store, "deps-1", src); err != nil || !saved {
+		t.Fatalf("Expected the cache to be saved but got saved %t, err %v", saved, err)
+	}
+	if saved, err := Save(store, "deps-1", src); err != nil || saved {
+		t.Errorf("Expected an existing cache not to be saved again but got saved %t, err %v", saved, err)
+	}
+
+	dest := []string{filepath.Join(dir, "dest", "mod"), filepath.Join(dir, "dest", "build")}
+	if found, err := Restore(store, "deps-1", dest); err != nil || !found {
+		t.Fatalf("Expected a cache hit but got found %t, err %v", found, err)
+	}
+	for p, want := range map[string]string{
+		filepath.Join(dest[0], "cache/download/list"): "v0.3.0",
+		filepath.Join(dest[1], "00/abc-d"):            "object",
+	} {
+		got, err := ioutil.ReadFile(p)
+		if err != nil {
+			t.Fatalf("Expected %s to be restored: %v", p, err)
+		}
+		if string(got) != want {
+			t.Errorf("Expected %s to contain %q but got %q", p, want, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	scheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterStepTemplatesGetter has a method to return a ClusterStepTemplateInterface.
+// A group's client should implement this interface.
+type ClusterStepTemplatesGetter interface {
+	ClusterStepTemplates() ClusterStepTemplateInterface
+}
+
+// ClusterStepTemplateInterface has methods to work with ClusterStepTemplate resources.
+type ClusterStepTemplateInterface interface {
+	Create(*v1alpha1.ClusterStepTemplate) (*v1alpha1.ClusterStepTemplate, error)
+	Update(*v1alpha1.ClusterStepTemplate) (*v1alpha1.ClusterStepTemplate, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.ClusterStepTemplate, error)
+	List(opts v1.ListOptions) (*v1alpha1.ClusterStepTemplateList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ClusterStepTemplate, err error)
+	ClusterStepTemplateExpansion
+}
+
+// clusterStepTemplates implements ClusterStepTemplateInterface
+type clusterStepTemplates struct {
+	client rest.Interface
+}
+
+// newClusterStepTemplates returns a ClusterStepTemplates
+func newClusterStepTemplates(c *TektonV1alpha1Client) *clusterStepTemplates {
+	return &clusterStepTemplates{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterStepTemplate, and returns the corresponding clusterStepTemplate object, and an error if there is any.
+func (c *clusterStepTemplates) Get(name string, options v1.GetOptions) (result *v1alpha1.ClusterStepTemplate, err error) {
+	result = &v1alpha1.ClusterStepTemplate{}
+	err = c.client.Get().
+		Resource("clustersteptemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterStepTemplates that match those selectors.
+func (c *clusterStepTemplates) List(opts v1.ListOptions) (result *v1alpha1.ClusterStepTemplateList, err error) {
+	result = &v1alpha1.ClusterStepTemplateList{}
+	err = c.client.Get().
+		Resource("clustersteptemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterStepTemplates.
+func (c *clusterStepTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clustersteptemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a clusterStepTemplate and creates it.  Returns the server's representation of the clusterStepTemplate, and an error, if there is any.
+func (c *clusterStepTemplates) Create(clusterStepTemplate *v1alpha1.ClusterStepTemplate) (result *v1alpha1.ClusterStepTemplate, err error) {
+	result = &v1alpha1.ClusterStepTemplate{}
+	err = c.client.Post().
+		Resource("clustersteptemplates").
+		Body(clusterStepTemplate).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterStepTemplate and updates it. Returns the server's representation of the clusterStepTemplate, and an error, if there is any.
+func (c *clusterStepTemplates) Update(clusterStepTemplate *v1alpha1.ClusterStepTemplate) (result *v1alpha1.ClusterStepTemplate, err error) {
+	result = &v1alpha1.ClusterStepTemplate{}
+	err = c.client.Put().
+		Resource("clustersteptemplates").
+		Name(clusterStepTemplate.Name).
+		Body(clusterStepTemplate).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterStepTemplate and deletes it. Returns an error if one occurs.
+func (c *clusterStepTemplates) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clustersteptemplates").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterStepTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Resource("clustersteptemplates").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterStepTemplate.
+func (c *clusterStepTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ClusterStepTemplate, err error) {
+	result = &v1alpha1.ClusterStepTemplate{}
+	err = c.client.Patch(pt).
+		Resource("clustersteptemplates").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
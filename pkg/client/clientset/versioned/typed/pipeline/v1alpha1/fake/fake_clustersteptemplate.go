@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterStepTemplates implements ClusterStepTemplateInterface
+type FakeClusterStepTemplates struct {
+	Fake *FakeTektonV1alpha1
+}
+
+var clustersteptemplatesResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "clustersteptemplates"}
+
+var clustersteptemplatesKind = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1alpha1", Kind: "ClusterStepTemplate"}
+
+// Get takes name of the clusterStepTemplate, and returns the corresponding clusterStepTemplate object, and an error if there is any.
+func (c *FakeClusterStepTemplates) Get(name string, options v1.GetOptions) (result *v1alpha1.ClusterStepTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clustersteptemplatesResource, name), &v1alpha1.ClusterStepTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterStepTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterStepTemplates that match those selectors.
+func (c *FakeClusterStepTemplates) List(opts v1.ListOptions) (result *v1alpha1.ClusterStepTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clustersteptemplatesResource, clustersteptemplatesKind, opts), &v1alpha1.ClusterStepTemplateList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterStepTemplateList{ListMeta: obj.(*v1alpha1.ClusterStepTemplateList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterStepTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterStepTemplates.
+func (c *FakeClusterStepTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clustersteptemplatesResource, opts))
+}
+
+// Create takes the representation of a clusterStepTemplate and creates it.  Returns the server's representation of the clusterStepTemplate, and an error, if there is any.
+func (c *FakeClusterStepTemplates) Create(clusterStepTemplate *v1alpha1.ClusterStepTemplate) (result *v1alpha1.ClusterStepTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clustersteptemplatesResource, clusterStepTemplate), &v1alpha1.ClusterStepTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterStepTemplate), err
+}
+
+// Update takes the representation of a clusterStepTemplate and updates it. Returns the server's representation of the clusterStepTemplate, and an error, if there is any.
+func (c *FakeClusterStepTemplates) Update(clusterStepTemplate *v1alpha1.ClusterStepTemplate) (result *v1alpha1.ClusterStepTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clustersteptemplatesResource, clusterStepTemplate), &v1alpha1.ClusterStepTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterStepTemplate), err
+}
+
+// Delete takes name of the clusterStepTemplate and deletes it. Returns an error if one occurs.
+func (c *FakeClusterStepTemplates) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clustersteptemplatesResource, name), &v1alpha1.ClusterStepTemplate{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterStepTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clustersteptemplatesResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterStepTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterStepTemplate.
+func (c *FakeClusterStepTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ClusterStepTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clustersteptemplatesResource, name, data, subresources...), &v1alpha1.ClusterStepTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterStepTemplate), err
+}
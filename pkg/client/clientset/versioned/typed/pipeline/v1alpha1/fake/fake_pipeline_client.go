@@ -25,6 +25,10 @@ type FakeTektonV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTektonV1alpha1) ClusterStepTemplates() v1alpha1.ClusterStepTemplateInterface {
+	return &FakeClusterStepTemplates{c}
+}
+
 func (c *FakeTektonV1alpha1) ClusterTasks() v1alpha1.ClusterTaskInterface {
 	return &FakeClusterTasks{c}
 }
@@ -41,6 +45,14 @@ func (c *FakeTektonV1alpha1) PipelineRuns(namespace string) v1alpha1.PipelineRun
 	return &FakePipelineRuns{c, namespace}
 }
 
+func (c *FakeTektonV1alpha1) ResourceTypes() v1alpha1.ResourceTypeInterface {
+	return &FakeResourceTypes{c}
+}
+
+func (c *FakeTektonV1alpha1) StepTemplates(namespace string) v1alpha1.StepTemplateInterface {
+	return &FakeStepTemplates{c, namespace}
+}
+
 func (c *FakeTektonV1alpha1) Tasks(namespace string) v1alpha1.TaskInterface {
 	return &FakeTasks{c, namespace}
 }
@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeResourceTypes implements ResourceTypeInterface
+type FakeResourceTypes struct {
+	Fake *FakeTektonV1alpha1
+}
+
+var resourcetypesResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "resourcetypes"}
+
+var resourcetypesKind = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1alpha1", Kind: "ResourceType"}
+
+// Get takes name of the resourceType, and returns the corresponding resourceType object, and an error if there is any.
+func (c *FakeResourceTypes) Get(name string, options v1.GetOptions) (result *v1alpha1.ResourceType, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(resourcetypesResource, name), &v1alpha1.ResourceType{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceType), err
+}
+
+// List takes label and field selectors, and returns the list of ResourceTypes that match those selectors.
+func (c *FakeResourceTypes) List(opts v1.ListOptions) (result *v1alpha1.ResourceTypeList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(resourcetypesResource, resourcetypesKind, opts), &v1alpha1.ResourceTypeList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ResourceTypeList{ListMeta: obj.(*v1alpha1.ResourceTypeList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ResourceTypeList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested resourceTypes.
+func (c *FakeResourceTypes) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(resourcetypesResource, opts))
+}
+
+// Create takes the representation of a resourceType and creates it.  Returns the server's representation of the resourceType, and an error, if there is any.
+func (c *FakeResourceTypes) Create(resourceType *v1alpha1.ResourceType) (result *v1alpha1.ResourceType, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(resourcetypesResource, resourceType), &v1alpha1.ResourceType{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceType), err
+}
+
+// Update takes the representation of a resourceType and updates it. Returns the server's representation of the resourceType, and an error, if there is any.
+func (c *FakeResourceTypes) Update(resourceType *v1alpha1.ResourceType) (result *v1alpha1.ResourceType, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(resourcetypesResource, resourceType), &v1alpha1.ResourceType{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceType), err
+}
+
+// Delete takes name of the resourceType and deletes it. Returns an error if one occurs.
+func (c *FakeResourceTypes) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(resourcetypesResource, name), &v1alpha1.ResourceType{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeResourceTypes) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(resourcetypesResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ResourceTypeList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched resourceType.
+func (c *FakeResourceTypes) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ResourceType, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(resourcetypesResource, name, data, subresources...), &v1alpha1.ResourceType{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceType), err
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeStepTemplates implements StepTemplateInterface
+type FakeStepTemplates struct {
+	Fake *FakeTektonV1alpha1
+	ns   string
+}
+
+var steptemplatesResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "steptemplates"}
+
+var steptemplatesKind = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1alpha1", Kind: "StepTemplate"}
+
+// Get takes name of the stepTemplate, and returns the corresponding stepTemplate object, and an error if there is any.
+func (c *FakeStepTemplates) Get(name string, options v1.GetOptions) (result *v1alpha1.StepTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(steptemplatesResource, c.ns, name), &v1alpha1.StepTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.StepTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of StepTemplates that match those selectors.
+func (c *FakeStepTemplates) List(opts v1.ListOptions) (result *v1alpha1.StepTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(steptemplatesResource, steptemplatesKind, c.ns, opts), &v1alpha1.StepTemplateList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.StepTemplateList{ListMeta: obj.(*v1alpha1.StepTemplateList).ListMeta}
+	for _, item := range obj.(*v1alpha1.StepTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested stepTemplates.
+func (c *FakeStepTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(steptemplatesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a stepTemplate and creates it.  Returns the server's representation of the stepTemplate, and an error, if there is any.
+func (c *FakeStepTemplates) Create(stepTemplate *v1alpha1.StepTemplate) (result *v1alpha1.StepTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(steptemplatesResource, c.ns, stepTemplate), &v1alpha1.StepTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.StepTemplate), err
+}
+
+// Update takes the representation of a stepTemplate and updates it. Returns the server's representation of the stepTemplate, and an error, if there is any.
+func (c *FakeStepTemplates) Update(stepTemplate *v1alpha1.StepTemplate) (result *v1alpha1.StepTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(steptemplatesResource, c.ns, stepTemplate), &v1alpha1.StepTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.StepTemplate), err
+}
+
+// Delete takes name of the stepTemplate and deletes it. Returns an error if one occurs.
+func (c *FakeStepTemplates) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(steptemplatesResource, c.ns, name), &v1alpha1.StepTemplate{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeStepTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(steptemplatesResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.StepTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched stepTemplate.
+func (c *FakeStepTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.StepTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(steptemplatesResource, c.ns, name, data, subresources...), &v1alpha1.StepTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.StepTemplate), err
+}
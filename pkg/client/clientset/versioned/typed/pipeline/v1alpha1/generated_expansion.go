@@ -15,6 +15,8 @@ limitations under the License.
 */
 package v1alpha1
 
+type ClusterStepTemplateExpansion interface{}
+
 type ClusterTaskExpansion interface{}
 
 type PipelineExpansion interface{}
@@ -23,6 +25,10 @@ type PipelineResourceExpansion interface{}
 
 type PipelineRunExpansion interface{}
 
+type ResourceTypeExpansion interface{}
+
+type StepTemplateExpansion interface{}
+
 type TaskExpansion interface{}
 
 type TaskRunExpansion interface{}
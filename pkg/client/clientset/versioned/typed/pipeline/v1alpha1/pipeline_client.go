@@ -24,10 +24,13 @@ import (
 
 type TektonV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterStepTemplatesGetter
 	ClusterTasksGetter
 	PipelinesGetter
 	PipelineResourcesGetter
 	PipelineRunsGetter
+	ResourceTypesGetter
+	StepTemplatesGetter
 	TasksGetter
 	TaskRunsGetter
 }
@@ -37,6 +40,10 @@ type TektonV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TektonV1alpha1Client) ClusterStepTemplates() ClusterStepTemplateInterface {
+	return newClusterStepTemplates(c)
+}
+
 func (c *TektonV1alpha1Client) ClusterTasks() ClusterTaskInterface {
 	return newClusterTasks(c)
 }
@@ -53,6 +60,14 @@ func (c *TektonV1alpha1Client) PipelineRuns(namespace string) PipelineRunInterfa
 	return newPipelineRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) ResourceTypes() ResourceTypeInterface {
+	return newResourceTypes(c)
+}
+
+func (c *TektonV1alpha1Client) StepTemplates(namespace string) StepTemplateInterface {
+	return newStepTemplates(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Tasks(namespace string) TaskInterface {
 	return newTasks(c, namespace)
 }
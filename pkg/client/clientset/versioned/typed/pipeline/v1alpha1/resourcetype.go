@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	scheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ResourceTypesGetter has a method to return a ResourceTypeInterface.
+// A group's client should implement this interface.
+type ResourceTypesGetter interface {
+	ResourceTypes() ResourceTypeInterface
+}
+
+// ResourceTypeInterface has methods to work with ResourceType resources.
+type ResourceTypeInterface interface {
+	Create(*v1alpha1.ResourceType) (*v1alpha1.ResourceType, error)
+	Update(*v1alpha1.ResourceType) (*v1alpha1.ResourceType, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.ResourceType, error)
+	List(opts v1.ListOptions) (*v1alpha1.ResourceTypeList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ResourceType, err error)
+	ResourceTypeExpansion
+}
+
+// resourceTypes implements ResourceTypeInterface
+type resourceTypes struct {
+	client rest.Interface
+}
+
+// newResourceTypes returns a ResourceTypes
+func newResourceTypes(c *TektonV1alpha1Client) *resourceTypes {
+	return &resourceTypes{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the resourceType, and returns the corresponding resourceType object, and an error if there is any.
+func (c *resourceTypes) Get(name string, options v1.GetOptions) (result *v1alpha1.ResourceType, err error) {
+	result = &v1alpha1.ResourceType{}
+	err = c.client.Get().
+		Resource("resourcetypes").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ResourceTypes that match those selectors.
+func (c *resourceTypes) List(opts v1.ListOptions) (result *v1alpha1.ResourceTypeList, err error) {
+	result = &v1alpha1.ResourceTypeList{}
+	err = c.client.Get().
+		Resource("resourcetypes").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested resourceTypes.
+func (c *resourceTypes) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("resourcetypes").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a resourceType and creates it.  Returns the server's representation of the resourceType, and an error, if there is any.
+func (c *resourceTypes) Create(resourceType *v1alpha1.ResourceType) (result *v1alpha1.ResourceType, err error) {
+	result = &v1alpha1.ResourceType{}
+	err = c.client.Post().
+		Resource("resourcetypes").
+		Body(resourceType).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a resourceType and updates it. Returns the server's representation of the resourceType, and an error, if there is any.
+func (c *resourceTypes) Update(resourceType *v1alpha1.ResourceType) (result *v1alpha1.ResourceType, err error) {
+	result = &v1alpha1.ResourceType{}
+	err = c.client.Put().
+		Resource("resourcetypes").
+		Name(resourceType.Name).
+		Body(resourceType).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the resourceType and deletes it. Returns an error if one occurs.
+func (c *resourceTypes) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("resourcetypes").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *resourceTypes) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Resource("resourcetypes").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched resourceType.
+func (c *resourceTypes) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ResourceType, err error) {
+	result = &v1alpha1.ResourceType{}
+	err = c.client.Patch(pt).
+		Resource("resourcetypes").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
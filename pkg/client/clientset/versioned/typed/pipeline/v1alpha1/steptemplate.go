@@ -0,0 +1,154 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	scheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// StepTemplatesGetter has a method to return a StepTemplateInterface.
+// A group's client should implement this interface.
+type StepTemplatesGetter interface {
+	StepTemplates(namespace string) StepTemplateInterface
+}
+
+// StepTemplateInterface has methods to work with StepTemplate resources.
+type StepTemplateInterface interface {
+	Create(*v1alpha1.StepTemplate) (*v1alpha1.StepTemplate, error)
+	Update(*v1alpha1.StepTemplate) (*v1alpha1.StepTemplate, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.StepTemplate, error)
+	List(opts v1.ListOptions) (*v1alpha1.StepTemplateList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.StepTemplate, err error)
+	StepTemplateExpansion
+}
+
+// stepTemplates implements StepTemplateInterface
+type stepTemplates struct {
+	client rest.Interface
+	ns     string
+}
+
+// newStepTemplates returns a StepTemplates
+func newStepTemplates(c *TektonV1alpha1Client, namespace string) *stepTemplates {
+	return &stepTemplates{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the stepTemplate, and returns the corresponding stepTemplate object, and an error if there is any.
+func (c *stepTemplates) Get(name string, options v1.GetOptions) (result *v1alpha1.StepTemplate, err error) {
+	result = &v1alpha1.StepTemplate{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("steptemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of StepTemplates that match those selectors.
+func (c *stepTemplates) List(opts v1.ListOptions) (result *v1alpha1.StepTemplateList, err error) {
+	result = &v1alpha1.StepTemplateList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("steptemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested stepTemplates.
+func (c *stepTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("steptemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a stepTemplate and creates it.  Returns the server's representation of the stepTemplate, and an error, if there is any.
+func (c *stepTemplates) Create(stepTemplate *v1alpha1.StepTemplate) (result *v1alpha1.StepTemplate, err error) {
+	result = &v1alpha1.StepTemplate{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("steptemplates").
+		Body(stepTemplate).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a stepTemplate and updates it. Returns the server's representation of the stepTemplate, and an error, if there is any.
+func (c *stepTemplates) Update(stepTemplate *v1alpha1.StepTemplate) (result *v1alpha1.StepTemplate, err error) {
+	result = &v1alpha1.StepTemplate{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("steptemplates").
+		Name(stepTemplate.Name).
+		Body(stepTemplate).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the stepTemplate and deletes it. Returns an error if one occurs.
+func (c *stepTemplates) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("steptemplates").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *stepTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("steptemplates").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched stepTemplate.
+func (c *stepTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.StepTemplate, err error) {
+	result = &v1alpha1.StepTemplate{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("steptemplates").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
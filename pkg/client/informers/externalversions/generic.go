@@ -50,6 +50,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=tekton.dev, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("clustersteptemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().ClusterStepTemplates().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clustertasks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().ClusterTasks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("pipelines"):
@@ -58,6 +60,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().PipelineResources().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("pipelineruns"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().PipelineRuns().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("resourcetypes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().ResourceTypes().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("steptemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().StepTemplates().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("tasks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().Tasks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("taskruns"):
@@ -21,6 +21,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterStepTemplates returns a ClusterStepTemplateInformer.
+	ClusterStepTemplates() ClusterStepTemplateInformer
 	// ClusterTasks returns a ClusterTaskInformer.
 	ClusterTasks() ClusterTaskInformer
 	// Pipelines returns a PipelineInformer.
@@ -29,6 +31,10 @@ type Interface interface {
 	PipelineResources() PipelineResourceInformer
 	// PipelineRuns returns a PipelineRunInformer.
 	PipelineRuns() PipelineRunInformer
+	// ResourceTypes returns a ResourceTypeInformer.
+	ResourceTypes() ResourceTypeInformer
+	// StepTemplates returns a StepTemplateInformer.
+	StepTemplates() StepTemplateInformer
 	// Tasks returns a TaskInformer.
 	Tasks() TaskInformer
 	// TaskRuns returns a TaskRunInformer.
@@ -46,6 +52,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterStepTemplates returns a ClusterStepTemplateInformer.
+func (v *version) ClusterStepTemplates() ClusterStepTemplateInformer {
+	return &clusterStepTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterTasks returns a ClusterTaskInformer.
 func (v *version) ClusterTasks() ClusterTaskInformer {
 	return &clusterTaskInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -66,6 +77,16 @@ func (v *version) PipelineRuns() PipelineRunInformer {
 	return &pipelineRunInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ResourceTypes returns a ResourceTypeInformer.
+func (v *version) ResourceTypes() ResourceTypeInformer {
+	return &resourceTypeInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// StepTemplates returns a StepTemplateInformer.
+func (v *version) StepTemplates() StepTemplateInformer {
+	return &stepTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Tasks returns a TaskInformer.
 func (v *version) Tasks() TaskInformer {
 	return &taskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
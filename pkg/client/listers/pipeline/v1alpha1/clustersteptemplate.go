@@ -0,0 +1,62 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterStepTemplateLister helps list ClusterStepTemplates.
+type ClusterStepTemplateLister interface {
+	// List lists all ClusterStepTemplates in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.ClusterStepTemplate, err error)
+	// Get retrieves the ClusterStepTemplate from the index for a given name.
+	Get(name string) (*v1alpha1.ClusterStepTemplate, error)
+	ClusterStepTemplateListerExpansion
+}
+
+// clusterStepTemplateLister implements the ClusterStepTemplateLister interface.
+type clusterStepTemplateLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterStepTemplateLister returns a new ClusterStepTemplateLister.
+func NewClusterStepTemplateLister(indexer cache.Indexer) ClusterStepTemplateLister {
+	return &clusterStepTemplateLister{indexer: indexer}
+}
+
+// List lists all ClusterStepTemplates in the indexer.
+func (s *clusterStepTemplateLister) List(selector labels.Selector) (ret []*v1alpha1.ClusterStepTemplate, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ClusterStepTemplate))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterStepTemplate from the index for a given name.
+func (s *clusterStepTemplateLister) Get(name string) (*v1alpha1.ClusterStepTemplate, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("clustersteptemplate"), name)
+	}
+	return obj.(*v1alpha1.ClusterStepTemplate), nil
+}
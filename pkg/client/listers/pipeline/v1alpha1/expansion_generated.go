@@ -15,6 +15,10 @@ limitations under the License.
 */
 package v1alpha1
 
+// ClusterStepTemplateListerExpansion allows custom methods to be added to
+// ClusterStepTemplateLister.
+type ClusterStepTemplateListerExpansion interface{}
+
 // ClusterTaskListerExpansion allows custom methods to be added to
 // ClusterTaskLister.
 type ClusterTaskListerExpansion interface{}
@@ -43,6 +47,18 @@ type PipelineRunListerExpansion interface{}
 // PipelineRunNamespaceLister.
 type PipelineRunNamespaceListerExpansion interface{}
 
+// ResourceTypeListerExpansion allows custom methods to be added to
+// ResourceTypeLister.
+type ResourceTypeListerExpansion interface{}
+
+// StepTemplateListerExpansion allows custom methods to be added to
+// StepTemplateLister.
+type StepTemplateListerExpansion interface{}
+
+// StepTemplateNamespaceListerExpansion allows custom methods to be added to
+// StepTemplateNamespaceLister.
+type StepTemplateNamespaceListerExpansion interface{}
+
 // TaskListerExpansion allows custom methods to be added to
 // TaskLister.
 type TaskListerExpansion interface{}
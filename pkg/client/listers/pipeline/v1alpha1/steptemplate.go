@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// StepTemplateLister helps list StepTemplates.
+type StepTemplateLister interface {
+	// List lists all StepTemplates in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.StepTemplate, err error)
+	// StepTemplates returns an object that can list and get StepTemplates.
+	StepTemplates(namespace string) StepTemplateNamespaceLister
+	StepTemplateListerExpansion
+}
+
+// stepTemplateLister implements the StepTemplateLister interface.
+type stepTemplateLister struct {
+	indexer cache.Indexer
+}
+
+// NewStepTemplateLister returns a new StepTemplateLister.
+func NewStepTemplateLister(indexer cache.Indexer) StepTemplateLister {
+	return &stepTemplateLister{indexer: indexer}
+}
+
+// List lists all StepTemplates in the indexer.
+func (s *stepTemplateLister) List(selector labels.Selector) (ret []*v1alpha1.StepTemplate, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.StepTemplate))
+	})
+	return ret, err
+}
+
+// StepTemplates returns an object that can list and get StepTemplates.
+func (s *stepTemplateLister) StepTemplates(namespace string) StepTemplateNamespaceLister {
+	return stepTemplateNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// StepTemplateNamespaceLister helps list and get StepTemplates.
+type StepTemplateNamespaceLister interface {
+	// List lists all StepTemplates in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.StepTemplate, err error)
+	// Get retrieves the StepTemplate from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.StepTemplate, error)
+	StepTemplateNamespaceListerExpansion
+}
+
+// stepTemplateNamespaceLister implements the StepTemplateNamespaceLister
+// interface.
+type stepTemplateNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all StepTemplates in the indexer for a given namespace.
+func (s stepTemplateNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.StepTemplate, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.StepTemplate))
+	})
+	return ret, err
+}
+
+// Get retrieves the StepTemplate from the indexer for a given namespace and name.
+func (s stepTemplateNamespaceLister) Get(name string) (*v1alpha1.StepTemplate, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("steptemplate"), name)
+	}
+	return obj.(*v1alpha1.StepTemplate), nil
+}
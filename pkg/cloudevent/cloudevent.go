@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SpecVersion is the version of the CloudEvents specification followed by
+	// the events sent.
+	SpecVersion = "0.3"
+
+	// TaskRunSuccessfulV1 is the type of the events sent for TaskRuns which
+	// succeeded.
+	TaskRunSuccessfulV1 = "dev.tekton.event.taskrun.successful.v1"
+	// TaskRunFailedV1 is the type of the events sent for TaskRuns which failed.
+	TaskRunFailedV1 = "dev.tekton.event.taskrun.failed.v1"
+	// TaskRunUnknownV1 is the type of the events sent for TaskRuns which
+	// haven't finished.
+	TaskRunUnknownV1 = "dev.tekton.event.taskrun.unknown.v1"
+)
+
+// Event is a CloudEvent.
+type Event struct {
+	// ID identifies the event for its source, so that the deliveries of the
+	// same event can be deduplicated.
+	ID     string
+	Type   string
+	Source string
+	Time   time.Time
+	// Data is encoded as JSON.
+	Data interface{}
+}
+
+// TaskRunEventData is the data of the events sent for TaskRuns.
+type TaskRunEventData struct {
+	TaskRun *v1alpha1.TaskRun `json:"taskRun"`
+}
+
+// NewTaskRunEvent returns the event notifying of the state of tr, including
+// its status and the results of its resources.
+func NewTaskRunEvent(tr *v1alpha1.TaskRun) Event {
+	eventType := TaskRunUnknownV1
+	if c := tr.Status.GetCondition(apis.ConditionSucceeded); c != nil {
+		switch c.Status {
+		case corev1.ConditionTrue:
+			eventType = TaskRunSuccessfulV1
+		case corev1.ConditionFalse:
+			eventType = TaskRunFailedV1
+		}
+	}
+	t := time.Now()
+	if tr.Status.CompletionTime != nil {
+		t = tr.Status.CompletionTime.Time
+	}
+	return Event{
+		ID:     string(tr.UID),
+		Type:   eventType,
+		Source: fmt.Sprintf("/apis/%s/namespaces/%s/taskruns/%s", v1alpha1.SchemeGroupVersion, tr.Namespace, tr.Name),
+		Time:   t,
+		Data:   TaskRunEventData{TaskRun: tr},
+	}
+}
+
+// Send delivers event to target in the binary content mode of the HTTP
+// transport binding, the attributes of the event being sent as headers and
+// its data as the body. Any response other than a 2xx is an error.
+func Send(client *http.Client, target string, event Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return xerrors.Errorf("failed to encode the data of event %s: %w", event.ID, err)
+	}
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return xerrors.Errorf("invalid target %q: %w", target, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Ce-Specversion", SpecVersion)
+	req.Header.Set("Ce-Id", event.ID)
+	req.Header.Set("Ce-Type", event.Type)
+	req.Header.Set("Ce-Source", event.Source)
+	req.Header.Set("Ce-Time", event.Time.UTC().Format(time.RFC3339))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("failed to send event %s to %s: %w", event.ID, target, err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return xerrors.Errorf("%s rejected event %s: %s", target, event.ID, resp.Status)
+	}
+	return nil
+}
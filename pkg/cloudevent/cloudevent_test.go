@@ -0,0 +1,142 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func taskRunWithCondition(status corev1.ConditionStatus) *v1alpha1.TaskRun {
+	return &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-taskrun",
+			Namespace: "foo",
+			UID:       "d4f3c1b2",
+		},
+		Status: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: status,
+				}},
+			},
+			CompletionTime: &metav1.Time{Time: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)},
+			ResourcesResult: []v1alpha1.PipelineResourceResult{{
+				Name:   "built-image",
+				Digest: "sha256:1234",
+			}},
+		},
+	}
+}
+
+func TestNewTaskRunEvent(t *testing.T) {
+	for _, tc := range []struct {
+		status corev1.ConditionStatus
+		want   string
+	}{{
+		status: corev1.ConditionTrue,
+		want:   TaskRunSuccessfulV1,
+	}, {
+		status: corev1.ConditionFalse,
+		want:   TaskRunFailedV1,
+	}, {
+		status: corev1.ConditionUnknown,
+		want:   TaskRunUnknownV1,
+	}} {
+		t.Run(string(tc.status), func(t *testing.T) {
+			tr := taskRunWithCondition(tc.status)
+			event := NewTaskRunEvent(tr)
+			want := Event{
+				ID:     "d4f3c1b2",
+				Type:   tc.want,
+				Source: "/apis/tekton.dev/v1alpha1/namespaces/foo/taskruns/test-taskrun",
+				Time:   tr.Status.CompletionTime.Time,
+				Data:   TaskRunEventData{TaskRun: tr},
+			}
+			if d := cmp.Diff(want, event); d != "" {
+				t.Errorf("Event diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	var (
+		headers http.Header
+		body    []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected a POST, got %s", r.Method)
+		}
+		headers = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	tr := taskRunWithCondition(corev1.ConditionTrue)
+	if err := Send(server.Client(), server.URL, NewTaskRunEvent(tr)); err != nil {
+		t.Fatalf("Send() = %v", err)
+	}
+
+	for header, want := range map[string]string{
+		"Content-Type":   "application/json",
+		"Ce-Specversion": "0.3",
+		"Ce-Id":          "d4f3c1b2",
+		"Ce-Type":        TaskRunSuccessfulV1,
+		"Ce-Source":      "/apis/tekton.dev/v1alpha1/namespaces/foo/taskruns/test-taskrun",
+		"Ce-Time":        "2019-06-01T12:00:00Z",
+	} {
+		if got := headers.Get(header); got != want {
+			t.Errorf("Expected header %s to be %q but got %q", header, want, got)
+		}
+	}
+	var data TaskRunEventData
+	if err := json.Unmarshal(body, &data); err != nil {
+		t.Fatalf("Failed to decode the body of the event: %v", err)
+	}
+	if d := cmp.Diff(tr.Status.ResourcesResult, data.TaskRun.Status.ResourcesResult); d != "" {
+		t.Errorf("ResourcesResult diff -want, +got: %s", d)
+	}
+	if data.TaskRun.Name != "test-taskrun" {
+		t.Errorf("Expected the event to be about test-taskrun, got %q", data.TaskRun.Name)
+	}
+}
+
+func TestSend_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if err := Send(server.Client(), server.URL, NewTaskRunEvent(taskRunWithCondition(corev1.ConditionFalse))); err == nil {
+		t.Error("Expected an error when the target rejects the event")
+	}
+}
@@ -33,6 +33,11 @@ type Entrypointer struct {
 	// PostFile is the file to write when complete. If not specified, no
 	// file is written.
 	PostFile string
+	// Breakpoint pauses execution after the command completes, whether or
+	// not it succeeded.
+	Breakpoint bool
+	// BreakpointOnFailure pauses execution after the command fails.
+	BreakpointOnFailure bool
 
 	// Waiter encapsulates waiting for files to exist.
 	Waiter Waiter
@@ -40,6 +45,8 @@ type Entrypointer struct {
 	Runner Runner
 	// PostWriter encapsulates writing files when complete.
 	PostWriter PostWriter
+	// Debugger encapsulates pausing at a breakpoint.
+	Debugger Debugger
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -59,8 +66,15 @@ type PostWriter interface {
 	Write(file string)
 }
 
-// Go optionally waits for a file, runs the command, and writes a
-// post file.
+// Debugger encapsulates pausing execution at a breakpoint.
+type Debugger interface {
+	// Pause blocks until execution is resumed. The breakpoint is identified
+	// by the post file of the step that paused.
+	Pause(postFile string) error
+}
+
+// Go optionally waits for a file, runs the command, optionally pauses at a
+// breakpoint, and writes a post file.
 func (e Entrypointer) Go() error {
 	if e.WaitFile != "" {
 		if err := e.Waiter.Wait(e.WaitFile); err != nil {
@@ -77,6 +91,14 @@ func (e Entrypointer) Go() error {
 
 	err := e.Runner.Run(e.Args...)
 
+	// Pause before writing the post file, so that following steps keep
+	// waiting while the pod is inspected.
+	if e.Breakpoint || (e.BreakpointOnFailure && err != nil) {
+		if derr := e.Debugger.Pause(e.PostFile); derr != nil && err == nil {
+			err = derr
+		}
+	}
+
 	// Write the post file *no matter what*
 	e.WritePostFile(e.PostFile, err)
 
@@ -173,6 +173,62 @@ func TestEntrypointer(t *testing.T) {
 	}
 }
 
+func TestEntrypointerBreakpoints(t *testing.T) {
+	for _, c := range []struct {
+		desc                            string
+		breakpoint, breakpointOnFailure bool
+		runner                          Runner
+		wantPause                       bool
+	}{{
+		desc:   "no breakpoint",
+		runner: &fakeRunner{},
+	}, {
+		desc:       "breakpoint after success",
+		breakpoint: true,
+		runner:     &fakeRunner{},
+		wantPause:  true,
+	}, {
+		desc:       "breakpoint after failure",
+		breakpoint: true,
+		runner:     &fakeErrorRunner{},
+		wantPause:  true,
+	}, {
+		desc:                "breakpoint on failure after success",
+		breakpointOnFailure: true,
+		runner:              &fakeRunner{},
+	}, {
+		desc:                "breakpoint on failure after failure",
+		breakpointOnFailure: true,
+		runner:              &fakeErrorRunner{},
+		wantPause:           true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fd := &fakeDebugger{}
+			Entrypointer{
+				Entrypoint:          "echo",
+				PostFile:            "writeme",
+				Breakpoint:          c.breakpoint,
+				BreakpointOnFailure: c.breakpointOnFailure,
+				Waiter:              &fakeWaiter{},
+				Runner:              c.runner,
+				PostWriter:          &fakePostWriter{},
+				Debugger:            fd,
+			}.Go()
+
+			if c.wantPause {
+				if fd.paused == nil {
+					t.Error("Wanted pause at breakpoint, got nil")
+				} else if *fd.paused != "writeme" {
+					t.Errorf("Paused at %q, want %q", *fd.paused, "writeme")
+				}
+			}
+			if !c.wantPause && fd.paused != nil {
+				t.Errorf("Paused when not required")
+			}
+		})
+	}
+}
+
 type fakeWaiter struct{ waited *string }
 
 func (f *fakeWaiter) Wait(file string) error {
@@ -204,3 +260,10 @@ func (f *fakeErrorRunner) Run(args ...string) error {
 	f.args = &args
 	return xerrors.New("runner failed")
 }
+
+type fakeDebugger struct{ paused *string }
+
+func (f *fakeDebugger) Pause(postFile string) error {
+	f.paused = &postFile
+	return nil
+}
@@ -17,8 +17,11 @@ package git
 
 import (
 	"bytes"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -38,8 +41,228 @@ func run(logger *zap.SugaredLogger, cmd string, args ...string) error {
 	return nil
 }
 
+// HeadCommit returns the SHA of the commit checked out in the repository of
+// the current directory, where Fetch leaves it.
+func HeadCommit(logger *zap.SugaredLogger) (string, error) {
+	c := exec.Command("git", "rev-parse", "HEAD")
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		logger.Errorf("Error running git rev-parse HEAD: %v\n%v", err, stderr.String())
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// FetchSpec describes how to fetch a Git repository.
+type FetchSpec struct {
+	URL      string
+	Revision string
+	// Refspec holds space separated refspecs to fetch. If set, Revision is
+	// checked out from the fetched refs instead of being fetched itself.
+	Refspec string
+	Path    string
+	// Depth of the history to fetch, 0 fetches the full history.
+	Depth      uint
+	Submodules bool
+	// SparseCheckoutDirectories restricts the checkout to these directories.
+	SparseCheckoutDirectories []string
+	SSLVerify                 bool
+}
+
 // Fetch fetches the specified git repository at the revision into path.
-func Fetch(logger *zap.SugaredLogger, revision, path, url string) error {
+func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
+	if err := ensureHomeEnv(logger); err != nil {
+		return err
+	}
+
+	revision := spec.Revision
+	if revision == "" {
+		revision = "master"
+	}
+	if spec.Path != "" {
+		if err := run(logger, "git", "init", spec.Path); err != nil {
+			return err
+		}
+		if err := os.Chdir(spec.Path); err != nil {
+			return xerrors.Errorf("Failed to change directory with path %s; err: %w", spec.Path, err)
+		}
+	} else {
+		if err := run(logger, "git", "init"); err != nil {
+			return err
+		}
+	}
+	trimmedURL := strings.TrimSpace(spec.URL)
+	if err := run(logger, "git", "remote", "add", "origin", trimmedURL); err != nil {
+		return err
+	}
+	if !spec.SSLVerify {
+		if err := run(logger, "git", "config", "http.sslVerify", "false"); err != nil {
+			return err
+		}
+	}
+	if len(spec.SparseCheckoutDirectories) > 0 {
+		if err := configureSparseCheckout(logger, spec.SparseCheckoutDirectories); err != nil {
+			return err
+		}
+	}
+
+	recurseSubmodules := "--recurse-submodules=no"
+	if spec.Submodules {
+		recurseSubmodules = "--recurse-submodules=yes"
+	}
+	fetchArgs := []string{"fetch", recurseSubmodules}
+	if spec.Depth > 0 {
+		fetchArgs = append(fetchArgs, fmt.Sprintf("--depth=%d", spec.Depth))
+	}
+	fetchArgs = append(fetchArgs, "origin")
+
+	if spec.Refspec != "" {
+		// The revision may only exist in the fetched refs, e.g. a commit of
+		// a pull request, so it is checked out rather than fetched.
+		fetchArgs = append(fetchArgs, strings.Fields(spec.Refspec)...)
+		if err := run(logger, "git", fetchArgs...); err != nil {
+			return err
+		}
+		if err := run(logger, "git", "checkout", "-f", revision); err != nil {
+			return err
+		}
+	} else if err := run(logger, "git", append(fetchArgs, revision)...); err != nil {
+		// Fetch can fail if an old commitid was used so try git pull, performing regardless of error
+		// as no guarantee that the same error is returned by all git servers gitlab, github etc...
+		if err := run(logger, "git", "pull", recurseSubmodules, "origin"); err != nil {
+			logger.Warnf("Failed to pull origin : %s", err)
+		}
+		if err := run(logger, "git", "checkout", revision); err != nil {
+			return err
+		}
+	} else {
+		if err := run(logger, "git", "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return err
+		}
+	}
+
+	if spec.Submodules {
+		submoduleArgs := []string{"submodule", "update", "--init", "--recursive"}
+		if spec.Depth > 0 {
+			submoduleArgs = append(submoduleArgs, fmt.Sprintf("--depth=%d", spec.Depth))
+		}
+		if err := run(logger, "git", submoduleArgs...); err != nil {
+			return err
+		}
+	}
+	logger.Infof("Successfully cloned %s @ %s in path %s", trimmedURL, revision, spec.Path)
+	return nil
+}
+
+// PushSpec describes how to commit the changes in a directory and push them
+// to a branch of a Git repository.
+type PushSpec struct {
+	URL string
+	// Branch is the branch pushed to, it is created if it does not exist.
+	Branch      string
+	Path        string
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+	// Force overwrites the branch instead of requiring the push to
+	// fast-forward it.
+	Force     bool
+	SSLVerify bool
+}
+
+// Push commits all the changes in path and pushes HEAD to the branch. If path
+// is not a Git repository, e.g. because it was not fetched as an input, its
+// files are committed on top of the branch instead: they are added to or
+// replace the files of the branch, whose other files are kept.
+func Push(logger *zap.SugaredLogger, spec PushSpec) error {
+	if err := ensureHomeEnv(logger); err != nil {
+		return err
+	}
+	return push(logger, spec)
+}
+
+// push is Push without the setup of the credentials.
+func push(logger *zap.SugaredLogger, spec PushSpec) error {
+	if spec.Path != "" {
+		if err := os.Chdir(spec.Path); err != nil {
+			return xerrors.Errorf("Failed to change directory with path %s; err: %w", spec.Path, err)
+		}
+	}
+	// The options are passed on the command line so that the configuration
+	// of the repository, which may come from an input, is left untouched.
+	git := func(args ...string) error {
+		opts := []string{"-c", "user.name=" + spec.AuthorName, "-c", "user.email=" + spec.AuthorEmail}
+		if !spec.SSLVerify {
+			opts = append(opts, "-c", "http.sslVerify=false")
+		}
+		return run(logger, "git", append(opts, args...)...)
+	}
+	trimmedURL := strings.TrimSpace(spec.URL)
+	branchRef := "refs/heads/" + spec.Branch
+
+	addArgs := []string{"add", "-A"}
+	if _, err := os.Stat(".git"); os.IsNotExist(err) {
+		if err := git("init"); err != nil {
+			return err
+		}
+		if err := git("fetch", "--depth=1", trimmedURL, branchRef); err != nil {
+			logger.Infof("Branch %s not found in %s, it will be created", spec.Branch, trimmedURL)
+		} else if err := git("reset", "--mixed", "FETCH_HEAD"); err != nil {
+			return err
+		}
+		// The files of the branch missing from path are in the index but
+		// not in the working tree, they must not be committed as deleted.
+		addArgs = []string{"add", "--ignore-removal", "."}
+	}
+
+	if err := git(addArgs...); err != nil {
+		return err
+	}
+	changed, err := hasStagedChanges()
+	if err != nil {
+		return err
+	}
+	if changed {
+		if err := git("commit", "-m", spec.Message); err != nil {
+			return err
+		}
+	} else {
+		logger.Infof("No changes to commit in path %s", spec.Path)
+	}
+
+	pushArgs := []string{"push"}
+	if spec.Force {
+		pushArgs = append(pushArgs, "--force")
+	}
+	if err := git(append(pushArgs, trimmedURL, "HEAD:"+branchRef)...); err != nil {
+		return err
+	}
+	logger.Infof("Successfully pushed path %s to %s @ %s", spec.Path, trimmedURL, spec.Branch)
+	return nil
+}
+
+// hasStagedChanges returns true if the index of the repository in the current
+// directory differs from HEAD, or holds any file if there is no HEAD yet.
+func hasStagedChanges() (bool, error) {
+	c := exec.Command("git", "status", "--porcelain", "--untracked-files=no")
+	out, err := c.Output()
+	if err != nil {
+		return false, xerrors.Errorf("Failed to get the status of the repository; err: %w", err)
+	}
+	// The first column of each line is the status of the file in the index.
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		if len(line) > 0 && line[0] != ' ' {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ensureHomeEnv makes the credentials written by creds-init to $HOME visible
+// to ssh.
+func ensureHomeEnv(logger *zap.SugaredLogger) error {
 	// HACK: This is to get git+ssh to work since ssh doesn't respect the HOME
 	// env variable.
 	homepath, err := homedir.Dir()
@@ -65,40 +288,26 @@ func Fetch(logger *zap.SugaredLogger, revision, path, url string) error {
 			}
 		}
 	}
+	return nil
+}
 
-	if revision == "" {
-		revision = "master"
+// configureSparseCheckout restricts the working tree of the repository in the
+// current directory to dirs.
+func configureSparseCheckout(logger *zap.SugaredLogger, dirs []string) error {
+	if err := run(logger, "git", "config", "core.sparseCheckout", "true"); err != nil {
+		return err
 	}
-	if path != "" {
-		if err := run(logger, "git", "init", path); err != nil {
-			return err
-		}
-		if err := os.Chdir(path); err != nil {
-			return xerrors.Errorf("Failed to change directory with path %s; err: %w", path, err)
-		}
-	} else {
-		if err := run(logger, "git", "init"); err != nil {
-			return err
-		}
+	var patterns strings.Builder
+	for _, dir := range dirs {
+		// Patterns are anchored to the root of the repository, so that they
+		// don't match nested directories with the same name.
+		patterns.WriteString("/" + strings.Trim(strings.TrimSpace(dir), "/") + "/\n")
 	}
-	trimmedURL := strings.TrimSpace(url)
-	if err := run(logger, "git", "remote", "add", "origin", trimmedURL); err != nil {
-		return err
+	if err := os.MkdirAll(filepath.Join(".git", "info"), 0755); err != nil {
+		return xerrors.Errorf("Failed to create .git/info; err: %w", err)
 	}
-	if err := run(logger, "git", "fetch", "--depth=1", "--recurse-submodules=yes", "origin", revision); err != nil {
-		// Fetch can fail if an old commitid was used so try git pull, performing regardless of error
-		// as no guarantee that the same error is returned by all git servers gitlab, github etc...
-		if err := run(logger, "git", "pull", "--recurse-submodules=yes", "origin"); err != nil {
-			logger.Warnf("Failed to pull origin : %s", err)
-		}
-		if err := run(logger, "git", "checkout", revision); err != nil {
-			return err
-		}
-	} else {
-		if err := run(logger, "git", "reset", "--hard", "FETCH_HEAD"); err != nil {
-			return err
-		}
+	if err := ioutil.WriteFile(filepath.Join(".git", "info", "sparse-checkout"), []byte(patterns.String()), 0644); err != nil {
+		return xerrors.Errorf("Failed to write sparse-checkout patterns; err: %w", err)
 	}
-	logger.Infof("Successfully cloned %s @ %s in path %s", trimmedURL, revision, path)
 	return nil
 }
@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+)
+
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	c := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	c.Dir = dir
+	out, err := c.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPushOnTopOfBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// push changes the working directory to the pushed one.
+	defer os.Chdir(wd)
+
+	tmp, err := ioutil.TempDir("", "git-push")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	remote, seed, output := filepath.Join(tmp, "remote.git"), filepath.Join(tmp, "seed"), filepath.Join(tmp, "output")
+	for _, d := range []string{remote, seed, output} {
+		if err := os.Mkdir(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitCmd(t, remote, "init", "--bare")
+	gitCmd(t, seed, "init")
+	writeFiles(t, seed, map[string]string{"README.md": "readme", "version": "1.0"})
+	gitCmd(t, seed, "add", "-A")
+	gitCmd(t, seed, "commit", "-m", "Initial commit")
+	gitCmd(t, seed, "push", remote, "HEAD:refs/heads/release")
+
+	// The output was not fetched as an input, only the version is written.
+	writeFiles(t, output, map[string]string{"version": "1.1"})
+	if err := push(zap.NewNop().Sugar(), PushSpec{
+		URL:         remote,
+		Branch:      "release",
+		Path:        output,
+		Message:     "Bump the version to 1.1",
+		AuthorName:  "Tekton",
+		AuthorEmail: "tekton@tekton.dev",
+		SSLVerify:   true,
+	}); err != nil {
+		t.Fatalf("push() = %v", err)
+	}
+
+	files := gitCmd(t, remote, "ls-tree", "--name-only", "release")
+	if d := cmp.Diff("README.md\nversion", files); d != "" {
+		t.Errorf("Unexpected files on the branch (-want +got): %s", d)
+	}
+	if got := gitCmd(t, remote, "show", "release:version"); got != "1.1" {
+		t.Errorf("Expected the version to be updated to 1.1, got %q", got)
+	}
+	if got := gitCmd(t, remote, "log", "-1", "--format=%s", "release"); got != "Bump the version to 1.1" {
+		t.Errorf("Unexpected commit message %q", got)
+	}
+	if got := gitCmd(t, remote, "rev-list", "--count", "release"); got != "2" {
+		t.Errorf("Expected the commit to be on top of the branch, got %s commits", got)
+	}
+}
+
+func TestConfigureSparseCheckoutAnchorsPatterns(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	tmp, err := ioutil.TempDir("", "git-sparse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	gitCmd(t, tmp, "init")
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := configureSparseCheckout(zap.NewNop().Sugar(), []string{"docs", " cmd/controller/ "}); err != nil {
+		t.Fatalf("configureSparseCheckout() = %v", err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(tmp, ".git", "info", "sparse-checkout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff("/docs/\n/cmd/controller/\n", string(b)); d != "" {
+		t.Errorf("Unexpected sparse-checkout patterns (-want +got): %s", d)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmchart
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	pkgarchive "github.com/tektoncd/pipeline/pkg/archive"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/yaml"
+)
+
+// chartFile is the file describing a chart, at the root of its directory.
+const chartFile = "Chart.yaml"
+
+// pack archives the chart of dir the way helm package does, under a
+// directory named after the chart, with the version of its Chart.yaml set to
+// version. It returns the archive and the Chart.yaml as JSON.
+func pack(dir, name, version string) ([]byte, []byte, error) {
+	chart, err := ioutil.ReadFile(filepath.Join(dir, chartFile))
+	if err != nil {
+		return nil, nil, xerrors.Errorf("reading the chart of %s: %w", dir, err)
+	}
+	metadata := map[string]interface{}{}
+	if err := yaml.Unmarshal(chart, &metadata); err != nil {
+		return nil, nil, xerrors.Errorf("parsing %s of %s: %w", chartFile, dir, err)
+	}
+	if metadata["name"] != name {
+		return nil, nil, xerrors.Errorf("the chart of %s is named %v, expected %s", dir, metadata["name"], name)
+	}
+	metadata["version"] = version
+	if chart, err = yaml.Marshal(metadata); err != nil {
+		return nil, nil, err
+	}
+	metadataJSON, err := yaml.YAMLToJSON(chart)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		// Only the files and directories of a chart are loaded by helm.
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+			return tw.WriteHeader(hdr)
+		}
+		if rel == chartFile {
+			hdr.Size = int64(len(chart))
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			_, err = tw.Write(chart)
+			return err
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return nil, nil, xerrors.Errorf("packaging the chart of %s: %w", dir, err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), metadataJSON, nil
+}
+
+// unpack extracts the chart archive into dest, without the directory named
+// after the chart the archive holds it in.
+func unpack(archive []byte, dest string) error {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return xerrors.Errorf("reading chart archive: %w", err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return xerrors.Errorf("reading chart archive: %w", err)
+		}
+		parts := strings.SplitN(path.Clean(hdr.Name), "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		// Links and devices are not part of a chart.
+		if hdr.Typeflag != tar.TypeDir && hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		if err := pkgarchive.ExtractEntry(dest, parts[1], hdr, tr); err != nil {
+			return err
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dest, chartFile)); err != nil {
+		return xerrors.Errorf("the chart archive has no %s: %w", chartFile, err)
+	}
+	return nil
+}
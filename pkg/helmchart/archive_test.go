@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmchart
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPack_NameMismatch(t *testing.T) {
+	dir := chartDir(t, "wizzbang", "1.2.3")
+	defer os.RemoveAll(dir)
+	if _, _, err := pack(dir, "foo", "1.2.3"); err == nil || !strings.Contains(err.Error(), "is named wizzbang") {
+		t.Errorf("Expected an error about the chart name, got %v", err)
+	}
+}
+
+func TestPack_MissingChart(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	if _, _, err := pack(dir, "wizzbang", "1.2.3"); err == nil {
+		t.Error("Expected an error packaging a directory without Chart.yaml")
+	}
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gw.Close()
+	return buf.Bytes()
+}
+
+func TestUnpack(t *testing.T) {
+	dest := tempDir(t)
+	defer os.RemoveAll(dest)
+	archive := tarGz(t, map[string]string{
+		"wizzbang/Chart.yaml":           "name: wizzbang\n",
+		"wizzbang/charts/db/Chart.yaml": "name: db\n",
+	})
+	if err := unpack(archive, dest); err != nil {
+		t.Fatalf("unpack() = %v", err)
+	}
+	for _, f := range []string{"Chart.yaml", "charts/db/Chart.yaml"} {
+		if _, err := os.Stat(filepath.Join(dest, f)); err != nil {
+			t.Errorf("Expected %s to be unpacked: %v", f, err)
+		}
+	}
+}
+
+func TestUnpack_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		files map[string]string
+	}{{
+		name:  "escape",
+		files: map[string]string{"wizzbang/Chart.yaml": "name: wizzbang\n", "wizzbang/../../../evil": "boom"},
+	}, {
+		name:  "no chart",
+		files: map[string]string{"wizzbang/values.yaml": "replicas: 1\n"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			dest := tempDir(t)
+			defer os.RemoveAll(dest)
+			if err := unpack(tarGz(t, tc.files), dest); err == nil {
+				t.Error("Expected an error unpacking the archive")
+			}
+		})
+	}
+}
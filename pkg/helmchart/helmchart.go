@@ -0,0 +1,216 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helmchart fetches Helm charts from chart repositories and OCI
+// registries into a directory, and packages and uploads the chart of a
+// directory to them.
+package helmchart
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// UsernameEnvVar is the environment variable holding the username used
+	// to authenticate with the repository, if any.
+	UsernameEnvVar = "HELM_USERNAME"
+	// PasswordEnvVar is the environment variable holding the password used
+	// to authenticate with the repository, if any.
+	PasswordEnvVar = "HELM_PASSWORD"
+
+	// ociScheme prefixes the URLs of OCI registries.
+	ociScheme = "oci://"
+)
+
+// Spec describes a version of a chart of a repository and the directory
+// holding it.
+type Spec struct {
+	// URL is the URL of a chart repository, or an oci:// URL of the
+	// registry namespace the chart is stored in.
+	URL     string
+	Name    string
+	Version string
+	// Path is the directory holding the chart, i.e. its Chart.yaml.
+	Path     string
+	Username string
+	Password string
+}
+
+func (s Spec) isOCI() bool {
+	return strings.HasPrefix(s.URL, ociScheme)
+}
+
+// Fetch downloads the version of the chart of the spec and unpacks it into
+// its directory.
+func Fetch(client *http.Client, spec Spec) error {
+	var archive []byte
+	var err error
+	if spec.isOCI() {
+		archive, err = pullOCI(spec)
+	} else {
+		archive, err = fetchFromRepository(client, spec)
+	}
+	if err != nil {
+		return err
+	}
+	return unpack(archive, spec.Path)
+}
+
+// Upload packages the chart of the directory of the spec at its version and
+// uploads it, returning the digest of the uploaded archive.
+func Upload(client *http.Client, spec Spec) (string, error) {
+	archive, metadata, err := pack(spec.Path, spec.Name, spec.Version)
+	if err != nil {
+		return "", err
+	}
+	if spec.isOCI() {
+		err = pushOCI(spec, archive, metadata)
+	} else {
+		err = uploadToRepository(client, spec, archive)
+	}
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(archive)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// index is the part of the index.yaml of a chart repository listing the
+// versions of its charts.
+type index struct {
+	Entries map[string][]struct {
+		Version string   `json:"version"`
+		URLs    []string `json:"urls"`
+		Digest  string   `json:"digest"`
+	} `json:"entries"`
+}
+
+// fetchFromRepository looks the chart up in the index of the repository and
+// downloads its archive, verifying its digest.
+func fetchFromRepository(client *http.Client, spec Spec) ([]byte, error) {
+	base, err := url.Parse(strings.TrimSuffix(spec.URL, "/") + "/")
+	if err != nil {
+		return nil, xerrors.Errorf("invalid chart repository URL %q: %w", spec.URL, err)
+	}
+	b, err := get(client, spec, base.ResolveReference(&url.URL{Path: "index.yaml"}).String())
+	if err != nil {
+		return nil, err
+	}
+	var idx index
+	if err := yaml.Unmarshal(b, &idx); err != nil {
+		return nil, xerrors.Errorf("parsing the index of %s: %w", spec.URL, err)
+	}
+	for _, entry := range idx.Entries[spec.Name] {
+		if entry.Version != spec.Version {
+			continue
+		}
+		if len(entry.URLs) == 0 {
+			return nil, xerrors.Errorf("chart %s %s of %s has no URL", spec.Name, spec.Version, spec.URL)
+		}
+		u, err := base.Parse(entry.URLs[0])
+		if err != nil {
+			return nil, xerrors.Errorf("invalid URL %q of chart %s %s: %w", entry.URLs[0], spec.Name, spec.Version, err)
+		}
+		archive, err := get(client, spec, u.String())
+		if err != nil {
+			return nil, err
+		}
+		if entry.Digest != "" {
+			if sum := sha256.Sum256(archive); hex.EncodeToString(sum[:]) != entry.Digest {
+				return nil, xerrors.Errorf("sha256 digest of %s is %x, expected %s", u, sum, entry.Digest)
+			}
+		}
+		return archive, nil
+	}
+	return nil, xerrors.Errorf("chart %s %s not found in %s", spec.Name, spec.Version, spec.URL)
+}
+
+// uploadToRepository uploads the archive with the API of ChartMuseum, which
+// adds it to the index of the repository. Repositories which don't implement
+// the POST of charts to /api/charts of ChartMuseum aren't supported.
+func uploadToRepository(client *http.Client, spec Spec, archive []byte) error {
+	req, err := newRequest(spec, http.MethodPost, strings.TrimSuffix(spec.URL, "/")+"/api/charts", bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("uploading chart %s %s to %s: %w", spec.Name, spec.Version, spec.URL, err)
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
+
+func get(client *http.Client, spec Spec, u string) ([]byte, error) {
+	req, err := newRequest(spec, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("fetching %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, xerrors.Errorf("fetching %s: %w", u, err)
+	}
+	return b, nil
+}
+
+func newRequest(spec Spec, method, u string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid URL %q: %w", u, err)
+	}
+	if (spec.Username != "" || spec.Password != "") && spec.authenticates(req.URL) {
+		req.SetBasicAuth(spec.Username, spec.Password)
+	}
+	return req, nil
+}
+
+// authenticates returns true if the credentials of the spec are sent to u,
+// i.e. if u has the scheme and host of the repository. The archives of an
+// index may be served by another host, which mustn't get the credentials.
+func (s Spec) authenticates(u *url.URL) bool {
+	repo, err := url.Parse(s.URL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(repo.Scheme, u.Scheme) && strings.EqualFold(repo.Host, u.Host)
+}
+
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return xerrors.Errorf("%s %s: unexpected status %s: %s", resp.Request.Method, resp.Request.URL, resp.Status, strings.TrimSpace(string(b)))
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmchart
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "helm-chart-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// chartDir returns a directory holding a chart with a template.
+func chartDir(t *testing.T, name, version string) string {
+	t.Helper()
+	dir := tempDir(t)
+	chart := fmt.Sprintf("apiVersion: v1\nname: %s\nversion: %s\n", name, version)
+	if err := ioutil.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(chart), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "templates", "service.yaml"), []byte("kind: Service\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// fakeRepository serves the charts uploaded to it with the ChartMuseum API
+// and their index.
+type fakeRepository struct {
+	charts map[string][]byte
+	// badDigest is set in the index instead of the digest of the charts if
+	// not empty.
+	badDigest string
+	// chartsURL is the URL the archives of the index are served from, the
+	// repository itself if empty.
+	chartsURL string
+}
+
+func (f *fakeRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, _ := r.BasicAuth(); user != "user" || pass != "pass" {
+		http.Error(w, "denied", http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/charts":
+		b, _ := ioutil.ReadAll(r.Body)
+		f.charts["wizzbang-1.2.3.tgz"] = b
+		w.WriteHeader(http.StatusCreated)
+	case r.URL.Path == "/index.yaml":
+		fmt.Fprintln(w, "apiVersion: v1\nentries:\n  wizzbang:")
+		for file, b := range f.charts {
+			digest := f.badDigest
+			if digest == "" {
+				sum := sha256.Sum256(b)
+				digest = hex.EncodeToString(sum[:])
+			}
+			fmt.Fprintf(w, "  - version: 1.2.3\n    digest: %s\n    urls:\n    - %s\n", digest, f.chartsURL+"charts/"+file)
+		}
+	case strings.HasPrefix(r.URL.Path, "/charts/"):
+		b, ok := f.charts[strings.TrimPrefix(r.URL.Path, "/charts/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestUploadFetchRepository(t *testing.T) {
+	f := &fakeRepository{charts: map[string][]byte{}}
+	server := httptest.NewServer(f)
+	defer server.Close()
+	src := chartDir(t, "wizzbang", "0.0.0")
+	defer os.RemoveAll(src)
+	spec := Spec{
+		URL:      server.URL,
+		Name:     "wizzbang",
+		Version:  "1.2.3",
+		Path:     src,
+		Username: "user",
+		Password: "pass",
+	}
+
+	digest, err := Upload(server.Client(), spec)
+	if err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	sum := sha256.Sum256(f.charts["wizzbang-1.2.3.tgz"])
+	if want := "sha256:" + hex.EncodeToString(sum[:]); digest != want {
+		t.Errorf("Expected digest %s, got %s", want, digest)
+	}
+
+	dest := tempDir(t)
+	defer os.RemoveAll(dest)
+	spec.Path = dest
+	if err := Fetch(server.Client(), spec); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+	for file, want := range map[string]string{
+		"Chart.yaml":             "apiVersion: v1\nname: wizzbang\nversion: 1.2.3\n",
+		"templates/service.yaml": "kind: Service\n",
+	} {
+		got, err := ioutil.ReadFile(filepath.Join(dest, file))
+		if err != nil {
+			t.Fatalf("Expected %s to be fetched: %v", file, err)
+		}
+		if d := cmp.Diff(want, string(got)); d != "" {
+			t.Errorf("Unexpected %s -want, +got: %s", file, d)
+		}
+	}
+}
+
+func TestFetchRepository_CredentialsOnlySentToRepository(t *testing.T) {
+	src := chartDir(t, "wizzbang", "1.2.3")
+	defer os.RemoveAll(src)
+	archive, _, err := pack(src, "wizzbang", "1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var authorized []string
+	charts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			authorized = append(authorized, auth)
+		}
+		w.Write(archive)
+	}))
+	defer charts.Close()
+	f := &fakeRepository{
+		charts:    map[string][]byte{"wizzbang-1.2.3.tgz": archive},
+		chartsURL: charts.URL + "/",
+	}
+	server := httptest.NewServer(f)
+	defer server.Close()
+
+	dest := tempDir(t)
+	defer os.RemoveAll(dest)
+	spec := Spec{URL: server.URL, Name: "wizzbang", Version: "1.2.3", Path: dest, Username: "user", Password: "pass"}
+	if err := Fetch(server.Client(), spec); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+	if len(authorized) != 0 {
+		t.Errorf("Expected the credentials not to be sent to %s, got %v", charts.URL, authorized)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "Chart.yaml")); err != nil {
+		t.Errorf("Expected the chart to be fetched: %v", err)
+	}
+}
+
+func TestFetchRepository_Invalid(t *testing.T) {
+	f := &fakeRepository{charts: map[string][]byte{}}
+	server := httptest.NewServer(f)
+	defer server.Close()
+	src := chartDir(t, "wizzbang", "1.2.3")
+	defer os.RemoveAll(src)
+	archive, _, err := pack(src, "wizzbang", "1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.charts["wizzbang-1.2.3.tgz"] = archive
+
+	for _, tc := range []struct {
+		name      string
+		spec      Spec
+		badDigest string
+		want      string
+	}{{
+		name: "unknown version",
+		spec: Spec{URL: server.URL, Name: "wizzbang", Version: "2.0.0", Username: "user", Password: "pass"},
+		want: "chart wizzbang 2.0.0 not found",
+	}, {
+		name: "unknown chart",
+		spec: Spec{URL: server.URL, Name: "foo", Version: "1.2.3", Username: "user", Password: "pass"},
+		want: "chart foo 1.2.3 not found",
+	}, {
+		name:      "digest mismatch",
+		spec:      Spec{URL: server.URL, Name: "wizzbang", Version: "1.2.3", Username: "user", Password: "pass"},
+		badDigest: strings.Repeat("0", 64),
+		want:      "sha256 digest",
+	}, {
+		name: "unauthorized",
+		spec: Spec{URL: server.URL, Name: "wizzbang", Version: "1.2.3"},
+		want: "401 Unauthorized",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			f.badDigest = tc.badDigest
+			dest := tempDir(t)
+			defer os.RemoveAll(dest)
+			tc.spec.Path = dest
+			err := Fetch(server.Client(), tc.spec)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Expected an error containing %q, got %v", tc.want, err)
+			}
+			if files, _ := ioutil.ReadDir(dest); len(files) != 0 {
+				t.Errorf("Expected nothing to be fetched, got %d files", len(files))
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmchart
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// configMediaType is the media type of the config of charts stored in
+	// OCI registries, their Chart.yaml as JSON.
+	configMediaType types.MediaType = "application/vnd.cncf.helm.config.v1+json"
+	// contentMediaType is the media type of the layer holding the chart
+	// archive.
+	contentMediaType types.MediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+)
+
+// reference returns the reference of the chart in the OCI registry, named
+// after it under the namespace of the URL and tagged with its version.
+func (s Spec) reference() (name.Reference, error) {
+	r := strings.TrimSuffix(strings.TrimPrefix(s.URL, ociScheme), "/") + "/" + s.Name + ":" + s.Version
+	ref, err := name.ParseReference(r, name.WeakValidation)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid chart reference %q: %w", r, err)
+	}
+	return ref, nil
+}
+
+// authenticator returns the credentials of the spec, or the ones found in
+// the default keychain for the registry.
+func (s Spec) authenticator(ref name.Reference) (authn.Authenticator, error) {
+	if s.Username != "" || s.Password != "" {
+		return &authn.Basic{Username: s.Username, Password: s.Password}, nil
+	}
+	auth, err := authn.DefaultKeychain.Resolve(ref.Context().Registry)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get the credentials of %s: %w", ref.Context().Registry, err)
+	}
+	return auth, nil
+}
+
+// pullOCI returns the chart archive of the manifest of the chart.
+func pullOCI(spec Spec) ([]byte, error) {
+	ref, err := spec.reference()
+	if err != nil {
+		return nil, err
+	}
+	auth, err := spec.authenticator(ref)
+	if err != nil {
+		return nil, err
+	}
+	img, err := remote.Image(ref, remote.WithAuth(auth))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to fetch chart %s: %w", ref, err)
+	}
+	m, err := img.Manifest()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to fetch chart %s: %w", ref, err)
+	}
+	for _, l := range m.Layers {
+		if l.MediaType != contentMediaType {
+			continue
+		}
+		layer, err := img.LayerByDigest(l.Digest)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to fetch chart %s: %w", ref, err)
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to fetch chart %s: %w", ref, err)
+		}
+		defer rc.Close()
+		b, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to fetch chart %s: %w", ref, err)
+		}
+		return b, nil
+	}
+	return nil, xerrors.Errorf("%s is not a chart, it has no %s layer", ref, contentMediaType)
+}
+
+// pushOCI pushes the chart archive and its metadata as the manifest of the
+// chart.
+func pushOCI(spec Spec, archive, metadata []byte) error {
+	ref, err := spec.reference()
+	if err != nil {
+		return err
+	}
+	auth, err := spec.authenticator(ref)
+	if err != nil {
+		return err
+	}
+	img, err := newChartImage(archive, metadata)
+	if err != nil {
+		return err
+	}
+	if err := remote.Write(ref, img, auth, http.DefaultTransport); err != nil {
+		return xerrors.Errorf("failed to push chart %s: %w", ref, err)
+	}
+	return nil
+}
+
+// chartImage is the manifest of a chart, its config being the metadata of
+// the chart and its only layer the chart archive.
+type chartImage struct {
+	manifest []byte
+	config   []byte
+	content  *blob
+}
+
+var _ partial.CompressedImageCore = (*chartImage)(nil)
+
+func newChartImage(archive, metadata []byte) (v1.Image, error) {
+	content, err := newBlob(archive)
+	if err != nil {
+		return nil, err
+	}
+	config, err := newBlob(metadata)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := json.Marshal(v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		Config:        config.descriptor(configMediaType),
+		Layers:        []v1.Descriptor{content.descriptor(contentMediaType)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return partial.CompressedToImage(&chartImage{manifest: manifest, config: metadata, content: content})
+}
+
+func (i *chartImage) RawConfigFile() ([]byte, error) {
+	return i.config, nil
+}
+
+func (i *chartImage) MediaType() (types.MediaType, error) {
+	return types.OCIManifestSchema1, nil
+}
+
+func (i *chartImage) RawManifest() ([]byte, error) {
+	return i.manifest, nil
+}
+
+func (i *chartImage) LayerByDigest(h v1.Hash) (partial.CompressedLayer, error) {
+	if h != i.content.digest {
+		return nil, xerrors.Errorf("unknown blob %s", h)
+	}
+	return i.content, nil
+}
+
+// blob is content stored as is in a registry.
+type blob struct {
+	content []byte
+	digest  v1.Hash
+}
+
+func newBlob(content []byte) (*blob, error) {
+	h, _, err := v1.SHA256(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	return &blob{content: content, digest: h}, nil
+}
+
+func (b *blob) descriptor(mediaType types.MediaType) v1.Descriptor {
+	return v1.Descriptor{MediaType: mediaType, Size: int64(len(b.content)), Digest: b.digest}
+}
+
+func (b *blob) Digest() (v1.Hash, error) {
+	return b.digest, nil
+}
+
+func (b *blob) Compressed() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(b.content)), nil
+}
+
+func (b *blob) Size() (int64, error) {
+	return int64(len(b.content)), nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmchart
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeRegistry implements the parts of the registry API used to push and
+// pull manifests.
+type fakeRegistry struct {
+	sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   map[string][]byte
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	p := r.URL.Path
+	body, _ := ioutil.ReadAll(r.Body)
+	switch {
+	case p == "/v2/":
+		w.WriteHeader(http.StatusOK)
+	case strings.Contains(p, "/blobs/uploads/"):
+		switch r.Method {
+		case http.MethodPost:
+			loc := fmt.Sprintf("%s%d", p, len(f.uploads))
+			f.uploads[loc] = nil
+			w.Header().Set("Location", loc)
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodPatch:
+			f.uploads[p] = append(f.uploads[p], body...)
+			w.Header().Set("Location", p)
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodPut:
+			f.blobs[r.URL.Query().Get("digest")] = append(f.uploads[p], body...)
+			w.WriteHeader(http.StatusCreated)
+		}
+	case strings.Contains(p, "/blobs/"):
+		b, ok := f.blobs[p[strings.LastIndex(p, "/")+1:]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	case strings.Contains(p, "/manifests/"):
+		if r.Method == http.MethodPut {
+			f.manifests[p] = body
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		b, ok := f.manifests[p]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		w.Write(b)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestPushPullOCI(t *testing.T) {
+	f := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, uploads: map[string][]byte{}}
+	server := httptest.NewServer(f)
+	defer server.Close()
+	src := chartDir(t, "wizzbang", "0.0.0")
+	defer os.RemoveAll(src)
+	spec := Spec{
+		URL:     "oci://" + strings.TrimPrefix(server.URL, "http://") + "/charts",
+		Name:    "wizzbang",
+		Version: "1.2.3",
+		Path:    src,
+		// Avoid looking up credentials in the docker config of the host.
+		Username: "user",
+		Password: "pass",
+	}
+
+	if _, err := Upload(http.DefaultClient, spec); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	if _, ok := f.manifests["/v2/charts/wizzbang/manifests/1.2.3"]; !ok {
+		t.Fatalf("Expected the chart to be pushed as charts/wizzbang:1.2.3, got %v", f.manifests)
+	}
+
+	dest := tempDir(t)
+	defer os.RemoveAll(dest)
+	spec.Path = dest
+	if err := Fetch(http.DefaultClient, spec); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+	chart, err := ioutil.ReadFile(filepath.Join(dest, "Chart.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff("apiVersion: v1\nname: wizzbang\nversion: 1.2.3\n", string(chart)); d != "" {
+		t.Errorf("Unexpected Chart.yaml -want, +got: %s", d)
+	}
+}
+
+func TestFetchOCI_NotAChart(t *testing.T) {
+	f := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, uploads: map[string][]byte{}}
+	f.manifests["/v2/charts/wizzbang/manifests/1.2.3"] = []byte(`{"schemaVersion":2,"config":{"mediaType":"application/vnd.oci.image.config.v1+json"},"layers":[]}`)
+	server := httptest.NewServer(f)
+	defer server.Close()
+
+	err := Fetch(http.DefaultClient, Spec{
+		URL:      "oci://" + strings.TrimPrefix(server.URL, "http://") + "/charts",
+		Name:     "wizzbang",
+		Version:  "1.2.3",
+		Path:     "/does/not/matter",
+		Username: "user",
+		Password: "pass",
+	})
+	if err == nil || !strings.Contains(err.Error(), "is not a chart") {
+		t.Errorf("Expected an error about the missing chart layer, got %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpresource
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pkgarchive "github.com/tektoncd/pipeline/pkg/archive"
+	"golang.org/x/xerrors"
+)
+
+// Format is the format of the content of a URL.
+type Format string
+
+const (
+	// FormatFile is a single file stored as is.
+	FormatFile Format = "file"
+	// FormatTar is a tar archive of a directory.
+	FormatTar Format = "tar"
+	// FormatTarGz is a gzip compressed tar archive of a directory.
+	FormatTarGz Format = "tar.gz"
+	// FormatZip is a zip archive of a directory.
+	FormatZip Format = "zip"
+)
+
+// DetectFormat returns the format of the content of rawURL according to the
+// extension of its path, FormatFile if it is not an archive.
+func DetectFormat(rawURL string) Format {
+	p := strings.ToLower(urlPath(rawURL))
+	switch {
+	case strings.HasSuffix(p, ".tar.gz"), strings.HasSuffix(p, ".tgz"):
+		return FormatTarGz
+	case strings.HasSuffix(p, ".tar"):
+		return FormatTar
+	case strings.HasSuffix(p, ".zip"):
+		return FormatZip
+	}
+	return FormatFile
+}
+
+// extract unpacks the archive in src of the given format into the directory
+// dest.
+func extract(src *os.File, format Format, dest string) error {
+	switch format {
+	case FormatTar:
+		return extractTar(src, dest)
+	case FormatTarGz:
+		zr, err := gzip.NewReader(src)
+		if err != nil {
+			return xerrors.Errorf("reading gzip stream: %w", err)
+		}
+		defer zr.Close()
+		return extractTar(zr, dest)
+	case FormatZip:
+		info, err := src.Stat()
+		if err != nil {
+			return err
+		}
+		return extractZip(src, info.Size(), dest)
+	}
+	return xerrors.Errorf("unsupported archive format %q", format)
+}
+
+// archive packs the directory src into dest in the given format.
+func archive(src string, format Format, dest io.Writer) error {
+	switch format {
+	case FormatTar:
+		return archiveTar(src, dest)
+	case FormatTarGz:
+		zw := gzip.NewWriter(dest)
+		if err := archiveTar(src, zw); err != nil {
+			return err
+		}
+		return zw.Close()
+	case FormatZip:
+		return archiveZip(src, dest)
+	}
+	return xerrors.Errorf("unsupported archive format %q", format)
+}
+
+func extractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("reading tar archive: %w", err)
+		}
+		if err := pkgarchive.ExtractEntry(dest, hdr.Name, hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func extractZip(r io.ReaderAt, size int64, dest string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return xerrors.Errorf("reading zip archive: %w", err)
+	}
+	for _, f := range zr.File {
+		// Zip entries are extracted as the tar entries of the same type, links
+		// excepted, which are not portably held in zip archives.
+		hdr := &tar.Header{Name: f.Name, Mode: int64(f.Mode().Perm()), Typeflag: tar.TypeReg}
+		if f.FileInfo().IsDir() {
+			hdr.Typeflag = tar.TypeDir
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return xerrors.Errorf("extracting %s: %w", f.Name, err)
+		}
+		err = pkgarchive.ExtractEntry(dest, f.Name, hdr, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(p string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// walk calls fn for every directory, regular file and symlink under src with
+// its slash separated path relative to src.
+func walk(src string, fn func(p, rel string, info os.FileInfo) error) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == src {
+			return nil
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() && info.Mode()&os.ModeSymlink == 0 {
+			return nil
+		}
+		return fn(p, filepath.ToSlash(rel), info)
+	})
+}
+
+func archiveTar(src string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := walk(src, func(p, rel string, info os.FileInfo) error {
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			var err error
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(tw, p)
+	})
+	if err != nil {
+		return xerrors.Errorf("creating tar archive of %s: %w", src, err)
+	}
+	return tw.Close()
+}
+
+func archiveZip(src string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	err := walk(src, func(p, rel string, info os.FileInfo) error {
+		// Zip archives do not portably hold symlinks, so they are skipped.
+		if info.Mode()&os.ModeSymlink != 0 {
+			return nil
+		}
+		hdr, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		if info.IsDir() {
+			hdr.Name += "/"
+		} else {
+			hdr.Method = zip.Deflate
+		}
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return copyFile(fw, p)
+	})
+	if err != nil {
+		return xerrors.Errorf("creating zip archive of %s: %w", src, err)
+	}
+	return zw.Close()
+}
+
+func copyFile(w io.Writer, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpresource
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDetectFormat(t *testing.T) {
+	for url, want := range map[string]Format{
+		"https://example.com/release.tar.gz":         FormatTarGz,
+		"https://example.com/release.TGZ":            FormatTarGz,
+		"https://example.com/release.tar":            FormatTar,
+		"https://example.com/release.zip?token=abcd": FormatZip,
+		"https://example.com/tool.sh":                FormatFile,
+		"https://example.com/":                       FormatFile,
+	} {
+		if got := DetectFormat(url); got != want {
+			t.Errorf("DetectFormat(%q) = %q, expected %q", url, got, want)
+		}
+	}
+}
+
+// readTree returns the content of the regular files under dir by their
+// relative path.
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		files[filepath.ToSlash(rel)] = string(b)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestUploadDownloadArchive(t *testing.T) {
+	want := map[string]string{"a.txt": "a", "sub/b.txt": "b", "sub/deeper/c.txt": "c"}
+	for _, format := range []Format{FormatTar, FormatTarGz, FormatZip} {
+		t.Run(string(format), func(t *testing.T) {
+			_, server := newFakeServer(t)
+			defer server.Close()
+			src := tempDir(t)
+			defer os.RemoveAll(src)
+			for name, content := range want {
+				p := filepath.Join(src, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			url := server.URL + "/archive." + string(format)
+			if err := Upload(server.Client(), Spec{URL: url, Format: format, Path: src}); err != nil {
+				t.Fatalf("Upload() = %v", err)
+			}
+
+			dest := tempDir(t)
+			defer os.RemoveAll(dest)
+			if err := Download(server.Client(), Spec{URL: url, Format: format, Path: dest}); err != nil {
+				t.Fatalf("Download() = %v", err)
+			}
+			if d := cmp.Diff(want, readTree(t, dest)); d != "" {
+				t.Errorf("Diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestDownloadArchive_Escape(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		hdrs []tar.Header
+	}{{
+		desc: "parent directory",
+		hdrs: []tar.Header{{Name: "../evil.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 4}},
+	}, {
+		desc: "absolute symlink",
+		hdrs: []tar.Header{{Name: "etc", Typeflag: tar.TypeSymlink, Linkname: "/etc"}},
+	}, {
+		desc: "relative symlink",
+		hdrs: []tar.Header{{Name: "sub/up", Typeflag: tar.TypeSymlink, Linkname: "../.."}},
+	}, {
+		desc: "symlink chain",
+		hdrs: []tar.Header{
+			{Name: "x/y/l", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+			{Name: "m", Typeflag: tar.TypeSymlink, Linkname: "x/y/l/.."},
+			{Name: "m/evil.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 4},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			f, server := newFakeServer(t)
+			defer server.Close()
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			for i := range c.hdrs {
+				if err := tw.WriteHeader(&c.hdrs[i]); err != nil {
+					t.Fatal(err)
+				}
+				if c.hdrs[i].Size > 0 {
+					tw.Write([]byte("evil"))
+				}
+			}
+			tw.Close()
+			f.files["/evil.tar"] = buf.Bytes()
+
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+			dest := filepath.Join(dir, "dest")
+			if err := Download(server.Client(), Spec{URL: server.URL + "/evil.tar", Format: FormatTar, Path: dest}); err == nil {
+				t.Error("Expected an error extracting an entry outside of the destination")
+			}
+			if _, err := os.Stat(filepath.Join(dir, "evil.txt")); !os.IsNotExist(err) {
+				t.Errorf("Expected nothing to be written outside of the destination, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpresource downloads the content of a URL into a directory,
+// verifying its checksum and unpacking it if it is an archive, and uploads a
+// directory to a URL with a HTTP PUT.
+package httpresource
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// AuthorizationEnvVar is the environment variable holding the value of the
+// Authorization header sent with the requests, if any.
+const AuthorizationEnvVar = "AUTHORIZATION"
+
+// Checksum is the expected digest of the content of a URL.
+type Checksum struct {
+	Algorithm string
+	Digest    []byte
+}
+
+// ParseChecksum parses a checksum of the form <algorithm>:<hex digest>, where
+// algorithm is sha256 or sha512. The empty string is no checksum.
+func ParseChecksum(s string) (*Checksum, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return nil, xerrors.Errorf("checksum %q is not of the form <algorithm>:<digest>", s)
+	}
+	c := &Checksum{Algorithm: parts[0]}
+	h, err := c.newHash()
+	if err != nil {
+		return nil, err
+	}
+	if c.Digest, err = hex.DecodeString(parts[1]); err != nil || len(c.Digest) != h.Size() {
+		return nil, xerrors.Errorf("checksum %q does not hold a hex encoded %s digest", s, c.Algorithm)
+	}
+	return c, nil
+}
+
+func (c *Checksum) newHash() (hash.Hash, error) {
+	switch c.Algorithm {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	}
+	return nil, xerrors.Errorf("unsupported checksum algorithm %q, expected sha256 or sha512", c.Algorithm)
+}
+
+// Spec describes the content of a URL and the directory it is transferred
+// from or to.
+type Spec struct {
+	URL    string
+	Format Format
+	// Checksum is verified before the content of the URL is used.
+	Checksum *Checksum
+	// Path is the directory holding the content. A FormatFile content is
+	// the file of Path named after the last element of the URL path.
+	Path string
+	// Authorization is the value of the Authorization header, if not empty.
+	Authorization string
+}
+
+// Download fetches the content of the URL into the directory of the spec.
+func Download(client *http.Client, spec Spec) error {
+	req, err := spec.newRequest(http.MethodGet, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("fetching %s: %w", spec.URL, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+
+	// The content is buffered in a file so that the checksum is verified
+	// before anything is written to the directory.
+	tmp, err := ioutil.TempFile("", "http-resource")
+	if err != nil {
+		return xerrors.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	var w io.Writer = tmp
+	var h hash.Hash
+	if spec.Checksum != nil {
+		if h, err = spec.Checksum.newHash(); err != nil {
+			return err
+		}
+		w = io.MultiWriter(tmp, h)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return xerrors.Errorf("fetching %s: %w", spec.URL, err)
+	}
+	if h != nil {
+		if got := h.Sum(nil); !bytes.Equal(got, spec.Checksum.Digest) {
+			return xerrors.Errorf("%s digest of %s is %x, expected %x", spec.Checksum.Algorithm, spec.URL, got, spec.Checksum.Digest)
+		}
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(spec.Path, 0755); err != nil {
+		return xerrors.Errorf("creating directory %s: %w", spec.Path, err)
+	}
+	if spec.Format == FormatFile {
+		return writeFile(filepath.Join(spec.Path, path.Base(urlPath(spec.URL))), tmp, 0644)
+	}
+	return extract(tmp, spec.Format, spec.Path)
+}
+
+// Upload stores the content of the directory of the spec at the URL with a
+// PUT request, packing it first if the format is an archive.
+func Upload(client *http.Client, spec Spec) error {
+	var body *os.File
+	if spec.Format == FormatFile {
+		f, err := os.Open(filepath.Join(spec.Path, path.Base(urlPath(spec.URL))))
+		if err != nil {
+			return xerrors.Errorf("opening file to upload: %w", err)
+		}
+		defer f.Close()
+		body = f
+	} else {
+		tmp, err := ioutil.TempFile("", "http-resource")
+		if err != nil {
+			return xerrors.Errorf("creating temporary file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+		if err := archive(spec.Path, spec.Format, tmp); err != nil {
+			return err
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		body = tmp
+	}
+	info, err := body.Stat()
+	if err != nil {
+		return err
+	}
+
+	req, err := spec.newRequest(http.MethodPut, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	resp, err := client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("uploading to %s: %w", spec.URL, err)
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
+
+func (s Spec) newRequest(method string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, s.URL, body)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid URL %q: %w", s.URL, err)
+	}
+	if s.Authorization != "" {
+		req.Header.Set("Authorization", s.Authorization)
+	}
+	return req, nil
+}
+
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return xerrors.Errorf("%s %s: unexpected status %s: %s", resp.Request.Method, resp.Request.URL, resp.Status, strings.TrimSpace(string(b)))
+}
+
+// urlPath returns the path of rawURL, or rawURL itself if it does not parse.
+func urlPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Path
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpresource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeServer stores the bodies PUT to it and serves them back on GET.
+type fakeServer struct {
+	sync.Mutex
+	files map[string][]byte
+	// authorization is required in the requests if not empty.
+	authorization string
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if f.authorization != "" && r.Header.Get("Authorization") != f.authorization {
+		http.Error(w, "denied", http.StatusUnauthorized)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		b, _ := ioutil.ReadAll(r.Body)
+		f.files[r.URL.Path] = b
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		b, ok := f.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newFakeServer(t *testing.T) (*fakeServer, *httptest.Server) {
+	t.Helper()
+	f := &fakeServer{files: map[string][]byte{}}
+	return f, httptest.NewServer(f)
+}
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "http-resource-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func sha256Checksum(s string) *Checksum {
+	sum := sha256.Sum256([]byte(s))
+	return &Checksum{Algorithm: "sha256", Digest: sum[:]}
+}
+
+func TestParseChecksum(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+	got, err := ParseChecksum("sha256:" + hex.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatalf("ParseChecksum() = %v", err)
+	}
+	if d := cmp.Diff(&Checksum{Algorithm: "sha256", Digest: sum[:]}, got); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+	if got, err := ParseChecksum(""); got != nil || err != nil {
+		t.Errorf("ParseChecksum(\"\") = %v, %v, expected no checksum", got, err)
+	}
+}
+
+func TestParseChecksum_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		"md5:5d41402abc4b2a76b9719d911017c592",
+		"sha256:not-hex",
+		"sha512:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+	} {
+		if _, err := ParseChecksum(s); err == nil {
+			t.Errorf("ParseChecksum(%q) expected an error", s)
+		}
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	f, server := newFakeServer(t)
+	defer server.Close()
+	f.files["/releases/tool.sh"] = []byte("echo hello")
+	f.authorization = "Bearer token"
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	err := Download(server.Client(), Spec{
+		URL:           server.URL + "/releases/tool.sh",
+		Format:        FormatFile,
+		Checksum:      sha256Checksum("echo hello"),
+		Path:          dir,
+		Authorization: "Bearer token",
+	})
+	if err != nil {
+		t.Fatalf("Download() = %v", err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "tool.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "echo hello" {
+		t.Errorf("Expected the downloaded file to hold %q, got %q", "echo hello", b)
+	}
+}
+
+func TestDownloadFile_ChecksumMismatch(t *testing.T) {
+	f, server := newFakeServer(t)
+	defer server.Close()
+	f.files["/tool.sh"] = []byte("rm -rf /")
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	err := Download(server.Client(), Spec{
+		URL:      server.URL + "/tool.sh",
+		Format:   FormatFile,
+		Checksum: sha256Checksum("echo hello"),
+		Path:     dir,
+	})
+	if err == nil || !strings.Contains(err.Error(), "digest") {
+		t.Fatalf("Expected a digest mismatch, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tool.sh")); !os.IsNotExist(err) {
+		t.Errorf("Expected no file to be written on a digest mismatch, got %v", err)
+	}
+}
+
+func TestDownload_Status(t *testing.T) {
+	_, server := newFakeServer(t)
+	defer server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	err := Download(server.Client(), Spec{URL: server.URL + "/missing", Format: FormatFile, Path: dir})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	f, server := newFakeServer(t)
+	defer server.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "report.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Upload(server.Client(), Spec{URL: server.URL + "/out/report.json", Format: FormatFile, Path: dir}); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	if got := string(f.files["/out/report.json"]); got != "{}" {
+		t.Errorf("Expected the uploaded file to hold %q, got %q", "{}", got)
+	}
+}
+
+func TestUpload_Status(t *testing.T) {
+	f, server := newFakeServer(t)
+	defer server.Close()
+	f.authorization = "Bearer token"
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "report.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Upload(server.Client(), Spec{URL: server.URL + "/report.json", Format: FormatFile, Path: dir})
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("Expected an unauthorized error, got %v", err)
+	}
+}
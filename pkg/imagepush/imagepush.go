@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepush
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"golang.org/x/xerrors"
+)
+
+// Push verifies the OCI image layout at path and pushes it to url with the
+// credentials found in keychain for its registry, returning the digest of
+// what was pushed.
+//
+// A layout holding a single image is pushed as that image, so that the
+// digest is the one of its manifest, and a layout holding several images or
+// indexes is pushed as an index.
+func Push(path, url string, keychain authn.Keychain) (v1.Hash, error) {
+	ref, err := name.ParseReference(url, name.WeakValidation)
+	if err != nil {
+		return v1.Hash{}, xerrors.Errorf("invalid image reference %q: %w", url, err)
+	}
+	img, ii, err := load(path)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	auth, err := keychain.Resolve(ref.Context().Registry)
+	if err != nil {
+		return v1.Hash{}, xerrors.Errorf("failed to get the credentials of %s: %w", ref.Context().Registry, err)
+	}
+	if img != nil {
+		if err := remote.Write(ref, img, auth, http.DefaultTransport); err != nil {
+			return v1.Hash{}, xerrors.Errorf("failed to push %s: %w", ref, err)
+		}
+		return img.Digest()
+	}
+	if err := remote.WriteIndex(ref, ii, auth, http.DefaultTransport); err != nil {
+		return v1.Hash{}, xerrors.Errorf("failed to push %s: %w", ref, err)
+	}
+	return ii.Digest()
+}
+
+// load reads the layout at path, returning either its only image or the
+// index of its images, after checking every blob they reference exists and
+// matches its digest.
+func load(path string) (v1.Image, v1.ImageIndex, error) {
+	ii, err := layout.ImageIndexFromPath(path)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to read the OCI image layout at %s: %w", path, err)
+	}
+	im, err := ii.IndexManifest()
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to read the index of the OCI image layout at %s: %w", path, err)
+	}
+	if len(im.Manifests) == 0 {
+		return nil, nil, xerrors.Errorf("the OCI image layout at %s contains no image", path)
+	}
+	if len(im.Manifests) == 1 && isImage(im.Manifests[0].MediaType) {
+		img, err := ii.Image(im.Manifests[0].Digest)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("failed to read image %s: %w", im.Manifests[0].Digest, err)
+		}
+		if err := verifyImage(img, im.Manifests[0].Digest); err != nil {
+			return nil, nil, err
+		}
+		return img, nil, nil
+	}
+	if err := verifyIndex(ii); err != nil {
+		return nil, nil, err
+	}
+	return nil, ii, nil
+}
+
+func isImage(mt types.MediaType) bool {
+	return mt == types.OCIManifestSchema1 || mt == types.DockerManifestSchema2
+}
+
+func isIndex(mt types.MediaType) bool {
+	return mt == types.OCIImageIndex || mt == types.DockerManifestList
+}
+
+func verifyIndex(ii v1.ImageIndex) error {
+	im, err := ii.IndexManifest()
+	if err != nil {
+		return xerrors.Errorf("failed to read index: %w", err)
+	}
+	for _, desc := range im.Manifests {
+		switch {
+		case isImage(desc.MediaType):
+			img, err := ii.Image(desc.Digest)
+			if err != nil {
+				return xerrors.Errorf("failed to read image %s: %w", desc.Digest, err)
+			}
+			if err := verifyImage(img, desc.Digest); err != nil {
+				return err
+			}
+		case isIndex(desc.MediaType):
+			child, err := ii.ImageIndex(desc.Digest)
+			if err != nil {
+				return xerrors.Errorf("failed to read index %s: %w", desc.Digest, err)
+			}
+			raw, err := child.RawManifest()
+			if err != nil {
+				return xerrors.Errorf("failed to read index %s: %w", desc.Digest, err)
+			}
+			if err := verifyDigest("index", desc.Digest, bytes.NewReader(raw)); err != nil {
+				return err
+			}
+			if err := verifyIndex(child); err != nil {
+				return err
+			}
+		default:
+			return xerrors.Errorf("unsupported media type %q of %s", desc.MediaType, desc.Digest)
+		}
+	}
+	return nil
+}
+
+func verifyImage(img v1.Image, digest v1.Hash) error {
+	raw, err := img.RawManifest()
+	if err != nil {
+		return xerrors.Errorf("failed to read the manifest of image %s: %w", digest, err)
+	}
+	if err := verifyDigest("manifest", digest, bytes.NewReader(raw)); err != nil {
+		return err
+	}
+	configName, err := img.ConfigName()
+	if err != nil {
+		return xerrors.Errorf("failed to read the config of image %s: %w", digest, err)
+	}
+	config, err := img.RawConfigFile()
+	if err != nil {
+		return xerrors.Errorf("failed to read the config of image %s: %w", digest, err)
+	}
+	if err := verifyDigest("config", configName, bytes.NewReader(config)); err != nil {
+		return err
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return xerrors.Errorf("failed to read the layers of image %s: %w", digest, err)
+	}
+	for _, l := range layers {
+		h, err := l.Digest()
+		if err != nil {
+			return xerrors.Errorf("failed to read a layer of image %s: %w", digest, err)
+		}
+		rc, err := l.Compressed()
+		if err != nil {
+			return xerrors.Errorf("failed to read layer %s: %w", h, err)
+		}
+		err = verifyDigest("layer", h, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyDigest(kind string, want v1.Hash, r io.Reader) error {
+	got, _, err := v1.SHA256(r)
+	if err != nil {
+		return xerrors.Errorf("failed to read %s %s: %w", kind, want, err)
+	}
+	if got != want {
+		return xerrors.Errorf("%s %s does not match its content, whose digest is %s", kind, want, got)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepush
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// writeBlob writes content to the blobs of the layout at dir and returns its
+// descriptor.
+func writeBlob(t *testing.T, dir string, mt types.MediaType, content []byte) v1.Descriptor {
+	t.Helper()
+	sum := sha256.Sum256(content)
+	h := v1.Hash{Algorithm: "sha256", Hex: hex.EncodeToString(sum[:])}
+	if err := os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "blobs", "sha256", h.Hex), content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return v1.Descriptor{MediaType: mt, Size: int64(len(content)), Digest: h}
+}
+
+func writeJSONBlob(t *testing.T, dir string, mt types.MediaType, v interface{}) v1.Descriptor {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writeBlob(t, dir, mt, b)
+}
+
+// writeImage writes an image with a single layer to the blobs of the layout
+// at dir and returns the descriptors of its manifest and of its layer.
+func writeImage(t *testing.T, dir, content string) (v1.Descriptor, v1.Descriptor) {
+	t.Helper()
+	var layer bytes.Buffer
+	zw := gzip.NewWriter(&layer)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(content))
+	config := writeJSONBlob(t, dir, types.OCIConfigJSON, v1.ConfigFile{
+		Architecture: "amd64",
+		OS:           "linux",
+		RootFS:       v1.RootFS{Type: "layers", DiffIDs: []v1.Hash{{Algorithm: "sha256", Hex: hex.EncodeToString(sum[:])}}},
+	})
+	layerDesc := writeBlob(t, dir, types.OCILayer, layer.Bytes())
+	manifest := writeJSONBlob(t, dir, types.OCIManifestSchema1, v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		Config:        config,
+		Layers:        []v1.Descriptor{layerDesc},
+	})
+	return manifest, layerDesc
+}
+
+func writeIndex(t *testing.T, dir string, manifests ...v1.Descriptor) {
+	t.Helper()
+	b, err := json.Marshal(v1.IndexManifest{SchemaVersion: 2, Manifests: manifests})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "index.json"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion": "1.0.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "imagepush")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoad_SingleImage(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	manifest, _ := writeImage(t, dir, "layer")
+	writeIndex(t, dir, manifest)
+
+	img, ii, err := load(dir)
+	if err != nil {
+		t.Fatalf("load() = %v", err)
+	}
+	if ii != nil || img == nil {
+		t.Fatalf("Expected the only image of the layout to be loaded, got image %v and index %v", img, ii)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != manifest.Digest {
+		t.Errorf("Expected the image to have digest %s but got %s", manifest.Digest, digest)
+	}
+}
+
+func TestLoad_SeveralImages(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	amd64, _ := writeImage(t, dir, "amd64")
+	arm64, _ := writeImage(t, dir, "arm64")
+	writeIndex(t, dir, amd64, arm64)
+
+	img, ii, err := load(dir)
+	if err != nil {
+		t.Fatalf("load() = %v", err)
+	}
+	if img != nil || ii == nil {
+		t.Fatalf("Expected the index of the layout to be loaded, got image %v and index %v", img, ii)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// setup writes the layout to dir
+		setup func(t *testing.T, dir string)
+		want  string
+	}{{
+		name:  "no layout",
+		setup: func(t *testing.T, dir string) {},
+		want:  "failed to read the OCI image layout",
+	}, {
+		name: "no image",
+		setup: func(t *testing.T, dir string) {
+			writeIndex(t, dir)
+		},
+		want: "contains no image",
+	}, {
+		name: "corrupted layer",
+		setup: func(t *testing.T, dir string) {
+			manifest, layer := writeImage(t, dir, "layer")
+			writeIndex(t, dir, manifest)
+			if err := ioutil.WriteFile(filepath.Join(dir, "blobs", "sha256", layer.Digest.Hex), []byte("tampered"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		},
+		want: "does not match its content",
+	}, {
+		name: "missing layer",
+		setup: func(t *testing.T, dir string) {
+			manifest, layer := writeImage(t, dir, "layer")
+			writeIndex(t, dir, manifest)
+			if err := os.Remove(filepath.Join(dir, "blobs", "sha256", layer.Digest.Hex)); err != nil {
+				t.Fatal(err)
+			}
+		},
+		want: "failed to read layer",
+	}, {
+		name: "corrupted image of an index",
+		setup: func(t *testing.T, dir string) {
+			amd64, _ := writeImage(t, dir, "amd64")
+			arm64, _ := writeImage(t, dir, "arm64")
+			writeIndex(t, dir, amd64, arm64)
+			if err := ioutil.WriteFile(filepath.Join(dir, "blobs", "sha256", amd64.Digest.Hex), []byte(`{"schemaVersion": 2}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+		},
+		want: "does not match its content",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+			tc.setup(t, dir)
+
+			_, _, err := load(dir)
+			if err == nil {
+				t.Fatal("Expected an error loading an invalid layout")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Expected error %q to contain %q", err, tc.want)
+			}
+		})
+	}
+}
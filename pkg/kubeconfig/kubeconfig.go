@@ -0,0 +1,79 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Build returns the kubeconfig giving access to the cluster of resource.
+//
+// The cluster, the user and the current context are all named after the
+// resource, and every additional context of the resource refers to the same
+// cluster and user.
+func Build(resource *v1alpha1.ClusterResource) *clientcmdapi.Config {
+	cluster := &clientcmdapi.Cluster{
+		Server:                   resource.URL,
+		InsecureSkipTLSVerify:    resource.Insecure,
+		CertificateAuthorityData: resource.CAData,
+	}
+	//only one authentication technique per user is allowed in a kubeconfig, so clear out the password if a token is provided
+	user := resource.Username
+	pass := resource.Password
+	if resource.Token != "" {
+		user = ""
+		pass = ""
+	}
+	auth := &clientcmdapi.AuthInfo{
+		Token:                 resource.Token,
+		Username:              user,
+		Password:              pass,
+		ClientCertificateData: resource.ClientCertificateData,
+		ClientKeyData:         resource.ClientKeyData,
+	}
+	if e := resource.Exec; e != nil {
+		auth.Exec = &clientcmdapi.ExecConfig{
+			Command:    e.Command,
+			Args:       e.Args,
+			APIVersion: e.APIVersion,
+		}
+		for _, env := range e.Env {
+			auth.Exec.Env = append(auth.Exec.Env, clientcmdapi.ExecEnvVar{Name: env.Name, Value: env.Value})
+		}
+	}
+
+	c := clientcmdapi.NewConfig()
+	c.Clusters[resource.Name] = cluster
+	c.AuthInfos[resource.Name] = auth
+	c.Contexts[resource.Name] = &clientcmdapi.Context{
+		Cluster:   resource.Name,
+		AuthInfo:  resource.Name,
+		Namespace: resource.Namespace,
+	}
+	for _, context := range resource.Contexts {
+		c.Contexts[context.Name] = &clientcmdapi.Context{
+			Cluster:   resource.Name,
+			AuthInfo:  resource.Name,
+			Namespace: context.Namespace,
+		}
+	}
+	c.CurrentContext = resource.Name
+	c.APIVersion = "v1"
+	c.Kind = "Config"
+	return c
+}
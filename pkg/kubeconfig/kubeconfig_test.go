@@ -0,0 +1,132 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestBuild(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		resource *v1alpha1.ClusterResource
+		wantAuth *clientcmdapi.AuthInfo
+		// wantContexts maps the name of the contexts to their namespace.
+		wantContexts map[string]string
+	}{{
+		name: "token",
+		resource: &v1alpha1.ClusterResource{
+			Name:     "prod",
+			URL:      "https://10.10.10.10",
+			CAData:   []byte("ca"),
+			Username: "admin",
+			Password: "pass",
+			Token:    "my-token",
+		},
+		wantAuth:     &clientcmdapi.AuthInfo{Token: "my-token"},
+		wantContexts: map[string]string{"prod": ""},
+	}, {
+		name: "basic auth",
+		resource: &v1alpha1.ClusterResource{
+			Name:     "prod",
+			URL:      "https://10.10.10.10",
+			CAData:   []byte("ca"),
+			Username: "admin",
+			Password: "pass",
+		},
+		wantAuth:     &clientcmdapi.AuthInfo{Username: "admin", Password: "pass"},
+		wantContexts: map[string]string{"prod": ""},
+	}, {
+		name: "client certificate in namespace",
+		resource: &v1alpha1.ClusterResource{
+			Name:                  "prod",
+			URL:                   "https://10.10.10.10",
+			CAData:                []byte("ca"),
+			Namespace:             "deploy",
+			ClientCertificateData: []byte("cert"),
+			ClientKeyData:         []byte("key"),
+		},
+		wantAuth:     &clientcmdapi.AuthInfo{ClientCertificateData: []byte("cert"), ClientKeyData: []byte("key")},
+		wantContexts: map[string]string{"prod": "deploy"},
+	}, {
+		name: "credential plugin with several contexts",
+		resource: &v1alpha1.ClusterResource{
+			Name:      "prod",
+			URL:       "https://10.10.10.10",
+			CAData:    []byte("ca"),
+			Namespace: "deploy",
+			Exec: &v1alpha1.ClusterExecConfig{
+				Command:    "aws-iam-authenticator",
+				Args:       []string{"token", "-i", "prod"},
+				Env:        []v1alpha1.ClusterExecEnvVar{{Name: "AWS_PROFILE", Value: "ci"}},
+				APIVersion: "client.authentication.k8s.io/v1beta1",
+			},
+			Contexts: []v1alpha1.ClusterContext{{
+				Name:      "monitoring",
+				Namespace: "prometheus",
+			}, {
+				Name:      "ingress",
+				Namespace: "ingress-nginx",
+			}},
+		},
+		wantAuth: &clientcmdapi.AuthInfo{
+			Exec: &clientcmdapi.ExecConfig{
+				Command:    "aws-iam-authenticator",
+				Args:       []string{"token", "-i", "prod"},
+				Env:        []clientcmdapi.ExecEnvVar{{Name: "AWS_PROFILE", Value: "ci"}},
+				APIVersion: "client.authentication.k8s.io/v1beta1",
+			},
+		},
+		wantContexts: map[string]string{"prod": "deploy", "monitoring": "prometheus", "ingress": "ingress-nginx"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Build(tc.resource)
+
+			if c.CurrentContext != tc.resource.Name {
+				t.Errorf("Expected the current context to be %q but got %q", tc.resource.Name, c.CurrentContext)
+			}
+			cluster, ok := c.Clusters[tc.resource.Name]
+			if !ok || len(c.Clusters) != 1 {
+				t.Fatalf("Expected a single cluster named %q, got %v", tc.resource.Name, c.Clusters)
+			}
+			if cluster.Server != tc.resource.URL || string(cluster.CertificateAuthorityData) != string(tc.resource.CAData) {
+				t.Errorf("Unexpected cluster %v", cluster)
+			}
+			auth, ok := c.AuthInfos[tc.resource.Name]
+			if !ok || len(c.AuthInfos) != 1 {
+				t.Fatalf("Expected a single user named %q, got %v", tc.resource.Name, c.AuthInfos)
+			}
+			if d := cmp.Diff(tc.wantAuth, auth); d != "" {
+				t.Errorf("AuthInfo diff -want, +got: %s", d)
+			}
+			gotContexts := map[string]string{}
+			for name, context := range c.Contexts {
+				if context.Cluster != tc.resource.Name || context.AuthInfo != tc.resource.Name {
+					t.Errorf("Expected context %q to refer to cluster and user %q, got %v", name, tc.resource.Name, context)
+				}
+				gotContexts[name] = context.Namespace
+			}
+			if d := cmp.Diff(tc.wantContexts, gotContexts); d != "" {
+				t.Errorf("Contexts diff -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ConfigName is the name of the ConfigMap configuring when completed runs
+	// are deleted.
+	ConfigName = "config-pruner"
+
+	// TTLKey is the name of the configmap entry that specifies how long
+	// completed runs are kept, as a Go duration such as 168h. Runs are kept
+	// regardless of their age if unset.
+	TTLKey = "ttl"
+
+	// KeepKey is the name of the configmap entry that specifies how many of
+	// the most recently completed runs of each Pipeline and Task are kept, at
+	// least one. Runs are kept regardless of their number if unset.
+	KeepKey = "keep"
+)
+
+// loadRunRetention loads the default retention of completed runs from the
+// pruner ConfigMap. It returns an empty retention if the ConfigMap doesn't
+// exist.
+func loadRunRetention(c kubernetes.Interface) (v1alpha1.RunRetention, error) {
+	r := v1alpha1.RunRetention{}
+	configMap, err := c.CoreV1().ConfigMaps(system.GetNamespace()).Get(ConfigName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return r, nil
+		}
+		return r, xerrors.Errorf("failed to get pruner ConfigMap %s: %w", ConfigName, err)
+	}
+	if ttl := strings.TrimSpace(configMap.Data[TTLKey]); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return r, xerrors.Errorf("invalid %s in config map %s: %w", TTLKey, ConfigName, err)
+		}
+		r.TTL = &metav1.Duration{Duration: d}
+	}
+	if keep := strings.TrimSpace(configMap.Data[KeepKey]); keep != "" {
+		n, err := strconv.ParseInt(keep, 10, 32)
+		if err != nil {
+			return r, xerrors.Errorf("invalid %s in config map %s: %w", KeepKey, ConfigName, err)
+		}
+		n32 := int32(n)
+		r.Keep = &n32
+	}
+	if err := r.Validate(context.Background(), "retention"); err != nil {
+		return r, xerrors.Errorf("invalid retention in config map %s: %w", ConfigName, err)
+	}
+	return r, nil
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"sort"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Pruner deletes the completed PipelineRuns and TaskRuns that outlived their
+// retention. Their TaskRuns and Pods are deleted by the garbage collector
+// through their owner references, after the artifact storage of the
+// PipelineRuns.
+type Pruner struct {
+	kubeclient        kubernetes.Interface
+	pipelineclient    clientset.Interface
+	pipelineRunLister listers.PipelineRunLister
+	taskRunLister     listers.TaskRunLister
+	pipelineLister    listers.PipelineLister
+	logger            *zap.SugaredLogger
+	now               func() time.Time
+}
+
+// NewPruner returns a Pruner of the runs listed by pipelineRunLister and
+// taskRunLister.
+func NewPruner(kubeclient kubernetes.Interface, pipelineclient clientset.Interface, pipelineRunLister listers.PipelineRunLister,
+	taskRunLister listers.TaskRunLister, pipelineLister listers.PipelineLister, logger *zap.SugaredLogger) *Pruner {
+	return &Pruner{
+		kubeclient:        kubeclient,
+		pipelineclient:    pipelineclient,
+		pipelineRunLister: pipelineRunLister,
+		taskRunLister:     taskRunLister,
+		pipelineLister:    pipelineLister,
+		logger:            logger,
+		now:               time.Now,
+	}
+}
+
+// Run prunes every period until stopCh is closed.
+func (p *Pruner) Run(period time.Duration, stopCh <-chan struct{}) {
+	wait.Until(p.Prune, period, stopCh)
+}
+
+// runGroup identifies the runs of a Pipeline or Task, whose most recently
+// completed ones are kept.
+type runGroup struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// Prune deletes the completed PipelineRuns, and the completed TaskRuns not
+// created by a PipelineRun, whose retention expired.
+func (p *Pruner) Prune() {
+	defaults, err := loadRunRetention(p.kubeclient)
+	if err != nil {
+		// The runs are still pruned by their own retention.
+		p.logger.Errorf("Failed to load the run retention configuration: %v", err)
+		defaults = v1alpha1.RunRetention{}
+	}
+	now := p.now()
+	p.prunePipelineRuns(defaults, now)
+	p.pruneTaskRuns(defaults, now)
+}
+
+func (p *Pruner) prunePipelineRuns(defaults v1alpha1.RunRetention, now time.Time) {
+	prs, err := p.pipelineRunLister.List(labels.Everything())
+	if err != nil {
+		p.logger.Errorf("Failed to list PipelineRuns: %v", err)
+		return
+	}
+	groups := map[runGroup][]*v1alpha1.PipelineRun{}
+	for _, pr := range prs {
+		if !pr.IsDone() {
+			continue
+		}
+		g := runGroup{namespace: pr.Namespace, name: pr.Spec.PipelineRef.Name}
+		groups[g] = append(groups[g], pr)
+	}
+
+	for g, prs := range groups {
+		r := defaults
+		pipeline, err := p.pipelineLister.Pipelines(g.namespace).Get(g.name)
+		switch {
+		case err == nil && pipeline.Spec.RunRetention != nil:
+			if pipeline.Spec.RunRetention.TTL != nil {
+				r.TTL = pipeline.Spec.RunRetention.TTL
+			}
+			if pipeline.Spec.RunRetention.Keep != nil {
+				r.Keep = pipeline.Spec.RunRetention.Keep
+			}
+		case err != nil && !errors.IsNotFound(err):
+			p.logger.Errorf("Failed to get Pipeline %s/%s: %v", g.namespace, g.name, err)
+			continue
+		}
+
+		sort.Slice(prs, func(i, j int) bool {
+			return completedBefore(pipelineRunCompletionTime(prs[j]), prs[j].Name, pipelineRunCompletionTime(prs[i]), prs[i].Name)
+		})
+		for i, pr := range prs {
+			ttl := r.TTL
+			if pr.Spec.TTL != nil {
+				ttl = pr.Spec.TTL
+			}
+			if !expired(i, pipelineRunCompletionTime(pr), ttl, r.Keep, now) {
+				continue
+			}
+			// Nothing deletes the prefix of the bucket of a PipelineRun once
+			// it is deleted, so it is deleted first.
+			deleted, err := artifacts.DeleteArtifactStorage(pr, p.kubeclient, p.logger)
+			if err != nil {
+				p.logger.Errorf("Failed to delete the artifact storage of PipelineRun %s/%s: %v", pr.Namespace, pr.Name, err)
+				continue
+			}
+			if !deleted {
+				// Deleted by a later prune, once its artifact storage is.
+				continue
+			}
+			err = p.pipelineclient.TektonV1alpha1().PipelineRuns(pr.Namespace).Delete(pr.Name, deleteOptions())
+			if err != nil && !errors.IsNotFound(err) {
+				p.logger.Errorf("Failed to delete PipelineRun %s/%s: %v", pr.Namespace, pr.Name, err)
+				continue
+			}
+			p.logger.Infof("Deleted completed PipelineRun %s/%s", pr.Namespace, pr.Name)
+		}
+	}
+}
+
+func (p *Pruner) pruneTaskRuns(defaults v1alpha1.RunRetention, now time.Time) {
+	trs, err := p.taskRunLister.List(labels.Everything())
+	if err != nil {
+		p.logger.Errorf("Failed to list TaskRuns: %v", err)
+		return
+	}
+	groups := map[runGroup][]*v1alpha1.TaskRun{}
+	for _, tr := range trs {
+		// TaskRuns created by a PipelineRun are deleted with it.
+		if !tr.IsDone() || metav1.GetControllerOf(tr) != nil {
+			continue
+		}
+		g := runGroup{namespace: tr.Namespace}
+		if tr.Spec.TaskRef != nil {
+			g.kind, g.name = string(tr.Spec.TaskRef.Kind), tr.Spec.TaskRef.Name
+			// A reference without a kind is to a namespaced Task.
+			if g.kind == "" {
+				g.kind = string(v1alpha1.NamespacedTaskKind)
+			}
+		}
+		groups[g] = append(groups[g], tr)
+	}
+
+	for g, trs := range groups {
+		keep := defaults.Keep
+		if g.name == "" {
+			// TaskRuns embedding their Task aren't runs of the same Task.
+			keep = nil
+		}
+		sort.Slice(trs, func(i, j int) bool {
+			return completedBefore(taskRunCompletionTime(trs[j]), trs[j].Name, taskRunCompletionTime(trs[i]), trs[i].Name)
+		})
+		for i, tr := range trs {
+			if !expired(i, taskRunCompletionTime(tr), defaults.TTL, keep, now) {
+				continue
+			}
+			err := p.pipelineclient.TektonV1alpha1().TaskRuns(tr.Namespace).Delete(tr.Name, deleteOptions())
+			if err != nil && !errors.IsNotFound(err) {
+				p.logger.Errorf("Failed to delete TaskRun %s/%s: %v", tr.Namespace, tr.Name, err)
+				continue
+			}
+			p.logger.Infof("Deleted completed TaskRun %s/%s", tr.Namespace, tr.Name)
+		}
+	}
+}
+
+// expired returns whether the run completed at completionTime, which is the
+// index-th most recently completed of its group, is to be deleted at now.
+func expired(index int, completionTime time.Time, ttl *metav1.Duration, keep *int32, now time.Time) bool {
+	if keep != nil && index >= int(*keep) {
+		return true
+	}
+	return ttl != nil && !completionTime.Add(ttl.Duration).After(now)
+}
+
+// completedBefore orders the runs by completion time, then by name for runs
+// completed at the same time.
+func completedBefore(t1 time.Time, name1 string, t2 time.Time, name2 string) bool {
+	if !t1.Equal(t2) {
+		return t1.Before(t2)
+	}
+	return name1 < name2
+}
+
+func pipelineRunCompletionTime(pr *v1alpha1.PipelineRun) time.Time {
+	if pr.Status.CompletionTime != nil {
+		return pr.Status.CompletionTime.Time
+	}
+	return pr.Status.GetCondition(apis.ConditionSucceeded).LastTransitionTime.Inner.Time
+}
+
+func taskRunCompletionTime(tr *v1alpha1.TaskRun) time.Time {
+	if tr.Status.CompletionTime != nil {
+		return tr.Status.CompletionTime.Time
+	}
+	return tr.Status.GetCondition(apis.ConditionSucceeded).LastTransitionTime.Inner.Time
+}
+
+// deleteOptions returns the options deleting a run along with the TaskRuns
+// and Pods it owns.
+func deleteOptions() *metav1.DeleteOptions {
+	propagationPolicy := metav1.DeletePropagationBackground
+	return &metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}
+}
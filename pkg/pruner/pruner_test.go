@@ -0,0 +1,234 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	logtesting "github.com/knative/pkg/logging/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
+	fakeclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/system"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+var now = time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func donePipelineRun(name, pipeline string, completed time.Duration, ops ...tb.PipelineRunSpecOp) *v1alpha1.PipelineRun {
+	return tb.PipelineRun(name, "foo",
+		tb.PipelineRunSpec(pipeline, ops...),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}),
+			tb.PipelineRunCompletionTime(now.Add(-completed)),
+		),
+	)
+}
+
+func doneTaskRun(name string, completed time.Duration, ops ...tb.TaskRunOp) *v1alpha1.TaskRun {
+	return tb.TaskRun(name, "foo", append(ops, tb.TaskRunStatus(tb.Condition(apis.Condition{
+		Type:               apis.ConditionSucceeded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: apis.VolatileTime{Inner: metav1.Time{Time: now.Add(-completed)}},
+	})))...)
+}
+
+func configMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: ConfigName},
+		Data:       data,
+	}
+}
+
+func TestPrune(t *testing.T) {
+	keepOne := int32(1)
+	running := tb.PipelineRun("running", "foo", tb.PipelineRunSpec("build"),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown})))
+	prs := []*v1alpha1.PipelineRun{
+		running,
+		// Pruned by the TTL of the cluster.
+		donePipelineRun("build-old", "build", 10*24*time.Hour),
+		donePipelineRun("build-recent", "build", time.Hour),
+		donePipelineRun("build-own-ttl", "build", 2*time.Hour, tb.PipelineRunTTL(&metav1.Duration{Duration: time.Hour})),
+		// Pruned by the retention of the Pipeline.
+		donePipelineRun("deploy-newest", "deploy", time.Minute),
+		donePipelineRun("deploy-newer", "deploy", time.Hour),
+		donePipelineRun("deploy-oldest", "deploy", 2*time.Hour),
+	}
+	pipelines := []*v1alpha1.Pipeline{
+		tb.Pipeline("deploy", "foo", tb.PipelineSpec(
+			tb.PipelineTask("deploy", "deploy"),
+			tb.PipelineRunRetention(&v1alpha1.RunRetention{Keep: &keepOne}),
+		)),
+	}
+	trs := []*v1alpha1.TaskRun{
+		doneTaskRun("owned", 10*24*time.Hour, tb.TaskRunSpec(tb.TaskRunTaskRef("lint")),
+			tb.TaskRunOwnerReference("PipelineRun", "build-recent", tb.Controller)),
+		doneTaskRun("lint-newest", time.Minute, tb.TaskRunSpec(tb.TaskRunTaskRef("lint"))),
+		// Runs of the same Task, whether its kind is explicit or not.
+		doneTaskRun("lint-newer", time.Hour, tb.TaskRunSpec(tb.TaskRunTaskRef("lint", tb.TaskRefKind(v1alpha1.NamespacedTaskKind)))),
+		doneTaskRun("lint-oldest", 2*time.Hour, tb.TaskRunSpec(tb.TaskRunTaskRef("lint"))),
+		doneTaskRun("cluster-lint", 3*time.Hour, tb.TaskRunSpec(tb.TaskRunTaskRef("lint", tb.TaskRefKind(v1alpha1.ClusterTaskKind)))),
+		doneTaskRun("embedded-recent", 2*time.Hour, tb.TaskRunSpec(tb.TaskRunTaskSpec())),
+		doneTaskRun("embedded-old", 8*24*time.Hour, tb.TaskRunSpec(tb.TaskRunTaskSpec())),
+	}
+
+	prIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	trIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	pIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	var objs []runtime.Object
+	for _, pr := range prs {
+		if err := prIndexer.Add(pr); err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, pr)
+	}
+	for _, tr := range trs {
+		if err := trIndexer.Add(tr); err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, tr)
+	}
+	for _, p := range pipelines {
+		if err := pIndexer.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fakekubeclient := fakek8s.NewSimpleClientset(configMap(map[string]string{TTLKey: "168h", KeepKey: "2"}))
+	fakeclient := fakeclientset.NewSimpleClientset(objs...)
+
+	p := NewPruner(fakekubeclient, fakeclient, listers.NewPipelineRunLister(prIndexer), listers.NewTaskRunLister(trIndexer),
+		listers.NewPipelineLister(pIndexer), logtesting.TestLogger(t))
+	p.now = func() time.Time { return now }
+	p.Prune()
+
+	for name, deleted := range map[string]bool{
+		"running":       false,
+		"build-old":     true,
+		"build-recent":  false,
+		"build-own-ttl": true,
+		"deploy-newest": false,
+		"deploy-newer":  true,
+		"deploy-oldest": true,
+	} {
+		_, err := fakeclient.TektonV1alpha1().PipelineRuns("foo").Get(name, metav1.GetOptions{})
+		if deleted && !errors.IsNotFound(err) {
+			t.Errorf("Expected PipelineRun %s to be deleted but got %v", name, err)
+		} else if !deleted && err != nil {
+			t.Errorf("Expected PipelineRun %s to be kept but got %v", name, err)
+		}
+	}
+	for name, deleted := range map[string]bool{
+		"owned":           false,
+		"lint-newest":     false,
+		"lint-newer":      false,
+		"lint-oldest":     true,
+		"cluster-lint":    false,
+		"embedded-recent": false,
+		"embedded-old":    true,
+	} {
+		_, err := fakeclient.TektonV1alpha1().TaskRuns("foo").Get(name, metav1.GetOptions{})
+		if deleted && !errors.IsNotFound(err) {
+			t.Errorf("Expected TaskRun %s to be deleted but got %v", name, err)
+		} else if !deleted && err != nil {
+			t.Errorf("Expected TaskRun %s to be kept but got %v", name, err)
+		}
+	}
+}
+
+func TestPruneWithoutConfig(t *testing.T) {
+	pr := donePipelineRun("build-old", "build", 365*24*time.Hour)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(pr); err != nil {
+		t.Fatal(err)
+	}
+	fakeclient := fakeclientset.NewSimpleClientset(pr)
+	emptyIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	p := NewPruner(fakek8s.NewSimpleClientset(), fakeclient, listers.NewPipelineRunLister(indexer), listers.NewTaskRunLister(emptyIndexer),
+		listers.NewPipelineLister(emptyIndexer), logtesting.TestLogger(t))
+	p.now = func() time.Time { return now }
+	p.Prune()
+
+	if _, err := fakeclient.TektonV1alpha1().PipelineRuns("foo").Get(pr.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected PipelineRun %s to be kept but got %v", pr.Name, err)
+	}
+}
+
+func TestPruneDeletesBucketArtifactsFirst(t *testing.T) {
+	pr := donePipelineRun("build-old", "build", 10*24*time.Hour)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(pr); err != nil {
+		t.Fatal(err)
+	}
+	fakeclient := fakeclientset.NewSimpleClientset(pr)
+	fakekubeclient := fakek8s.NewSimpleClientset(configMap(map[string]string{TTLKey: "168h"}), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: v1alpha1.BucketConfigName},
+		Data: map[string]string{
+			v1alpha1.BucketLocationKey:              "gs://fake-bucket",
+			v1alpha1.BucketServiceAccountSecretName: "secret1",
+			v1alpha1.BucketServiceAccountSecretKey:  "sakey",
+		},
+	})
+	emptyIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	p := NewPruner(fakekubeclient, fakeclient, listers.NewPipelineRunLister(indexer), listers.NewTaskRunLister(emptyIndexer),
+		listers.NewPipelineLister(emptyIndexer), logtesting.TestLogger(t))
+	p.now = func() time.Time { return now }
+	p.Prune()
+
+	// The PipelineRun is kept until the Pod deleting its artifacts succeeds.
+	pod, err := fakekubeclient.CoreV1().Pods(pr.Namespace).Get(artifacts.GetBucketCleanupPodName(pr), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected a Pod deleting the artifacts but got %v", err)
+	}
+	if _, err := fakeclient.TektonV1alpha1().PipelineRuns("foo").Get(pr.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected PipelineRun %s to be kept until its artifacts are deleted but got %v", pr.Name, err)
+	}
+
+	pod.Status.Phase = corev1.PodSucceeded
+	if _, err := fakekubeclient.CoreV1().Pods(pr.Namespace).UpdateStatus(pod); err != nil {
+		t.Fatal(err)
+	}
+	p.Prune()
+	if _, err := fakeclient.TektonV1alpha1().PipelineRuns("foo").Get(pr.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected PipelineRun %s to be deleted but got %v", pr.Name, err)
+	}
+}
+
+func TestLoadRunRetentionInvalid(t *testing.T) {
+	for _, data := range []map[string]string{
+		{TTLKey: "a week"},
+		{TTLKey: "-1h"},
+		{KeepKey: "all"},
+		{KeepKey: "-1"},
+		{KeepKey: "0"},
+	} {
+		if _, err := loadRunRetention(fakek8s.NewSimpleClientset(configMap(data))); err == nil {
+			t.Errorf("Expected error loading run retention %v", data)
+		}
+	}
+}
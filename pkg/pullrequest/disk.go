@@ -0,0 +1,299 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// The layout of a pull request on disk, relative to its directory.
+const (
+	// PRFile holds the JSON form of the PullRequest.
+	PRFile = "pr.json"
+	// LabelsDir holds one empty file per label, named after the escaped label.
+	LabelsDir = "labels"
+	// CommentsDir holds one <id>.json file per Comment. Files without an ID,
+	// or that are not JSON, are new comments with the content of the file.
+	CommentsDir = "comments"
+	// DownloadedLabelsFile holds the downloaded labels, the only ones
+	// removed when their file is deleted.
+	DownloadedLabelsFile = "downloaded-labels.json"
+	// DownloadedCommentsFile holds the IDs of the downloaded comments, the
+	// only ones deleted when their file is removed.
+	DownloadedCommentsFile = "downloaded-comments.json"
+	// StatusDir holds one <id>.json file per Status, named after its escaped ID.
+	StatusDir = "status"
+)
+
+// Download writes the pull request of p to dir.
+func Download(ctx context.Context, p Provider, dir string) error {
+	pr, err := p.Get(ctx)
+	if err != nil {
+		return xerrors.Errorf("getting pull request: %w", err)
+	}
+	for _, d := range []string{LabelsDir, CommentsDir, StatusDir} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+			return xerrors.Errorf("creating %s: %w", d, err)
+		}
+	}
+	if err := writeJSON(filepath.Join(dir, PRFile), pr); err != nil {
+		return err
+	}
+	for _, l := range pr.Labels {
+		if err := ioutil.WriteFile(filepath.Join(dir, LabelsDir, url.PathEscape(l)), nil, 0644); err != nil {
+			return xerrors.Errorf("writing label %q: %w", l, err)
+		}
+	}
+	if err := writeJSON(filepath.Join(dir, DownloadedLabelsFile), append([]string{}, pr.Labels...)); err != nil {
+		return err
+	}
+	downloaded := []int64{}
+	for _, c := range pr.Comments {
+		if err := writeJSON(filepath.Join(dir, CommentsDir, fmt.Sprintf("%d.json", c.ID)), c); err != nil {
+			return err
+		}
+		downloaded = append(downloaded, c.ID)
+	}
+	if err := writeJSON(filepath.Join(dir, DownloadedCommentsFile), downloaded); err != nil {
+		return err
+	}
+	for _, s := range pr.Statuses {
+		if err := writeJSON(filepath.Join(dir, StatusDir, url.PathEscape(s.ID)+".json"), s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upload pushes the changes made in dir since Download back to p: labels are
+// added, new comments are created, the downloaded labels and comments whose
+// file was deleted are removed, and changed statuses are set on the head
+// commit. A missing labels, comments or status directory leaves that part of
+// the pull request untouched.
+func Upload(ctx context.Context, p Provider, dir string) error {
+	current, err := p.Get(ctx)
+	if err != nil {
+		return xerrors.Errorf("getting pull request: %w", err)
+	}
+	// Report statuses on the commit the Task actually ran against, which may
+	// not be the head of the pull request anymore.
+	sha := current.Head.SHA
+	if _, err := os.Stat(filepath.Join(dir, PRFile)); err == nil {
+		var local PullRequest
+		if err := readJSON(filepath.Join(dir, PRFile), &local); err != nil {
+			return err
+		}
+		if local.Head.SHA != "" {
+			sha = local.Head.SHA
+		}
+	}
+
+	if err := uploadLabels(ctx, p, current, dir); err != nil {
+		return err
+	}
+	if err := uploadComments(ctx, p, current, dir); err != nil {
+		return err
+	}
+	return uploadStatuses(ctx, p, current, sha, filepath.Join(dir, StatusDir))
+}
+
+// uploadLabels uploads the labels of the pull request in dir. Only the
+// labels listed in its DownloadedLabelsFile may be removed, so that labels
+// added since, or never downloaded, are left alone.
+func uploadLabels(ctx context.Context, p Provider, current *PullRequest, dir string) error {
+	files, err := readDir(filepath.Join(dir, LabelsDir))
+	if err != nil || files == nil {
+		return err
+	}
+	var downloaded []string
+	if _, err := os.Stat(filepath.Join(dir, DownloadedLabelsFile)); err == nil {
+		if err := readJSON(filepath.Join(dir, DownloadedLabelsFile), &downloaded); err != nil {
+			return err
+		}
+	}
+	want := map[string]bool{}
+	for _, f := range files {
+		l, err := url.PathUnescape(f.Name())
+		if err != nil {
+			return xerrors.Errorf("invalid label file %q: %w", f.Name(), err)
+		}
+		want[l] = true
+	}
+	removed := map[string]bool{}
+	for _, l := range downloaded {
+		removed[l] = !want[l]
+	}
+	for _, l := range current.Labels {
+		if want[l] {
+			delete(want, l)
+			continue
+		}
+		if !removed[l] {
+			continue
+		}
+		if err := p.RemoveLabel(ctx, l); err != nil {
+			return xerrors.Errorf("removing label %q: %w", l, err)
+		}
+	}
+	for _, l := range sortedKeys(want) {
+		if err := p.AddLabel(ctx, l); err != nil {
+			return xerrors.Errorf("adding label %q: %w", l, err)
+		}
+	}
+	return nil
+}
+
+// uploadComments uploads the comments of the pull request in dir. Only the
+// comments listed in its DownloadedCommentsFile may be deleted, so that
+// comments made since, or never downloaded, are left alone.
+func uploadComments(ctx context.Context, p Provider, current *PullRequest, dir string) error {
+	files, err := readDir(filepath.Join(dir, CommentsDir))
+	if err != nil || files == nil {
+		return err
+	}
+	var downloaded []int64
+	if _, err := os.Stat(filepath.Join(dir, DownloadedCommentsFile)); err == nil {
+		if err := readJSON(filepath.Join(dir, DownloadedCommentsFile), &downloaded); err != nil {
+			return err
+		}
+	}
+	deleted := map[int64]bool{}
+	for _, id := range downloaded {
+		deleted[id] = true
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, CommentsDir, f.Name())
+		c := &Comment{}
+		if strings.HasSuffix(f.Name(), ".json") {
+			if err := readJSON(path, c); err != nil {
+				return err
+			}
+		} else {
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return xerrors.Errorf("reading comment %s: %w", f.Name(), err)
+			}
+			c.Text = string(b)
+		}
+		if c.ID != 0 {
+			delete(deleted, c.ID)
+			continue
+		}
+		if err := p.CreateComment(ctx, c.Text); err != nil {
+			return xerrors.Errorf("creating comment from %s: %w", f.Name(), err)
+		}
+	}
+	for _, c := range current.Comments {
+		if !deleted[c.ID] {
+			continue
+		}
+		if err := p.DeleteComment(ctx, c.ID); err != nil {
+			return xerrors.Errorf("deleting comment %d: %w", c.ID, err)
+		}
+	}
+	return nil
+}
+
+func uploadStatuses(ctx context.Context, p Provider, current *PullRequest, sha, dir string) error {
+	files, err := readDir(dir)
+	if err != nil || files == nil {
+		return err
+	}
+	existing := map[string]Status{}
+	for _, s := range current.Statuses {
+		existing[s.ID] = *s
+	}
+	for _, f := range files {
+		s := &Status{}
+		if err := readJSON(filepath.Join(dir, f.Name()), s); err != nil {
+			return err
+		}
+		if s.ID == "" {
+			id, err := url.PathUnescape(strings.TrimSuffix(f.Name(), ".json"))
+			if err != nil {
+				return xerrors.Errorf("invalid status file %q: %w", f.Name(), err)
+			}
+			s.ID = id
+		}
+		if old, ok := existing[s.ID]; ok && old == *s {
+			continue
+		}
+		if err := p.SetStatus(ctx, sha, s); err != nil {
+			return xerrors.Errorf("setting status %q: %w", s.ID, err)
+		}
+	}
+	return nil
+}
+
+// readDir returns the regular files of dir, or nil if it does not exist.
+func readDir(dir string) ([]os.FileInfo, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("reading %s: %w", dir, err)
+	}
+	files := []os.FileInfo{}
+	for _, info := range infos {
+		if info.Mode().IsRegular() {
+			files = append(files, info)
+		}
+	}
+	return files, nil
+}
+
+func readJSON(path string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return xerrors.Errorf("reading %s: %w", path, err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return xerrors.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("encoding %s: %w", path, err)
+	}
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		return xerrors.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
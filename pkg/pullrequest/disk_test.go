@@ -0,0 +1,249 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeProvider keeps a pull request in memory.
+type fakeProvider struct {
+	pr *PullRequest
+	// statusSHAs records the commit each status was set on.
+	statusSHAs map[string]string
+	nextID     int64
+}
+
+func newFakeProvider() *fakeProvider {
+	return &fakeProvider{
+		pr: &PullRequest{
+			Type:   "fake",
+			ID:     42,
+			Title:  "Add feature",
+			Author: "octocat",
+			Head:   GitReference{Repo: "https://example.com/fork.git", Branch: "feature", SHA: "headsha"},
+			Base:   GitReference{Repo: "https://example.com/repo.git", Branch: "master", SHA: "basesha"},
+			Labels: []string{"kind/feature", "needs-ok-to-test"},
+			Comments: []*Comment{
+				{ID: 1, Author: "octocat", Text: "first"},
+				{ID: 2, Author: "bot", Text: "second"},
+			},
+			Statuses: []*Status{{ID: "ci/unit", Code: StatusPending}},
+		},
+		statusSHAs: map[string]string{},
+		nextID:     100,
+	}
+}
+
+func (f *fakeProvider) Get(ctx context.Context) (*PullRequest, error) {
+	pr := *f.pr
+	pr.Labels = append([]string(nil), f.pr.Labels...)
+	pr.Comments = append([]*Comment(nil), f.pr.Comments...)
+	pr.Statuses = append([]*Status(nil), f.pr.Statuses...)
+	return &pr, nil
+}
+
+func (f *fakeProvider) AddLabel(ctx context.Context, label string) error {
+	f.pr.Labels = append(f.pr.Labels, label)
+	sort.Strings(f.pr.Labels)
+	return nil
+}
+
+func (f *fakeProvider) RemoveLabel(ctx context.Context, label string) error {
+	var labels []string
+	for _, l := range f.pr.Labels {
+		if l != label {
+			labels = append(labels, l)
+		}
+	}
+	f.pr.Labels = labels
+	return nil
+}
+
+func (f *fakeProvider) CreateComment(ctx context.Context, text string) error {
+	f.pr.Comments = append(f.pr.Comments, &Comment{ID: f.nextID, Author: "tekton", Text: text})
+	f.nextID++
+	return nil
+}
+
+func (f *fakeProvider) DeleteComment(ctx context.Context, id int64) error {
+	var comments []*Comment
+	for _, c := range f.pr.Comments {
+		if c.ID != id {
+			comments = append(comments, c)
+		}
+	}
+	f.pr.Comments = comments
+	return nil
+}
+
+func (f *fakeProvider) SetStatus(ctx context.Context, sha string, status *Status) error {
+	var statuses []*Status
+	for _, s := range f.pr.Statuses {
+		if s.ID != status.ID {
+			statuses = append(statuses, s)
+		}
+	}
+	f.pr.Statuses = append(statuses, status)
+	f.statusSHAs[status.ID] = sha
+	return nil
+}
+
+func TestDownload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := Download(context.Background(), newFakeProvider(), dir); err != nil {
+		t.Fatalf("Download() = %v", err)
+	}
+
+	var pr PullRequest
+	if err := readJSON(filepath.Join(dir, PRFile), &pr); err != nil {
+		t.Fatal(err)
+	}
+	if pr.ID != 42 || pr.Head.SHA != "headsha" || pr.Base.Branch != "master" {
+		t.Errorf("Unexpected %s: %+v", PRFile, pr)
+	}
+	for _, f := range []string{
+		filepath.Join(LabelsDir, url.PathEscape("kind/feature")),
+		filepath.Join(LabelsDir, "needs-ok-to-test"),
+		filepath.Join(CommentsDir, "1.json"),
+		filepath.Join(CommentsDir, "2.json"),
+		filepath.Join(StatusDir, url.PathEscape("ci/unit")+".json"),
+		DownloadedLabelsFile,
+		DownloadedCommentsFile,
+	} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("Expected %s to be downloaded: %v", f, err)
+		}
+	}
+	var c Comment
+	if err := readJSON(filepath.Join(dir, CommentsDir, "2.json"), &c); err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(Comment{ID: 2, Author: "bot", Text: "second"}, c); d != "" {
+		t.Errorf("Unexpected comment: %s", d)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := newFakeProvider()
+	if err := Download(context.Background(), p, dir); err != nil {
+		t.Fatalf("Download() = %v", err)
+	}
+	// The pull request is updated while the Task runs.
+	p.pr.Head.SHA = "newsha"
+	p.pr.Comments = append(p.pr.Comments, &Comment{ID: 3, Author: "reviewer", Text: "meanwhile"})
+	p.pr.Labels = append(p.pr.Labels, "approved")
+
+	for _, op := range []func() error{
+		func() error { return os.Remove(filepath.Join(dir, LabelsDir, "needs-ok-to-test")) },
+		func() error { return ioutil.WriteFile(filepath.Join(dir, LabelsDir, "lgtm"), nil, 0644) },
+		func() error { return os.Remove(filepath.Join(dir, CommentsDir, "2.json")) },
+		func() error {
+			return ioutil.WriteFile(filepath.Join(dir, CommentsDir, "result"), []byte("All tests passed"), 0644)
+		},
+		func() error {
+			return writeJSON(filepath.Join(dir, StatusDir, "tekton.json"), &Status{ID: "tekton", Code: StatusSuccess, URL: "https://dashboard"})
+		},
+	} {
+		if err := op(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Upload(context.Background(), p, dir); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+
+	if d := cmp.Diff([]string{"approved", "kind/feature", "lgtm"}, p.pr.Labels); d != "" {
+		t.Errorf("Unexpected labels: %s", d)
+	}
+	wantComments := []*Comment{
+		{ID: 1, Author: "octocat", Text: "first"},
+		{ID: 3, Author: "reviewer", Text: "meanwhile"},
+		{ID: 100, Author: "tekton", Text: "All tests passed"},
+	}
+	if d := cmp.Diff(wantComments, p.pr.Comments); d != "" {
+		t.Errorf("Unexpected comments: %s", d)
+	}
+	wantStatuses := []*Status{
+		{ID: "ci/unit", Code: StatusPending},
+		{ID: "tekton", Code: StatusSuccess, URL: "https://dashboard"},
+	}
+	if d := cmp.Diff(wantStatuses, p.pr.Statuses); d != "" {
+		t.Errorf("Unexpected statuses: %s", d)
+	}
+	if d := cmp.Diff(map[string]string{"tekton": "headsha"}, p.statusSHAs); d != "" {
+		t.Errorf("Statuses were not set on the downloaded head: %s", d)
+	}
+}
+
+func TestUploadWithoutDownload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A status and a new comment are written, the labels and existing
+	// comments are left alone.
+	for _, d := range []string{StatusDir, CommentsDir} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writeJSON(filepath.Join(dir, StatusDir, "ci.json"), &Status{Code: StatusFailure}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, CommentsDir, "result"), []byte("Tests failed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := newFakeProvider()
+	want := newFakeProvider().pr
+	if err := Upload(context.Background(), p, dir); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	if d := cmp.Diff(want.Labels, p.pr.Labels); d != "" {
+		t.Errorf("Labels changed: %s", d)
+	}
+	wantComments := append(want.Comments, &Comment{ID: 100, Author: "tekton", Text: "Tests failed"})
+	if d := cmp.Diff(wantComments, p.pr.Comments); d != "" {
+		t.Errorf("Unexpected comments: %s", d)
+	}
+	if d := cmp.Diff(map[string]string{"ci": "headsha"}, p.statusSHAs); d != "" {
+		t.Errorf("Unexpected statuses set: %s", d)
+	}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// GitHubProvider is the name of the GitHub provider.
+	GitHubProvider = "github"
+
+	gitHubHost   = "github.com"
+	gitHubAPIURL = "https://api.github.com"
+	// gitHubPageSize is the largest page size the GitHub API allows.
+	gitHubPageSize = 100
+)
+
+func init() {
+	Register(GitHubProvider, gitHubHost, NewGitHub)
+}
+
+// GitHub is the Provider for pull requests on GitHub and GitHub Enterprise,
+// implemented with the GitHub REST API v3.
+type GitHub struct {
+	// HTTPClient sends the requests, http.DefaultClient if nil.
+	HTTPClient *http.Client
+
+	apiURL string
+	token  string
+	owner  string
+	repo   string
+	number int64
+}
+
+// NewGitHub returns the GitHub Provider for a pull request URL of the form
+// https://github.com/owner/repo/pull/number. The API of GitHub Enterprise is
+// found under /api/v3 of its host unless apiURL is set.
+func NewGitHub(prURL *url.URL, apiURL, token string) (Provider, error) {
+	parts := strings.Split(strings.Trim(prURL.Path, "/"), "/")
+	if len(parts) != 4 || parts[2] != "pull" {
+		return nil, xerrors.Errorf("GitHub pull request URL %q must be of the form https://host/owner/repo/pull/number", prURL)
+	}
+	number, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid pull request number in %q: %w", prURL, err)
+	}
+	if apiURL == "" {
+		apiURL = gitHubAPIURL
+		if !strings.EqualFold(prURL.Host, gitHubHost) {
+			apiURL = fmt.Sprintf("%s://%s/api/v3", prURL.Scheme, prURL.Host)
+		}
+	}
+	return &GitHub{
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		token:  token,
+		owner:  parts[0],
+		repo:   parts[1],
+		number: number,
+	}, nil
+}
+
+type gitHubUser struct {
+	Login string `json:"login"`
+}
+
+type gitHubRef struct {
+	Ref  string `json:"ref"`
+	SHA  string `json:"sha"`
+	Repo struct {
+		CloneURL string `json:"clone_url"`
+	} `json:"repo"`
+}
+
+type gitHubPullRequest struct {
+	Number  int64      `json:"number"`
+	Title   string     `json:"title"`
+	Body    string     `json:"body"`
+	HTMLURL string     `json:"html_url"`
+	User    gitHubUser `json:"user"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Head gitHubRef `json:"head"`
+	Base gitHubRef `json:"base"`
+}
+
+type gitHubComment struct {
+	ID   int64      `json:"id"`
+	Body string     `json:"body"`
+	User gitHubUser `json:"user"`
+}
+
+type gitHubStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+// Get implements Provider.
+func (g *GitHub) Get(ctx context.Context) (*PullRequest, error) {
+	var ghpr gitHubPullRequest
+	if err := g.do(ctx, http.MethodGet, g.repoPath("pulls/%d", g.number), nil, &ghpr); err != nil {
+		return nil, err
+	}
+	pr := &PullRequest{
+		Type:   GitHubProvider,
+		ID:     ghpr.Number,
+		Title:  ghpr.Title,
+		Body:   ghpr.Body,
+		Author: ghpr.User.Login,
+		URL:    ghpr.HTMLURL,
+		Head:   GitReference{Repo: ghpr.Head.Repo.CloneURL, Branch: ghpr.Head.Ref, SHA: ghpr.Head.SHA},
+		Base:   GitReference{Repo: ghpr.Base.Repo.CloneURL, Branch: ghpr.Base.Ref, SHA: ghpr.Base.SHA},
+	}
+	for _, l := range ghpr.Labels {
+		pr.Labels = append(pr.Labels, l.Name)
+	}
+
+	for page := 1; ; page++ {
+		var comments []gitHubComment
+		path := g.repoPath("issues/%d/comments?per_page=%d&page=%d", g.number, gitHubPageSize, page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &comments); err != nil {
+			return nil, err
+		}
+		for _, c := range comments {
+			pr.Comments = append(pr.Comments, &Comment{ID: c.ID, Author: c.User.Login, Text: c.Body})
+		}
+		if len(comments) < gitHubPageSize {
+			break
+		}
+	}
+
+	var combined struct {
+		Statuses []gitHubStatus `json:"statuses"`
+	}
+	if err := g.do(ctx, http.MethodGet, g.repoPath("commits/%s/status", pr.Head.SHA), nil, &combined); err != nil {
+		return nil, err
+	}
+	for _, s := range combined.Statuses {
+		pr.Statuses = append(pr.Statuses, &Status{
+			ID:          s.Context,
+			Code:        StatusCode(s.State),
+			Description: s.Description,
+			URL:         s.TargetURL,
+		})
+	}
+	return pr, nil
+}
+
+// AddLabel implements Provider.
+func (g *GitHub) AddLabel(ctx context.Context, label string) error {
+	body := map[string][]string{"labels": {label}}
+	return g.do(ctx, http.MethodPost, g.repoPath("issues/%d/labels", g.number), body, nil)
+}
+
+// RemoveLabel implements Provider.
+func (g *GitHub) RemoveLabel(ctx context.Context, label string) error {
+	return g.do(ctx, http.MethodDelete, g.repoPath("issues/%d/labels/%s", g.number, url.PathEscape(label)), nil, nil)
+}
+
+// CreateComment implements Provider.
+func (g *GitHub) CreateComment(ctx context.Context, text string) error {
+	body := map[string]string{"body": text}
+	return g.do(ctx, http.MethodPost, g.repoPath("issues/%d/comments", g.number), body, nil)
+}
+
+// DeleteComment implements Provider.
+func (g *GitHub) DeleteComment(ctx context.Context, id int64) error {
+	return g.do(ctx, http.MethodDelete, g.repoPath("issues/comments/%d", id), nil, nil)
+}
+
+// SetStatus implements Provider.
+func (g *GitHub) SetStatus(ctx context.Context, sha string, status *Status) error {
+	switch status.Code {
+	case StatusPending, StatusSuccess, StatusFailure, StatusError:
+	default:
+		return xerrors.Errorf("unsupported status code %q", status.Code)
+	}
+	body := gitHubStatus{
+		State:       string(status.Code),
+		TargetURL:   status.URL,
+		Description: status.Description,
+		Context:     status.ID,
+	}
+	return g.do(ctx, http.MethodPost, g.repoPath("statuses/%s", sha), body, nil)
+}
+
+func (g *GitHub) repoPath(format string, args ...interface{}) string {
+	return fmt.Sprintf("/repos/%s/%s/", url.PathEscape(g.owner), url.PathEscape(g.repo)) + fmt.Sprintf(format, args...)
+}
+
+// do sends a request with the JSON encoding of in, if not nil, to path and
+// decodes the response into out, if not nil.
+func (g *GitHub) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return xerrors.Errorf("encoding request to %s: %w", path, err)
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, g.apiURL+path, body)
+	if err != nil {
+		return xerrors.Errorf("creating request to %s: %w", path, err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if g.token != "" {
+		req.Header.Set("Authorization", "token "+g.token)
+	}
+
+	client := g.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return xerrors.Errorf("%s %s: unexpected status %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return xerrors.Errorf("decoding response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
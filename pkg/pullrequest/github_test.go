@@ -0,0 +1,270 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeGitHub serves the subset of the GitHub API used by the provider for
+// octocat/hello/pull/7.
+type fakeGitHub struct {
+	sync.Mutex
+	t        *testing.T
+	labels   []string
+	comments []gitHubComment
+	statuses map[string][]gitHubStatus
+	nextID   int64
+}
+
+var (
+	labelsRe   = regexp.MustCompile(`^/repos/octocat/hello/issues/7/labels(/(.+))?$`)
+	commentRe  = regexp.MustCompile(`^/repos/octocat/hello/issues/comments/(\d+)$`)
+	statusesRe = regexp.MustCompile(`^/repos/octocat/hello/(statuses/(\w+)|commits/(\w+)/status)$`)
+)
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if got := r.Header.Get("Authorization"); got != "token secret" {
+		http.Error(w, "bad credentials", http.StatusUnauthorized)
+		return
+	}
+	path := r.URL.Path
+	switch {
+	case r.Method == http.MethodGet && path == "/repos/octocat/hello/pulls/7":
+		var labels []map[string]string
+		for _, l := range f.labels {
+			labels = append(labels, map[string]string{"name": l})
+		}
+		f.write(w, map[string]interface{}{
+			"number":   7,
+			"title":    "Fix everything",
+			"body":     "Fixes #6",
+			"html_url": "https://github.com/octocat/hello/pull/7",
+			"user":     map[string]string{"login": "octocat"},
+			"labels":   labels,
+			"head":     map[string]interface{}{"ref": "fix", "sha": "abc123", "repo": map[string]string{"clone_url": "https://github.com/fork/hello.git"}},
+			"base":     map[string]interface{}{"ref": "master", "sha": "def456", "repo": map[string]string{"clone_url": "https://github.com/octocat/hello.git"}},
+		})
+	case r.Method == http.MethodGet && path == "/repos/octocat/hello/issues/7/comments":
+		var page, perPage int
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		fmt.Sscanf(r.URL.Query().Get("per_page"), "%d", &perPage)
+		if page < 1 || perPage < 1 {
+			http.Error(w, "bad page", http.StatusBadRequest)
+			return
+		}
+		comments := []gitHubComment{}
+		for i := (page - 1) * perPage; i < page*perPage && i < len(f.comments); i++ {
+			comments = append(comments, f.comments[i])
+		}
+		f.write(w, comments)
+	case r.Method == http.MethodPost && path == "/repos/octocat/hello/issues/7/comments":
+		var body map[string]string
+		f.read(r, &body)
+		f.comments = append(f.comments, gitHubComment{ID: f.nextID, Body: body["body"], User: gitHubUser{Login: "tekton"}})
+		f.nextID++
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodDelete && commentRe.MatchString(path):
+		id := commentRe.FindStringSubmatch(path)[1]
+		var comments []gitHubComment
+		for _, c := range f.comments {
+			if fmt.Sprint(c.ID) != id {
+				comments = append(comments, c)
+			}
+		}
+		f.comments = comments
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && labelsRe.MatchString(path):
+		var body map[string][]string
+		f.read(r, &body)
+		f.labels = append(f.labels, body["labels"]...)
+		f.write(w, []interface{}{})
+	case r.Method == http.MethodDelete && labelsRe.MatchString(path):
+		// The router sees the unescaped path.
+		label := labelsRe.FindStringSubmatch(path)[2]
+		var labels []string
+		for _, l := range f.labels {
+			if l != label {
+				labels = append(labels, l)
+			}
+		}
+		f.labels = labels
+		f.write(w, []interface{}{})
+	case r.Method == http.MethodGet && statusesRe.MatchString(path):
+		sha := statusesRe.FindStringSubmatch(path)[3]
+		f.write(w, map[string]interface{}{"statuses": f.statuses[sha]})
+	case r.Method == http.MethodPost && statusesRe.MatchString(path):
+		sha := statusesRe.FindStringSubmatch(path)[2]
+		var s gitHubStatus
+		f.read(r, &s)
+		f.statuses[sha] = append(f.statuses[sha], s)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeGitHub) read(r *http.Request, v interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		f.t.Errorf("Decoding %s %s: %v", r.Method, r.URL, err)
+	}
+}
+
+func (f *fakeGitHub) write(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.t.Errorf("Encoding response: %v", err)
+	}
+}
+
+func newTestGitHub(t *testing.T) (*fakeGitHub, Provider, func()) {
+	fake := &fakeGitHub{
+		t:      t,
+		labels: []string{"bug", "area/api"},
+		comments: []gitHubComment{
+			{ID: 1, Body: "looks good", User: gitHubUser{Login: "reviewer"}},
+			{ID: 2, Body: "/test", User: gitHubUser{Login: "octocat"}},
+		},
+		statuses: map[string][]gitHubStatus{
+			"abc123": {{State: "pending", Context: "ci", Description: "running"}},
+		},
+		nextID: 10,
+	}
+	server := httptest.NewServer(fake)
+	p, err := NewProvider(GitHubProvider, "https://github.com/octocat/hello/pull/7", server.URL, "secret")
+	if err != nil {
+		server.Close()
+		t.Fatalf("NewProvider() = %v", err)
+	}
+	return fake, p, server.Close
+}
+
+func TestGitHubGet(t *testing.T) {
+	_, p, cleanup := newTestGitHub(t)
+	defer cleanup()
+
+	got, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	want := &PullRequest{
+		Type:   GitHubProvider,
+		ID:     7,
+		Title:  "Fix everything",
+		Body:   "Fixes #6",
+		Author: "octocat",
+		URL:    "https://github.com/octocat/hello/pull/7",
+		Head:   GitReference{Repo: "https://github.com/fork/hello.git", Branch: "fix", SHA: "abc123"},
+		Base:   GitReference{Repo: "https://github.com/octocat/hello.git", Branch: "master", SHA: "def456"},
+		Labels: []string{"bug", "area/api"},
+		Comments: []*Comment{
+			{ID: 1, Author: "reviewer", Text: "looks good"},
+			{ID: 2, Author: "octocat", Text: "/test"},
+		},
+		Statuses: []*Status{{ID: "ci", Code: StatusPending, Description: "running"}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected pull request: %s", d)
+	}
+}
+
+func TestGitHubGetPaginatesComments(t *testing.T) {
+	fake, p, cleanup := newTestGitHub(t)
+	defer cleanup()
+
+	fake.comments = nil
+	for i := 0; i < gitHubPageSize+10; i++ {
+		fake.comments = append(fake.comments, gitHubComment{ID: int64(i + 1), Body: fmt.Sprint(i)})
+	}
+	got, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if len(got.Comments) != len(fake.comments) {
+		t.Errorf("Expected %d comments, got %d", len(fake.comments), len(got.Comments))
+	}
+}
+
+func TestGitHubUpdates(t *testing.T) {
+	fake, p, cleanup := newTestGitHub(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, op := range []func() error{
+		func() error { return p.AddLabel(ctx, "lgtm") },
+		func() error { return p.RemoveLabel(ctx, "area/api") },
+		func() error { return p.CreateComment(ctx, "Tests passed") },
+		func() error { return p.DeleteComment(ctx, 2) },
+		func() error {
+			return p.SetStatus(ctx, "abc123", &Status{ID: "tekton", Code: StatusSuccess, URL: "https://dashboard"})
+		},
+	} {
+		if err := op(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if d := cmp.Diff([]string{"bug", "lgtm"}, fake.labels); d != "" {
+		t.Errorf("Unexpected labels: %s", d)
+	}
+	wantComments := []gitHubComment{
+		{ID: 1, Body: "looks good", User: gitHubUser{Login: "reviewer"}},
+		{ID: 10, Body: "Tests passed", User: gitHubUser{Login: "tekton"}},
+	}
+	if d := cmp.Diff(wantComments, fake.comments); d != "" {
+		t.Errorf("Unexpected comments: %s", d)
+	}
+	wantStatuses := []gitHubStatus{
+		{State: "pending", Context: "ci", Description: "running"},
+		{State: "success", Context: "tekton", TargetURL: "https://dashboard"},
+	}
+	if d := cmp.Diff(wantStatuses, fake.statuses["abc123"]); d != "" {
+		t.Errorf("Unexpected statuses: %s", d)
+	}
+}
+
+func TestGitHubErrors(t *testing.T) {
+	_, p, cleanup := newTestGitHub(t)
+	defer cleanup()
+
+	if err := p.SetStatus(context.Background(), "abc123", &Status{ID: "ci", Code: "unknown"}); err == nil {
+		t.Error("Expected an error setting an unknown status code")
+	}
+	if err := p.DeleteComment(context.Background(), 404); err != nil {
+		t.Errorf("Deleting a missing comment in the fake is not an error: %v", err)
+	}
+
+	bad, err := NewProvider(GitHubProvider, "https://github.com/octocat/hello/pull/7", p.(*GitHub).apiURL, "wrong")
+	if err != nil {
+		t.Fatalf("NewProvider() = %v", err)
+	}
+	if _, err := bad.Get(context.Background()); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected an authentication error, got %v", err)
+	}
+}
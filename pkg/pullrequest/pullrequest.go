@@ -0,0 +1,154 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pullrequest moves pull request metadata between an SCM provider and
+// a directory on disk, so that Tasks can read and edit it with plain files.
+package pullrequest
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// TokenEnvVar is the environment variable holding the token used to
+// authenticate against the SCM provider API.
+const TokenEnvVar = "AUTH_TOKEN"
+
+// PullRequest is the metadata of a pull request. Labels, comments and statuses
+// are stored in their own files on disk and so are not part of the JSON form.
+type PullRequest struct {
+	Type   string       `json:"type"`
+	ID     int64        `json:"id"`
+	Title  string       `json:"title"`
+	Body   string       `json:"body"`
+	Author string       `json:"author"`
+	URL    string       `json:"url"`
+	Head   GitReference `json:"head"`
+	Base   GitReference `json:"base"`
+
+	Labels   []string   `json:"-"`
+	Comments []*Comment `json:"-"`
+	Statuses []*Status  `json:"-"`
+}
+
+// GitReference is one side of a pull request.
+type GitReference struct {
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+	SHA    string `json:"sha"`
+}
+
+// Comment is a comment on a pull request. Comments that have not been created
+// yet have no ID.
+type Comment struct {
+	ID     int64  `json:"id,omitempty"`
+	Author string `json:"author,omitempty"`
+	Text   string `json:"text"`
+}
+
+// StatusCode is the state of a Status.
+type StatusCode string
+
+// The StatusCodes understood by every provider.
+const (
+	StatusPending StatusCode = "pending"
+	StatusSuccess StatusCode = "success"
+	StatusFailure StatusCode = "failure"
+	StatusError   StatusCode = "error"
+)
+
+// Status is a commit status reported on the head of a pull request. ID is the
+// name the status is reported under, e.g. the context on GitHub.
+type Status struct {
+	ID          string     `json:"id"`
+	Code        StatusCode `json:"code"`
+	Description string     `json:"description,omitempty"`
+	URL         string     `json:"url,omitempty"`
+}
+
+// Provider is the API of an SCM provider for a single pull request.
+type Provider interface {
+	// Get returns the pull request with its labels, comments and the
+	// statuses of its head commit.
+	Get(ctx context.Context) (*PullRequest, error)
+	AddLabel(ctx context.Context, label string) error
+	RemoveLabel(ctx context.Context, label string) error
+	CreateComment(ctx context.Context, text string) error
+	DeleteComment(ctx context.Context, id int64) error
+	SetStatus(ctx context.Context, sha string, status *Status) error
+}
+
+// NewProviderFunc creates the Provider for the pull request at prURL. apiURL
+// overrides the default API endpoint of the provider, e.g. for self-hosted
+// installations, and token authenticates the requests if not empty.
+type NewProviderFunc func(prURL *url.URL, apiURL, token string) (Provider, error)
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]NewProviderFunc{}
+	// hosts maps the host of public instances to the name of their provider.
+	hosts = map[string]string{}
+)
+
+// Register makes a provider available under name. If host is not empty, pull
+// requests on that host use the provider when none is named explicitly.
+func Register(name, host string, fn NewProviderFunc) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[name] = fn
+	if host != "" {
+		hosts[host] = name
+	}
+}
+
+// Providers returns the names of the registered providers.
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	var names []string
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewProvider returns the Provider called name for the pull request at
+// rawURL. If name is empty, it is guessed from the host of rawURL.
+func NewProvider(name, rawURL, apiURL, token string) (Provider, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid pull request URL %q: %w", rawURL, err)
+	}
+	providersMu.RLock()
+	if name == "" {
+		name = hosts[strings.ToLower(u.Host)]
+	}
+	fn, ok := providers[name]
+	providersMu.RUnlock()
+	if !ok {
+		if name == "" {
+			return nil, xerrors.Errorf("no provider known for host %q, one of %v must be set", u.Host, Providers())
+		}
+		return nil, xerrors.Errorf("unknown provider %q, must be one of %v", name, Providers())
+	}
+	return fn(u, apiURL, token)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestNewProvider(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		provider string
+		url      string
+		apiURL   string
+		want     string
+	}{{
+		name: "github guessed from host",
+		url:  "https://github.com/tektoncd/pipeline/pull/1",
+		want: "https://api.github.com",
+	}, {
+		name:     "github enterprise",
+		provider: GitHubProvider,
+		url:      "https://github.example.com/tektoncd/pipeline/pull/1",
+		want:     "https://github.example.com/api/v3",
+	}, {
+		name:     "explicit api url",
+		provider: GitHubProvider,
+		url:      "https://github.example.com/tektoncd/pipeline/pull/1",
+		apiURL:   "https://api.example.com/",
+		want:     "https://api.example.com",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewProvider(tc.provider, tc.url, tc.apiURL, "")
+			if err != nil {
+				t.Fatalf("NewProvider() = %v", err)
+			}
+			g, ok := p.(*GitHub)
+			if !ok {
+				t.Fatalf("Expected a GitHub provider, got %T", p)
+			}
+			if g.apiURL != tc.want {
+				t.Errorf("Expected API URL %q, got %q", tc.want, g.apiURL)
+			}
+		})
+	}
+}
+
+func TestNewProviderErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		provider string
+		url      string
+	}{{
+		name: "unknown host",
+		url:  "https://scm.example.com/tektoncd/pipeline/pull/1",
+	}, {
+		name:     "unknown provider",
+		provider: "svn",
+		url:      "https://github.com/tektoncd/pipeline/pull/1",
+	}, {
+		name: "not a pull request",
+		url:  "https://github.com/tektoncd/pipeline/issues/1",
+	}, {
+		name: "invalid number",
+		url:  "https://github.com/tektoncd/pipeline/pull/one",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewProvider(tc.provider, tc.url, "", ""); err == nil {
+				t.Error("Expected NewProvider to fail")
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	fake := newFakeProvider()
+	Register("fake", "fake.example.com", func(prURL *url.URL, apiURL, token string) (Provider, error) {
+		return fake, nil
+	})
+	defer func() {
+		providersMu.Lock()
+		delete(providers, "fake")
+		delete(hosts, "fake.example.com")
+		providersMu.Unlock()
+	}()
+
+	p, err := NewProvider("", "https://fake.example.com/a/b", "", "")
+	if err != nil {
+		t.Fatalf("NewProvider() = %v", err)
+	}
+	pr, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if pr.ID != 42 {
+		t.Errorf("Expected the registered provider to be used, got %+v", pr)
+	}
+}
@@ -32,11 +32,17 @@ import (
 
 // cancelPipelineRun makrs the PipelineRun as cancelled and any resolved taskrun too.
 func cancelPipelineRun(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, clientSet clientset.Interface) error {
+	return stopPipelineRun(pr, pipelineState, clientSet, "PipelineRunCancelled", fmt.Sprintf("PipelineRun %q was cancelled", pr.Name))
+}
+
+// stopPipelineRun marks the PipelineRun as failed for reason and cancels any
+// resolved taskrun.
+func stopPipelineRun(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, clientSet clientset.Interface, reason, message string) error {
 	pr.Status.SetCondition(&apis.Condition{
 		Type:    apis.ConditionSucceeded,
 		Status:  corev1.ConditionFalse,
-		Reason:  "PipelineRunCancelled",
-		Message: fmt.Sprintf("PipelineRun %q was cancelled", pr.Name),
+		Reason:  reason,
+		Message: message,
 	})
 	// update pr completed time
 	pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
@@ -64,6 +64,12 @@ const (
 	// ReasonInvalidGraph indicates that the reason for the failure status is that the
 	// associated Pipeline is an invalid graph (a.k.a wrong order, cycle, …)
 	ReasonInvalidGraph = "PipelineInvalidGraph"
+	// ReasonArtifactQuotaExceeded indicates that the reason for the failure status is that
+	// the artifacts stored by the TaskRuns of the PipelineRun exceeded its quota
+	ReasonArtifactQuotaExceeded = "ArtifactQuotaExceeded"
+	// ReasonArtifactQuotaUnsupported indicates that the reason for the failure status is that
+	// the PipelineRun has an artifact quota but its artifacts aren't measured
+	ReasonArtifactQuotaUnsupported = "ArtifactQuotaUnsupported"
 	// pipelineRunAgentName defines logging agent name for PipelineRun Controller
 	pipelineRunAgentName = "pipeline-controller"
 	// pipelineRunControllerName defines name for PipelineRun Controller
@@ -183,7 +189,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 
 	if pr.IsDone() {
 		if err := artifacts.CleanupArtifactStorage(pr, c.KubeClientSet, c.Logger); err != nil {
-			c.Logger.Errorf("Failed to delete PVC for PipelineRun %s: %v", pr.Name, err)
+			c.Logger.Errorf("Failed to delete artifact storage for PipelineRun %s: %v", pr.Name, err)
 			return err
 		}
 		c.timeoutHandler.Release(pr)
@@ -273,6 +279,19 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	// Apply parameter templating from the PipelineRun
 	p = resources.ApplyParameters(p, pr)
 
+	// Resolve the params of the PipelineResources from the PipelineRun
+	providedResources, err = resources.ApplyResourceParameters(p, pr, providedResources, c.resourceLister.PipelineResources(pr.Namespace).Get)
+	if err != nil {
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: ReasonFailedValidation,
+			Message: fmt.Sprintf("PipelineRun %s can't be Run; couldn't resolve the params of its PipelineResources: %s",
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), err),
+		})
+		return nil
+	}
+
 	// Propagate labels from Pipeline to PipelineRun.
 	if pr.ObjectMeta.Labels == nil {
 		pr.ObjectMeta.Labels = make(map[string]string, len(p.ObjectMeta.Labels)+1)
@@ -373,6 +392,33 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		return cancelPipelineRun(pr, pipelineState, c.PipelineClientSet)
 	}
 
+	// If the artifacts stored by the taskruns exceed the quota, fail the pipelinerun
+	pr.Status.ArtifactStorage = pipelineState.GetArtifactStorageStatus()
+	quota, err := artifacts.GetArtifactQuota(pr, c.KubeClientSet)
+	if err != nil {
+		c.Logger.Errorf("Failed to get the artifact quota of pipelinerun %s: %v", pr.Name, err)
+		return err
+	}
+	if quota != nil {
+		as, err := artifacts.GetArtifactStorage(pr.Name, pr.Namespace, c.KubeClientSet, c.Logger)
+		if err != nil {
+			c.Logger.Errorf("Failed to get the artifact storage of pipelinerun %s: %v", pr.Name, err)
+			return err
+		}
+		// Nothing reports the size of the artifacts mounted directly from
+		// the PVC, so their quota would never be enforced. A quota configured
+		// along with mount.direct is refused when the config map is loaded,
+		// only the quota of the PipelineRun itself gets here.
+		if pvc, ok := as.(*v1alpha1.ArtifactPVC); ok && pvc.MountDirect {
+			return stopPipelineRun(pr, pipelineState, c.PipelineClientSet, ReasonArtifactQuotaUnsupported,
+				fmt.Sprintf("PipelineRun %q has an artifact quota of %s, which can't be enforced on artifacts mounted directly from its PVC", pr.Name, quota.String()))
+		}
+	}
+	if quota != nil && pr.Status.ArtifactStorage != nil && pr.Status.ArtifactStorage.Size > quota.Value() {
+		return stopPipelineRun(pr, pipelineState, c.PipelineClientSet, ReasonArtifactQuotaExceeded,
+			fmt.Sprintf("PipelineRun %q stored %d bytes of artifacts, exceeding its quota of %s", pr.Name, pr.Status.ArtifactStorage.Size, quota.String()))
+	}
+
 	candidateTasks, err := dag.GetSchedulable(d, pipelineState.SuccessfulPipelineTaskNames()...)
 	if err != nil {
 		c.Logger.Errorf("Error getting potential next tasks for valid pipelinerun %s: %v", pr.Name, err)
@@ -385,10 +431,25 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		return err
 	}
 
+	// The TaskRuns mounting a ReadWriteOnce PVC directly are kept on the node
+	// it is attached to, which is only known once a pod was scheduled.
+	coScheduling := false
+	var node string
+	if pvc, ok := as.(*v1alpha1.ArtifactPVC); ok && pvc.NeedsCoScheduling() {
+		coScheduling = true
+		if node, err = artifacts.GetCoSchedulingNode(pr, c.KubeClientSet); err != nil {
+			return err
+		}
+	}
+
 	for _, rprt := range rprts {
 		if rprt != nil {
+			if coScheduling && node == "" && hasRunningTaskRun(pipelineState) {
+				c.Logger.Infof("Waiting for a pod of PipelineRun %s to be scheduled before creating TaskRun %s", pr.Name, rprt.TaskRunName)
+				continue
+			}
 			c.Logger.Infof("Creating a new TaskRun object %s", rprt.TaskRunName)
-			rprt.TaskRun, err = c.createTaskRun(c.Logger, rprt, pr, as.StorageBasePath(pr))
+			rprt.TaskRun, err = c.createTaskRun(c.Logger, rprt, pr, pipelineState, providedResources, as, coScheduling, node)
 			if err != nil {
 				c.Recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
 				return xerrors.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
@@ -406,6 +467,17 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	return nil
 }
 
+// hasRunningTaskRun returns true if a TaskRun of pipelineState, including
+// the ones created since it was resolved, isn't done.
+func hasRunningTaskRun(pipelineState resources.PipelineRunState) bool {
+	for _, rprt := range pipelineState {
+		if rprt.TaskRun != nil && !rprt.TaskRun.IsDone() {
+			return true
+		}
+	}
+	return false
+}
+
 func updateTaskRunsStatus(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask) {
 	for _, rprt := range pipelineState {
 		if rprt.TaskRun != nil {
@@ -438,7 +510,7 @@ func (c *Reconciler) updateTaskRunsStatusDirectly(pr *v1alpha1.PipelineRun) erro
 	return nil
 }
 
-func (c *Reconciler) createTaskRun(logger *zap.SugaredLogger, rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, storageBasePath string) (*v1alpha1.TaskRun, error) {
+func (c *Reconciler) createTaskRun(logger *zap.SugaredLogger, rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, pipelineState resources.PipelineRunState, providedResources map[string]v1alpha1.PipelineResourceBinding, as artifacts.ArtifactStorageInterface, coScheduling bool, node string) (*v1alpha1.TaskRun, error) {
 	var taskRunTimeout = &metav1.Duration{Duration: 0 * time.Second}
 
 	if pr.Spec.Timeout != nil {
@@ -502,9 +574,15 @@ func (c *Reconciler) createTaskRun(logger *zap.SugaredLogger, rprt *resources.Re
 			NodeSelector:   pr.Spec.NodeSelector,
 			Tolerations:    pr.Spec.Tolerations,
 			Affinity:       pr.Spec.Affinity,
+			PodTemplate:    pr.Spec.PodTemplate,
 		}}
 
-	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
+	if coScheduling {
+		tr.Spec.Affinity = artifacts.GetCoSchedulingAffinity(tr.Spec.Affinity, pr, node)
+	}
+	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, as.StorageBasePath(pr))
+	resources.AddInputArtifacts(&tr.Spec, pipelineState)
+	resources.EmbedResourceSpecs(&tr.Spec, rprt.PipelineTask, providedResources)
 
 	return c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Create(tr)
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/knative/pkg/configmap"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/resources"
 	taskrunresources "github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
@@ -37,6 +38,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
@@ -89,6 +91,7 @@ func TestReconcile(t *testing.T) {
 				tb.PipelineRunResourceBinding("git-repo", tb.PipelineResourceBindingRef("some-repo")),
 				tb.PipelineRunResourceBinding("best-image", tb.PipelineResourceBindingRef("some-image")),
 				tb.PipelineRunParam("bar", "somethingmorefun"),
+				tb.PipelineRunPodTemplate(&v1alpha1.PodTemplate{PriorityClassName: "builds"}),
 			),
 		),
 	}
@@ -216,6 +219,7 @@ func TestReconcile(t *testing.T) {
 		tb.TaskRunSpec(
 			tb.TaskRunTaskRef("unit-test-task"),
 			tb.TaskRunServiceAccount("test-sa"),
+			tb.TaskRunPodTemplate(&v1alpha1.PodTemplate{PriorityClassName: "builds"}),
 			tb.TaskRunInputs(
 				tb.TaskRunInputsParam("foo", "somethingfun"),
 				tb.TaskRunInputsParam("bar", "somethingmorefun"),
@@ -731,6 +735,272 @@ func TestReconcileCancelledPipelineRun(t *testing.T) {
 	}
 }
 
+func TestReconcileWithArtifactQuotaExceeded(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-2", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-quota", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+		),
+	)}
+	prs[0].Spec.ArtifactQuota = resource.NewQuantity(1024, resource.BinarySI)
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("hello-world-1", "foo",
+			tb.TaskRunStatus(
+				tb.Condition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+			)),
+	}
+	trs[0].Status.Artifacts = []v1alpha1.ArtifactResult{{
+		Name: "workspace",
+		Path: "/pvc/hello-world-1/workspace",
+		Size: 2048,
+	}}
+	prs[0].Status.TaskRuns = map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"hello-world-1": {
+			PipelineTaskName: "hello-world-1",
+			Status:           &trs[0].Status,
+		},
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(t, d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-quota")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	reconciledRun, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run-with-quota", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonArtifactQuotaExceeded {
+		t.Errorf("Expected PipelineRun to fail with reason %s, but condition is %v", ReasonArtifactQuotaExceeded, condition)
+	}
+	expectedArtifactStorage := &v1alpha1.ArtifactStorageStatus{
+		Size:      2048,
+		Resources: map[string]int64{"workspace": 2048},
+	}
+	if d := cmp.Diff(expectedArtifactStorage, reconciledRun.Status.ArtifactStorage); d != "" {
+		t.Errorf("Unexpected artifact storage status (-want +got): %s", d)
+	}
+
+	// Check that the next TaskRun wasn't created
+	for _, action := range clients.Pipeline.Actions() {
+		if action.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created, but saw %v", action)
+		}
+	}
+}
+
+func TestReconcileWithArtifactQuotaAndDirectMount(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-quota", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+		),
+	)}
+	prs[0].Spec.ArtifactQuota = resource.NewQuantity(1024, resource.BinarySI)
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	testAssets := getPipelineRunController(t, d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ConfigMaps("foo").Create(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: artifacts.PvcConfigName},
+		Data:       map[string]string{artifacts.PvcMountDirectKey: "true"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-quota"); err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	reconciledRun, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run-with-quota", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonArtifactQuotaUnsupported {
+		t.Errorf("Expected PipelineRun to fail with reason %s, but condition is %v", ReasonArtifactQuotaUnsupported, condition)
+	}
+	for _, action := range clients.Pipeline.Actions() {
+		if action.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created, but saw %v", action)
+		}
+	}
+}
+
+func TestReconcileWithConfiguredArtifactQuotaAndDirectMount(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-quota", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	testAssets := getPipelineRunController(t, d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ConfigMaps("foo").Create(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: artifacts.PvcConfigName},
+		Data:       map[string]string{artifacts.PvcMountDirectKey: "true", artifacts.PvcQuotaKey: "2Gi"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The configuration is invalid rather than the PipelineRun, which is
+	// reconciled again once it is fixed.
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-quota"); err == nil {
+		t.Error("Expected an error reconciling a PipelineRun with a quota configured along with mount.direct")
+	}
+	for _, action := range clients.Pipeline.Actions() {
+		if action.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created, but saw %v", action)
+		}
+	}
+}
+
+func TestReconcileCoSchedulesParallelTaskRuns(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-2", "hello-world"),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	pvcConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: artifacts.PvcConfigName},
+		Data:       map[string]string{artifacts.PvcMountDirectKey: "true"},
+	}
+	running := tb.TaskRun("hello-world-1", "foo",
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-co-scheduled"),
+		tb.TaskRunStatus(tb.PodName("hello-world-1-pod")),
+	)
+	scheduledPod := tb.Pod("hello-world-1-pod", "foo",
+		tb.PodLabel("tekton.dev/pipelineRun", "test-pipeline-run-co-scheduled"),
+	)
+	scheduledPod.Spec.NodeName = "node-1"
+
+	for _, tc := range []struct {
+		name     string
+		taskRuns []*v1alpha1.TaskRun
+		pods     []*corev1.Pod
+		// created are the names of the pipeline tasks whose TaskRun is
+		// expected to be created.
+		created []string
+		node    string
+	}{{
+		name:    "no pod scheduled yet",
+		created: []string{"hello-world-1"},
+	}, {
+		name:     "pod not scheduled yet",
+		taskRuns: []*v1alpha1.TaskRun{running},
+		pods:     []*corev1.Pod{tb.Pod("hello-world-1-pod", "foo", tb.PodLabel("tekton.dev/pipelineRun", "test-pipeline-run-co-scheduled"))},
+	}, {
+		name:     "pod scheduled",
+		taskRuns: []*v1alpha1.TaskRun{running},
+		pods:     []*corev1.Pod{scheduledPod},
+		created:  []string{"hello-world-2"},
+		node:     "node-1",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := tb.PipelineRun("test-pipeline-run-co-scheduled", "foo",
+				tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+				tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+			)
+			pr.Status.TaskRuns = map[string]*v1alpha1.PipelineRunTaskRunStatus{}
+			for _, tr := range tc.taskRuns {
+				pr.Status.TaskRuns[tr.Name] = &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: tr.Name,
+					Status:           &tr.Status,
+				}
+			}
+			d := test.Data{
+				PipelineRuns: []*v1alpha1.PipelineRun{pr},
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     tc.taskRuns,
+				Pods:         tc.pods,
+			}
+			testAssets := getPipelineRunController(t, d, record.NewFakeRecorder(2))
+			clients := testAssets.Clients
+			if _, err := clients.Kube.CoreV1().ConfigMaps("foo").Create(pvcConfigMap); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-co-scheduled"); err != nil {
+				t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+			}
+
+			var created []string
+			for _, action := range clients.Pipeline.Actions() {
+				if action.GetVerb() != "create" {
+					continue
+				}
+				tr := action.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+				// The names of the TaskRuns end with a random suffix.
+				name := strings.TrimPrefix(tr.Name, "test-pipeline-run-co-scheduled-")
+				created = append(created, name[:strings.LastIndex(name, "-")])
+				var got []corev1.NodeSelectorRequirement
+				if tr.Spec.Affinity != nil && tr.Spec.Affinity.NodeAffinity != nil {
+					got = tr.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions
+				}
+				var want []corev1.NodeSelectorRequirement
+				if tc.node != "" {
+					want = []corev1.NodeSelectorRequirement{{
+						Key:      "kubernetes.io/hostname",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{tc.node},
+					}}
+				}
+				if d := cmp.Diff(want, got); d != "" {
+					t.Errorf("Unexpected node affinity of TaskRun %s (-want +got): %s", tr.Name, d)
+				}
+				if tr.Spec.Affinity == nil || tr.Spec.Affinity.PodAffinity == nil {
+					t.Errorf("Expected TaskRun %s to have a pod affinity for the pods of the PipelineRun", tr.Name)
+				}
+			}
+			if d := cmp.Diff(tc.created, created); d != "" {
+				t.Errorf("Unexpected TaskRuns created (-want +got): %s", d)
+			}
+		})
+	}
+}
+
 func TestReconcilePropagateLabels(t *testing.T) {
 	names.TestingSeed()
 
@@ -794,6 +1064,104 @@ func TestReconcilePropagateLabels(t *testing.T) {
 	}
 }
 
+func TestReconcileWithParameterizedResources(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineDeclaredResource("git-repo", "git"),
+		tb.PipelineDeclaredResource("best-image", "image"),
+		tb.PipelineParam("revision", tb.PipelineParamDefault("master")),
+		tb.PipelineParam("project"),
+		tb.PipelineTask("build", "build-task",
+			tb.PipelineTaskInputResource("workspace", "git-repo"),
+			tb.PipelineTaskOutputResource("image", "best-image"),
+		),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-params", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunParam("revision", "v0.5.0"),
+			tb.PipelineRunParam("project", "tekton"),
+			tb.PipelineRunResourceBinding("git-repo", tb.PipelineResourceBindingRef("some-repo")),
+			tb.PipelineRunResourceBinding("best-image", tb.PipelineResourceBindingResourceSpec(v1alpha1.PipelineResourceTypeImage,
+				tb.PipelineResourceSpecParam("url", "gcr.io/${params.project}/app"),
+			)),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("build-task", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsResource("workspace", v1alpha1.PipelineResourceTypeGit)),
+		tb.TaskOutputs(tb.OutputsResource("image", v1alpha1.PipelineResourceTypeImage)),
+	))}
+	rs := []*v1alpha1.PipelineResource{tb.PipelineResource("some-repo", "foo", tb.PipelineResourceSpec(
+		v1alpha1.PipelineResourceTypeGit,
+		tb.PipelineResourceSpecParam("url", "https://github.com/kristoff/reindeer"),
+		tb.PipelineResourceSpecParam("revision", "${params.revision}"),
+	))}
+
+	d := test.Data{
+		PipelineRuns:      prs,
+		Pipelines:         ps,
+		Tasks:             ts,
+		PipelineResources: rs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(t, d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-params"); err != nil {
+		t.Fatalf("Error reconciling: %s", err)
+	}
+
+	// make sure there is no failed events
+	validateNoEvents(t, fr)
+
+	// Check that the TaskRun was created with the resolved resources embedded
+	var actual *v1alpha1.TaskRun
+	for _, a := range clients.Pipeline.Actions() {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			if tr, ok := ca.GetObject().(*v1alpha1.TaskRun); ok {
+				actual = tr
+			}
+		}
+	}
+	if actual == nil {
+		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedInputs := []v1alpha1.TaskResourceBinding{{
+		Name: "workspace",
+		ResourceSpec: &v1alpha1.PipelineResourceSpec{
+			Type: v1alpha1.PipelineResourceTypeGit,
+			Params: []v1alpha1.Param{{
+				Name:  "url",
+				Value: "https://github.com/kristoff/reindeer",
+			}, {
+				Name:  "revision",
+				Value: "v0.5.0",
+			}},
+		},
+	}}
+	if d := cmp.Diff(expectedInputs, actual.Spec.Inputs.Resources); d != "" {
+		t.Errorf("expected to see TaskRun input resources %v. Diff %s", expectedInputs, d)
+	}
+	expectedOutputs := []v1alpha1.TaskResourceBinding{{
+		Name: "image",
+		ResourceSpec: &v1alpha1.PipelineResourceSpec{
+			Type: v1alpha1.PipelineResourceTypeImage,
+			Params: []v1alpha1.Param{{
+				Name:  "url",
+				Value: "gcr.io/tekton/app",
+			}},
+		},
+		Paths: []string{"/pvc/build/image"},
+	}}
+	if d := cmp.Diff(expectedOutputs, actual.Spec.Outputs.Resources); d != "" {
+		t.Errorf("expected to see TaskRun output resources %v. Diff %s", expectedOutputs, d)
+	}
+}
+
 func TestReconcileWithTimeoutAndRetry(t *testing.T) {
 
 	tcs := []struct {
@@ -17,11 +17,48 @@ import (
 	"fmt"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/templating"
+	"golang.org/x/xerrors"
 )
 
 // ApplyParameters applies the params from a PipelineRun.Params to a PipelineSpec.
 func ApplyParameters(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) *v1alpha1.Pipeline {
+	return ApplyReplacements(p, getParameterReplacements(p, pr))
+}
+
+// ApplyResourceParameters resolves the references to the params of the PipelineRun, e.g.
+// ${params.revision}, in the params of the PipelineResources bound to it. The bindings to
+// PipelineResources referencing params are replaced by bindings embedding their resolved
+// specs. The bindings to PipelineResources which can't be retrieved are left as is for
+// ResolvePipelineRun to report.
+func ApplyResourceParameters(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun, providedResources map[string]v1alpha1.PipelineResourceBinding, getResource resources.GetResource) (map[string]v1alpha1.PipelineResourceBinding, error) {
+	replacements := getParameterReplacements(p, pr)
+	applied := make(map[string]v1alpha1.PipelineResourceBinding, len(providedResources))
+	for name, binding := range providedResources {
+		var r *v1alpha1.PipelineResource
+		if binding.ResourceSpec != nil {
+			r = &v1alpha1.PipelineResource{Spec: *binding.ResourceSpec}
+		} else if fetched, err := getResource(binding.ResourceRef.Name); err == nil {
+			r = fetched
+		}
+		if r == nil || len(r.Spec.ParamReferences()) == 0 {
+			applied[name] = binding
+			continue
+		}
+		resolved, err := resources.ApplyResourceReplacements(r, replacements)
+		if err != nil {
+			return nil, xerrors.Errorf("couldn't resolve params of PipelineResource %q: %w", name, err)
+		}
+		applied[name] = v1alpha1.PipelineResourceBinding{
+			Name:         binding.Name,
+			ResourceSpec: &resolved.Spec,
+		}
+	}
+	return applied, nil
+}
+
+func getParameterReplacements(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) map[string]string {
 	// This assumes that the PipelineRun inputs have been validated against what the Pipeline requests.
 	replacements := map[string]string{}
 	// Set all the default replacements
@@ -34,8 +71,7 @@ func ApplyParameters(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) *v1alpha1.P
 	for _, p := range pr.Spec.Params {
 		replacements[fmt.Sprintf("params.%s", p.Name)] = p.Value
 	}
-
-	return ApplyReplacements(p, replacements)
+	return replacements
 }
 
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
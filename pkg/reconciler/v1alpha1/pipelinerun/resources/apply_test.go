@@ -19,6 +19,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	tb "github.com/tektoncd/pipeline/test/builder"
+	"golang.org/x/xerrors"
 )
 
 func TestApplyParameters(t *testing.T) {
@@ -79,3 +80,87 @@ func TestApplyParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyResourceParameters(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParam("revision", tb.PipelineParamDefault("master")),
+		tb.PipelineParam("project"),
+		tb.PipelineDeclaredResource("source", v1alpha1.PipelineResourceTypeGit),
+		tb.PipelineDeclaredResource("image", v1alpha1.PipelineResourceTypeImage),
+		tb.PipelineDeclaredResource("static", v1alpha1.PipelineResourceTypeGit),
+		tb.PipelineDeclaredResource("missing", v1alpha1.PipelineResourceTypeGit),
+	))
+	pr := tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline",
+		tb.PipelineRunParam("project", "tekton"),
+		tb.PipelineRunResourceBinding("source", tb.PipelineResourceBindingRef("source-repo")),
+		tb.PipelineRunResourceBinding("image", tb.PipelineResourceBindingResourceSpec(v1alpha1.PipelineResourceTypeImage,
+			tb.PipelineResourceSpecParam("url", "gcr.io/${params.project}/app"),
+		)),
+		tb.PipelineRunResourceBinding("static", tb.PipelineResourceBindingRef("static-repo")),
+		tb.PipelineRunResourceBinding("missing", tb.PipelineResourceBindingRef("missing-repo")),
+	))
+	providedResources, err := GetResourcesFromBindings(p, pr)
+	if err != nil {
+		t.Fatalf("Unexpected error getting resources from bindings: %v", err)
+	}
+	resources := map[string]*v1alpha1.PipelineResource{
+		"source-repo": tb.PipelineResource("source-repo", "foo", tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeGit,
+			tb.PipelineResourceSpecParam("url", "https://github.com/tektoncd/pipeline"),
+			tb.PipelineResourceSpecParam("revision", "${params.revision}"),
+		)),
+		"static-repo": tb.PipelineResource("static-repo", "foo", tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeGit,
+			tb.PipelineResourceSpecParam("url", "https://github.com/tektoncd/pipeline"),
+		)),
+	}
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) {
+		if r, ok := resources[name]; ok {
+			return r, nil
+		}
+		return nil, xerrors.Errorf("resource %s not found", name)
+	}
+
+	got, err := ApplyResourceParameters(p, pr, providedResources, getResource)
+	if err != nil {
+		t.Fatalf("Unexpected error resolving params of resources: %v", err)
+	}
+	expected := tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline",
+		tb.PipelineRunResourceBinding("source", tb.PipelineResourceBindingResourceSpec(v1alpha1.PipelineResourceTypeGit,
+			tb.PipelineResourceSpecParam("url", "https://github.com/tektoncd/pipeline"),
+			tb.PipelineResourceSpecParam("revision", "master"),
+		)),
+		tb.PipelineRunResourceBinding("image", tb.PipelineResourceBindingResourceSpec(v1alpha1.PipelineResourceTypeImage,
+			tb.PipelineResourceSpecParam("url", "gcr.io/tekton/app"),
+		)),
+		tb.PipelineRunResourceBinding("static", tb.PipelineResourceBindingRef("static-repo")),
+		tb.PipelineRunResourceBinding("missing", tb.PipelineResourceBindingRef("missing-repo")),
+	))
+	want, err := GetResourcesFromBindings(p, expected)
+	if err != nil {
+		t.Fatalf("Unexpected error getting resources from bindings: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyResourceParameters() got diff %s", d)
+	}
+}
+
+func TestApplyResourceParameters_NotProvided(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineDeclaredResource("source", v1alpha1.PipelineResourceTypeGit),
+	))
+	pr := tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline",
+		tb.PipelineRunResourceBinding("source", tb.PipelineResourceBindingResourceSpec(v1alpha1.PipelineResourceTypeGit,
+			tb.PipelineResourceSpecParam("url", "https://github.com/tektoncd/pipeline"),
+			tb.PipelineResourceSpecParam("revision", "${params.revision}"),
+		)),
+	))
+	providedResources, err := GetResourcesFromBindings(p, pr)
+	if err != nil {
+		t.Fatalf("Unexpected error getting resources from bindings: %v", err)
+	}
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) {
+		return nil, xerrors.Errorf("resource %s not found", name)
+	}
+	if _, err := ApplyResourceParameters(p, pr, providedResources, getResource); err == nil {
+		t.Errorf("Expected error resolving params of resources but got none")
+	}
+}
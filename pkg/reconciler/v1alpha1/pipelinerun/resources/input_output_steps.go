@@ -81,3 +81,53 @@ func WrapSteps(tr *v1alpha1.TaskRunSpec, pt *v1alpha1.PipelineTask, inputs, outp
 	// Add poststeps to setup outputs
 	tr.Outputs.Resources = append(tr.Outputs.Resources, GetOutputSteps(outputs, pt.Name, storageBasePath)...)
 }
+
+// AddInputArtifacts adds to the input bindings of tr the artifacts the
+// TaskRuns of state stored at their Paths, so that their checksum is verified
+// when they are copied.
+func AddInputArtifacts(tr *v1alpha1.TaskRunSpec, state PipelineRunState) {
+	stored := map[string]v1alpha1.ArtifactResult{}
+	for _, rprt := range state {
+		if rprt.TaskRun == nil {
+			continue
+		}
+		for _, a := range rprt.TaskRun.Status.Artifacts {
+			stored[a.Path] = a
+		}
+	}
+	for i := range tr.Inputs.Resources {
+		binding := &tr.Inputs.Resources[i]
+		for _, path := range binding.Paths {
+			if a, ok := stored[path]; ok {
+				binding.Artifacts = append(binding.Artifacts, a)
+			}
+		}
+	}
+}
+
+// EmbedResourceSpecs replaces the references to PipelineResources in the bindings of tr
+// by the specs embedded in the PipelineRun for the PipelineResources pt uses, so that
+// the TaskRun uses the same specs, with their params resolved, as the PipelineRun.
+func EmbedResourceSpecs(tr *v1alpha1.TaskRunSpec, pt *v1alpha1.PipelineTask, providedResources map[string]v1alpha1.PipelineResourceBinding) {
+	if pt == nil || pt.Resources == nil {
+		return
+	}
+	for _, taskInput := range pt.Resources.Inputs {
+		embedResourceSpec(tr.Inputs.Resources, taskInput.Name, providedResources[taskInput.Resource].ResourceSpec)
+	}
+	for _, taskOutput := range pt.Resources.Outputs {
+		embedResourceSpec(tr.Outputs.Resources, taskOutput.Name, providedResources[taskOutput.Resource].ResourceSpec)
+	}
+}
+
+func embedResourceSpec(bindings []v1alpha1.TaskResourceBinding, name string, spec *v1alpha1.PipelineResourceSpec) {
+	if spec == nil {
+		return
+	}
+	for i := range bindings {
+		if bindings[i].Name == name {
+			bindings[i].ResourceRef = v1alpha1.PipelineResourceRef{}
+			bindings[i].ResourceSpec = spec.DeepCopy()
+		}
+	}
+}
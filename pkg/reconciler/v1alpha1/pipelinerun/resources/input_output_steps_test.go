@@ -200,3 +200,134 @@ func TestWrapSteps(t *testing.T) {
 		t.Errorf("error comparing output resources: %s", d)
 	}
 }
+
+func TestAddInputArtifacts(t *testing.T) {
+	state := resources.PipelineRunState{{
+		TaskRunName: "prev-task-run",
+		TaskRun: &v1alpha1.TaskRun{
+			Status: v1alpha1.TaskRunStatus{
+				Artifacts: []v1alpha1.ArtifactResult{{
+					Name:     "resource1",
+					Path:     "/pvc/prev-task/test-output",
+					Checksum: "sha256:abc",
+				}, {
+					Name:     "resource2",
+					Path:     "/pvc/prev-task/other-output",
+					Checksum: "sha256:def",
+				}},
+			},
+		},
+	}, {
+		// TaskRuns not created yet have no artifacts.
+		TaskRunName: "next-task-run",
+	}}
+	taskRunSpec := &v1alpha1.TaskRunSpec{
+		Inputs: v1alpha1.TaskRunInputs{
+			Resources: []v1alpha1.TaskResourceBinding{{
+				Name:        "test-input",
+				ResourceRef: v1alpha1.PipelineResourceRef{Name: "resource1"},
+				Paths:       []string{"/pvc/prev-task/test-output"},
+			}, {
+				Name:        "test-input-2",
+				ResourceRef: v1alpha1.PipelineResourceRef{Name: "resource1"},
+			}},
+		},
+	}
+
+	resources.AddInputArtifacts(taskRunSpec, state)
+
+	expectedtaskInputResources := []v1alpha1.TaskResourceBinding{{
+		Name:        "test-input",
+		ResourceRef: v1alpha1.PipelineResourceRef{Name: "resource1"},
+		Paths:       []string{"/pvc/prev-task/test-output"},
+		Artifacts: []v1alpha1.ArtifactResult{{
+			Name:     "resource1",
+			Path:     "/pvc/prev-task/test-output",
+			Checksum: "sha256:abc",
+		}},
+	}, {
+		Name:        "test-input-2",
+		ResourceRef: v1alpha1.PipelineResourceRef{Name: "resource1"},
+	}}
+	if d := cmp.Diff(expectedtaskInputResources, taskRunSpec.Inputs.Resources); d != "" {
+		t.Errorf("error comparing input resources: %s", d)
+	}
+}
+
+func TestEmbedResourceSpecs(t *testing.T) {
+	imageSpec := &v1alpha1.PipelineResourceSpec{
+		Type: v1alpha1.PipelineResourceTypeImage,
+		Params: []v1alpha1.Param{{
+			Name:  "url",
+			Value: "gcr.io/tekton/app",
+		}},
+	}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
+		"source": {
+			Name:        "source",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "source-repo"},
+		},
+		"image": {
+			Name:         "image",
+			ResourceSpec: imageSpec,
+		},
+	}
+	pt := &v1alpha1.PipelineTask{
+		Name: "test-task",
+		Resources: &v1alpha1.PipelineTaskResources{
+			Inputs: []v1alpha1.PipelineTaskInputResource{{
+				Name:     "workspace",
+				Resource: "source",
+			}, {
+				Name:     "base-image",
+				Resource: "image",
+				From:     []string{"prev-task"},
+			}},
+			Outputs: []v1alpha1.PipelineTaskOutputResource{{
+				Name:     "built-image",
+				Resource: "image",
+			}},
+		},
+	}
+	taskRunSpec := &v1alpha1.TaskRunSpec{
+		Inputs: v1alpha1.TaskRunInputs{
+			Resources: []v1alpha1.TaskResourceBinding{{
+				Name:        "workspace",
+				ResourceRef: v1alpha1.PipelineResourceRef{Name: "source-repo"},
+			}, {
+				Name:        "base-image",
+				ResourceRef: v1alpha1.PipelineResourceRef{Name: "image"},
+				Paths:       []string{"/pvc/prev-task/base-image"},
+			}},
+		},
+		Outputs: v1alpha1.TaskRunOutputs{
+			Resources: []v1alpha1.TaskResourceBinding{{
+				Name:        "built-image",
+				ResourceRef: v1alpha1.PipelineResourceRef{Name: "image"},
+				Paths:       []string{"/pvc/test-task/built-image"},
+			}},
+		},
+	}
+
+	resources.EmbedResourceSpecs(taskRunSpec, pt, providedResources)
+
+	expectedtaskInputResources := []v1alpha1.TaskResourceBinding{{
+		Name:        "workspace",
+		ResourceRef: v1alpha1.PipelineResourceRef{Name: "source-repo"},
+	}, {
+		Name:         "base-image",
+		ResourceSpec: imageSpec,
+		Paths:        []string{"/pvc/prev-task/base-image"},
+	}}
+	expectedtaskOuputResources := []v1alpha1.TaskResourceBinding{{
+		Name:         "built-image",
+		ResourceSpec: imageSpec,
+		Paths:        []string{"/pvc/test-task/built-image"},
+	}}
+	if d := cmp.Diff(expectedtaskInputResources, taskRunSpec.Inputs.Resources); d != "" {
+		t.Errorf("error comparing input resources: %s", d)
+	}
+	if d := cmp.Diff(expectedtaskOuputResources, taskRunSpec.Outputs.Resources); d != "" {
+		t.Errorf("error comparing output resources: %s", d)
+	}
+}
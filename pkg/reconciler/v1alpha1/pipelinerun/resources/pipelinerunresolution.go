@@ -126,14 +126,36 @@ func (state PipelineRunState) SuccessfulPipelineTaskNames() []string {
 	return done
 }
 
+// GetArtifactStorageStatus sums the sizes of the artifacts reported in the
+// statuses of the TaskRuns in state. It returns nil if no size was reported.
+func (state PipelineRunState) GetArtifactStorageStatus() *v1alpha1.ArtifactStorageStatus {
+	var status *v1alpha1.ArtifactStorageStatus
+	for _, t := range state {
+		if t.TaskRun == nil {
+			continue
+		}
+		for _, a := range t.TaskRun.Status.Artifacts {
+			if a.Size == 0 {
+				continue
+			}
+			if status == nil {
+				status = &v1alpha1.ArtifactStorageStatus{Resources: map[string]int64{}}
+			}
+			status.Size += a.Size
+			status.Resources[a.Name] += a.Size
+		}
+	}
+	return status
+}
+
 // GetTaskRun is a function that will retrieve the TaskRun name.
 type GetTaskRun func(name string) (*v1alpha1.TaskRun, error)
 
 // GetResourcesFromBindings will validate that all PipelineResources declared in Pipeline p are bound in PipelineRun pr
 // and if so, will return a map from the declared name of the PipelineResource (which is how the PipelineResource will
-// be referred to in the PipelineRun) to the ResourceRef.
-func GetResourcesFromBindings(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) (map[string]v1alpha1.PipelineResourceRef, error) {
-	resources := map[string]v1alpha1.PipelineResourceRef{}
+// be referred to in the PipelineRun) to the PipelineResourceBinding.
+func GetResourcesFromBindings(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) (map[string]v1alpha1.PipelineResourceBinding, error) {
+	resources := map[string]v1alpha1.PipelineResourceBinding{}
 
 	required := make([]string, 0, len(p.Spec.Resources))
 	for _, resource := range p.Spec.Resources {
@@ -149,12 +171,12 @@ func GetResourcesFromBindings(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) (m
 	}
 
 	for _, resource := range pr.Spec.Resources {
-		resources[resource.Name] = resource.ResourceRef
+		resources[resource.Name] = resource
 	}
 	return resources, nil
 }
 
-func getPipelineRunTaskResources(pt v1alpha1.PipelineTask, providedResources map[string]v1alpha1.PipelineResourceRef) ([]v1alpha1.TaskResourceBinding, []v1alpha1.TaskResourceBinding, error) {
+func getPipelineRunTaskResources(pt v1alpha1.PipelineTask, providedResources map[string]v1alpha1.PipelineResourceBinding) ([]v1alpha1.TaskResourceBinding, []v1alpha1.TaskResourceBinding, error) {
 	inputs, outputs := []v1alpha1.TaskResourceBinding{}, []v1alpha1.TaskResourceBinding{}
 	if pt.Resources != nil {
 		for _, taskInput := range pt.Resources.Inputs {
@@ -163,8 +185,9 @@ func getPipelineRunTaskResources(pt v1alpha1.PipelineTask, providedResources map
 				return inputs, outputs, xerrors.Errorf("pipelineTask tried to use input resource %s not present in declared resources", taskInput.Resource)
 			}
 			inputs = append(inputs, v1alpha1.TaskResourceBinding{
-				Name:        taskInput.Name,
-				ResourceRef: resource,
+				Name:         taskInput.Name,
+				ResourceRef:  resource.ResourceRef,
+				ResourceSpec: resource.ResourceSpec,
 			})
 		}
 		for _, taskOutput := range pt.Resources.Outputs {
@@ -173,14 +196,34 @@ func getPipelineRunTaskResources(pt v1alpha1.PipelineTask, providedResources map
 				return outputs, outputs, xerrors.Errorf("pipelineTask tried to use output resource %s not present in declared resources", taskOutput.Resource)
 			}
 			outputs = append(outputs, v1alpha1.TaskResourceBinding{
-				Name:        taskOutput.Name,
-				ResourceRef: resource,
+				Name:         taskOutput.Name,
+				ResourceRef:  resource.ResourceRef,
+				ResourceSpec: resource.ResourceSpec,
 			})
 		}
 	}
 	return inputs, outputs, nil
 }
 
+// nameEmbeddedResources names the PipelineResources embedded in the PipelineRun after
+// the PipelineResources declared by the Pipeline, so that the same embedded resource
+// can be recognized across the Tasks using it.
+func nameEmbeddedResources(pt v1alpha1.PipelineTask, rtr *resources.ResolvedTaskResources, providedResources map[string]v1alpha1.PipelineResourceBinding) {
+	if pt.Resources == nil {
+		return
+	}
+	for _, taskInput := range pt.Resources.Inputs {
+		if r, ok := rtr.Inputs[taskInput.Name]; ok && providedResources[taskInput.Resource].ResourceSpec != nil {
+			r.Name = taskInput.Resource
+		}
+	}
+	for _, taskOutput := range pt.Resources.Outputs {
+		if r, ok := rtr.Outputs[taskOutput.Name]; ok && providedResources[taskOutput.Resource].ResourceSpec != nil {
+			r.Name = taskOutput.Resource
+		}
+	}
+}
+
 // TaskNotFoundError indicates that the resolution failed because a referenced Task couldn't be retrieved
 type TaskNotFoundError struct {
 	Name string
@@ -212,7 +255,7 @@ func ResolvePipelineRun(
 	getClusterTask resources.GetClusterTask,
 	getResource resources.GetResource,
 	tasks []v1alpha1.PipelineTask,
-	providedResources map[string]v1alpha1.PipelineResourceRef,
+	providedResources map[string]v1alpha1.PipelineResourceBinding,
 ) (PipelineRunState, error) {
 
 	state := []*ResolvedPipelineRunTask{}
@@ -250,6 +293,7 @@ func ResolvePipelineRun(
 		if err != nil {
 			return nil, &ResourceNotFoundError{Msg: err.Error()}
 		}
+		nameEmbeddedResources(pt, rtr, providedResources)
 		rprt.ResolvedTaskResources = rtr
 
 		taskRun, err := getTaskRun(rprt.TaskRunName)
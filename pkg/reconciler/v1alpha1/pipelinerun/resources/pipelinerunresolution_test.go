@@ -752,6 +752,53 @@ func TestSuccessfulPipelineTaskNames(t *testing.T) {
 	}
 }
 
+func TestGetArtifactStorageStatus(t *testing.T) {
+	withArtifacts := func(artifacts ...v1alpha1.ArtifactResult) *v1alpha1.TaskRun {
+		tr := &v1alpha1.TaskRun{}
+		tr.Status.Artifacts = artifacts
+		return tr
+	}
+	tcs := []struct {
+		name     string
+		state    PipelineRunState
+		expected *v1alpha1.ArtifactStorageStatus
+	}{{
+		name:  "no-tasks-started",
+		state: noneStartedState,
+	}, {
+		name: "no-sizes-reported",
+		state: PipelineRunState{{
+			PipelineTask: &pts[0],
+			TaskRun:      withArtifacts(v1alpha1.ArtifactResult{Name: "source", Path: "/pvc/mytask1/source", Checksum: "sha256:abc"}),
+		}},
+	}, {
+		name: "sizes-reported",
+		state: PipelineRunState{{
+			PipelineTask: &pts[0],
+			TaskRun: withArtifacts(
+				v1alpha1.ArtifactResult{Name: "source", Path: "/pvc/mytask1/source", Size: 100},
+				v1alpha1.ArtifactResult{Name: "image", Path: "/pvc/mytask1/image", Size: 20},
+			),
+		}, {
+			PipelineTask: &pts[1],
+			TaskRun:      withArtifacts(v1alpha1.ArtifactResult{Name: "source", Path: "/pvc/mytask2/source", Size: 50}),
+		}, {
+			PipelineTask: &pts[2],
+		}},
+		expected: &v1alpha1.ArtifactStorageStatus{
+			Size:      170,
+			Resources: map[string]int64{"source": 150, "image": 20},
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.expected, tc.state.GetArtifactStorageStatus()); d != "" {
+				t.Errorf("Unexpected artifact storage status (-want +got): %s", d)
+			}
+		})
+	}
+}
+
 func TestGetPipelineConditionStatus(t *testing.T) {
 
 	var taskRetriedState = PipelineRunState{{
@@ -821,9 +868,12 @@ func TestGetResourcesFromBindings(t *testing.T) {
 	if err != nil {
 		t.Fatalf("didn't expect error getting resources from bindings but got: %v", err)
 	}
-	expectedResources := map[string]v1alpha1.PipelineResourceRef{
+	expectedResources := map[string]v1alpha1.PipelineResourceBinding{
 		"git-resource": {
-			Name: "sweet-resource",
+			Name: "git-resource",
+			ResourceRef: v1alpha1.PipelineResourceRef{
+				Name: "sweet-resource",
+			},
 		},
 	}
 	if d := cmp.Diff(expectedResources, m); d != "" {
@@ -873,9 +923,12 @@ func TestResolvePipelineRun(t *testing.T) {
 			tb.PipelineTaskOutputResource("output1", "git-resource"),
 		),
 	))
-	providedResources := map[string]v1alpha1.PipelineResourceRef{
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
 		"git-resource": {
-			Name: "someresource",
+			Name: "git-resource",
+			ResourceRef: v1alpha1.PipelineResourceRef{
+				Name: "someresource",
+			},
 		},
 	}
 
@@ -946,6 +999,84 @@ func TestResolvePipelineRun(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_EmbeddedResources(t *testing.T) {
+	names.TestingSeed()
+
+	p := tb.Pipeline("pipelines", "namespace", tb.PipelineSpec(
+		tb.PipelineDeclaredResource("git-resource", "git"),
+		tb.PipelineTask("mytask1", "task",
+			tb.PipelineTaskOutputResource("output1", "git-resource"),
+		),
+		tb.PipelineTask("mytask2", "task",
+			tb.PipelineTaskInputResource("input1", "git-resource", tb.From("mytask1")),
+		),
+	))
+	spec := &v1alpha1.PipelineResourceSpec{
+		Type: v1alpha1.PipelineResourceTypeGit,
+		Params: []v1alpha1.Param{{
+			Name:  "url",
+			Value: "https://github.com/tektoncd/pipeline",
+		}},
+	}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
+		"git-resource": {
+			Name:         "git-resource",
+			ResourceSpec: spec,
+		},
+	}
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun",
+		},
+	}
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
+	getTaskRun := func(name string) (*v1alpha1.TaskRun, error) { return nil, nil }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, nil }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) {
+		return nil, xerrors.Errorf("embedded resources shouldn't be retrieved but got %s", name)
+	}
+
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getResource, p.Spec.Tasks, providedResources)
+	if err != nil {
+		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
+	}
+	r := &v1alpha1.PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "git-resource",
+		},
+		Spec: *spec,
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask: &p.Spec.Tasks[0],
+		TaskRunName:  "pipelinerun-mytask1-9l9zj",
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskName: task.Name,
+			TaskSpec: &task.Spec,
+			Inputs:   map[string]*v1alpha1.PipelineResource{},
+			Outputs: map[string]*v1alpha1.PipelineResource{
+				"output1": r,
+			},
+		},
+	}, {
+		PipelineTask: &p.Spec.Tasks[1],
+		TaskRunName:  "pipelinerun-mytask2-mz4c7",
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskName: task.Name,
+			TaskSpec: &task.Spec,
+			Inputs: map[string]*v1alpha1.PipelineResource{
+				"input1": r,
+			},
+			Outputs: map[string]*v1alpha1.PipelineResource{},
+		},
+	}}
+	if d := cmp.Diff(pipelineState, expectedState, cmpopts.IgnoreUnexported(v1alpha1.TaskRunSpec{})); d != "" {
+		t.Errorf("Expected to get current pipeline state %v, but actual differed: %s", expectedState, d)
+	}
+	if err := ValidateFrom(pipelineState); err != nil {
+		t.Errorf("Didn't expect error validating from clauses of embedded resources but got: %v", err)
+	}
+}
+
 func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	pts := []v1alpha1.PipelineTask{{
 		Name:    "mytask1",
@@ -957,7 +1088,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 		Name:    "mytask3",
 		TaskRef: v1alpha1.TaskRef{Name: "task"},
 	}}
-	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{}
 
 	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
 	getTaskRun := func(name string) (*v1alpha1.TaskRun, error) { return &trs[0], nil }
@@ -996,7 +1127,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 		Name:    "mytask1",
 		TaskRef: v1alpha1.TaskRef{Name: "task"},
 	}}
-	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{}
 
 	// Return an error when the Task is retrieved, as if it didn't exist
 	getTask := func(name string) (v1alpha1.TaskInterface, error) {
@@ -1050,7 +1181,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 			)),
 		},
 	}
-	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{}
 
 	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
 	getTaskRun := func(name string) (*v1alpha1.TaskRun, error) { return &trs[0], nil }
@@ -1096,9 +1227,12 @@ func TestResolvePipelineRun_ResourcesDontExist(t *testing.T) {
 			)),
 		},
 	}
-	providedResources := map[string]v1alpha1.PipelineResourceRef{
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
 		"git-resource": {
-			Name: "doesnt-exist",
+			Name: "git-resource",
+			ResourceRef: v1alpha1.PipelineResourceRef{
+				Name: "doesnt-exist",
+			},
 		},
 	}
 
@@ -1313,9 +1447,12 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 			tb.PipelineTaskInputResource("input1", "git-resource"),
 		),
 	))
-	providedResources := map[string]v1alpha1.PipelineResourceRef{
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
 		"git-resource": {
-			Name: "someresource",
+			Name: "git-resource",
+			ResourceRef: v1alpha1.PipelineResourceRef{
+				Name: "someresource",
+			},
 		},
 	}
 
@@ -0,0 +1,212 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// maxCloudEventAttempts is the number of times the delivery of a CloudEvent
+// is attempted before it is marked as failed.
+const maxCloudEventAttempts = 5
+
+// CloudEventSender delivers the CloudEvents of finished TaskRuns from its own
+// workqueue, so that slow targets don't block the workers of the TaskRun
+// controller. Failed deliveries are retried with a backoff.
+type CloudEventSender struct {
+	client         *http.Client
+	pipelineclient clientset.Interface
+	queue          workqueue.RateLimitingInterface
+	logger         *zap.SugaredLogger
+}
+
+// NewCloudEventSender returns a CloudEventSender recording the deliveries in
+// the status of the TaskRuns with pipelineclient.
+func NewCloudEventSender(pipelineclient clientset.Interface, logger *zap.SugaredLogger) *CloudEventSender {
+	return &CloudEventSender{
+		client:         &http.Client{Timeout: cloudEventTimeout},
+		pipelineclient: pipelineclient,
+		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CloudEvents"),
+		logger:         logger,
+	}
+}
+
+// Enqueue schedules the delivery of the CloudEvents of tr which weren't
+// delivered yet, if any.
+func (s *CloudEventSender) Enqueue(tr *v1alpha1.TaskRun) {
+	if !hasPendingCloudEvents(tr) {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(tr)
+	if err != nil {
+		s.logger.Errorf("Failed to get the key of taskrun %s: %v", tr.Name, err)
+		return
+	}
+	s.queue.Add(key)
+}
+
+// Run delivers CloudEvents with workers goroutines until stopCh is closed.
+func (s *CloudEventSender) Run(workers int, stopCh <-chan struct{}) {
+	defer s.queue.ShutDown()
+	for i := 0; i < workers; i++ {
+		go wait.Until(s.runWorker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (s *CloudEventSender) runWorker() {
+	for s.processNextItem() {
+	}
+}
+
+// processNextItem delivers the CloudEvents of the next TaskRun of the queue,
+// and returns false once the queue is shut down.
+func (s *CloudEventSender) processNextItem() bool {
+	key, quit := s.queue.Get()
+	if quit {
+		return false
+	}
+	defer s.queue.Done(key)
+	if err := s.deliver(key.(string)); err != nil {
+		s.logger.Warnf("Failed to deliver the CloudEvents of taskrun %s: %v", key, err)
+		s.queue.AddRateLimited(key)
+		return true
+	}
+	s.queue.Forget(key)
+	return true
+}
+
+// deliver sends the pending CloudEvents of the TaskRun with key and records
+// their delivery in its status.
+func (s *CloudEventSender) deliver(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		s.logger.Errorf("Invalid resource key %s: %v", key, err)
+		return nil
+	}
+	// The TaskRun is read from the API server since the copy of the informer
+	// may not hold the deliveries recorded last yet.
+	tr, err := s.pipelineclient.TektonV1alpha1().TaskRuns(namespace).Get(name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !hasPendingCloudEvents(tr) {
+		return nil
+	}
+	sendErr := sendCloudEvents(s.client, tr, s.logger)
+	if _, err := s.pipelineclient.TektonV1alpha1().TaskRuns(namespace).UpdateStatus(tr); err != nil {
+		return xerrors.Errorf("failed to record the CloudEvent deliveries of taskrun %s: %w", key, err)
+	}
+	return sendErr
+}
+
+// hasPendingCloudEvents returns true if some CloudEvents of tr are neither
+// delivered nor given up on.
+func hasPendingCloudEvents(tr *v1alpha1.TaskRun) bool {
+	for _, delivery := range tr.Status.CloudEvents {
+		if delivery.Status.Condition == v1alpha1.CloudEventConditionUnknown {
+			return true
+		}
+	}
+	return false
+}
+
+// initializeCloudEvents records a CloudEvent to deliver to the target of every
+// cloud event output of the TaskRun, unless they were already recorded. The
+// outputs are resolved on their own with gr, before the Task, so that their
+// targets are notified even if the TaskRun fails to resolve or validate.
+func initializeCloudEvents(tr *v1alpha1.TaskRun, gr resources.GetResource, logger *zap.SugaredLogger) {
+	if len(tr.Status.CloudEvents) > 0 {
+		return
+	}
+	replacements := map[string]string{}
+	for _, p := range tr.Spec.Inputs.Params {
+		replacements[fmt.Sprintf("params.%s", p.Name)] = p.Value
+	}
+	for i := range tr.Spec.Outputs.Resources {
+		output := &tr.Spec.Outputs.Resources[i]
+		r, err := resources.GetResourceFromBinding(output, gr)
+		if err != nil || r.Spec.Type != v1alpha1.PipelineResourceTypeCloudEvent {
+			// Outputs which don't resolve fail the TaskRun later on.
+			continue
+		}
+		if r, err = resources.ApplyResourceReplacements(r, replacements); err != nil {
+			logger.Errorf("Invalid cloud event output %q of taskrun %s: %v", output.Name, tr.Name, err)
+			continue
+		}
+		ce, err := v1alpha1.NewCloudEventResource(r)
+		if err != nil {
+			logger.Errorf("Invalid cloud event output %q of taskrun %s: %v", output.Name, tr.Name, err)
+			continue
+		}
+		tr.Status.CloudEvents = append(tr.Status.CloudEvents, v1alpha1.CloudEventDelivery{
+			Target: ce.TargetURI,
+			Status: v1alpha1.CloudEventDeliveryState{
+				Condition: v1alpha1.CloudEventConditionUnknown,
+			},
+		})
+	}
+}
+
+// sendCloudEvents attempts to deliver the CloudEvents of the finished TaskRun
+// which weren't delivered yet, recording the outcome in its status. An error
+// is returned when some deliveries failed and will be retried.
+func sendCloudEvents(client *http.Client, tr *v1alpha1.TaskRun, logger *zap.SugaredLogger) error {
+	event := cloudevent.NewTaskRunEvent(tr)
+	pending := 0
+	for i := range tr.Status.CloudEvents {
+		delivery := &tr.Status.CloudEvents[i]
+		if delivery.Status.Condition != v1alpha1.CloudEventConditionUnknown {
+			continue
+		}
+		if err := cloudevent.Send(client, delivery.Target, event); err != nil {
+			delivery.Status.RetryCount++
+			delivery.Status.Error = err.Error()
+			if delivery.Status.RetryCount >= maxCloudEventAttempts {
+				logger.Errorf("Giving up sending the CloudEvent of taskrun %s to %s: %v", tr.Name, delivery.Target, err)
+				delivery.Status.Condition = v1alpha1.CloudEventConditionFailed
+			} else {
+				pending++
+			}
+			continue
+		}
+		now := metav1.Now()
+		delivery.Status.Condition = v1alpha1.CloudEventConditionSent
+		delivery.Status.SentAt = &now
+		delivery.Status.Error = ""
+	}
+	if pending > 0 {
+		return xerrors.Errorf("failed to send %d CloudEvents of taskrun %s, they will be retried", pending, tr.Name)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/logging"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestInitializeCloudEvents(t *testing.T) {
+	logger, _ := logging.NewLogger("", "")
+	tr := tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(
+		tb.TaskRunOutputs(
+			tb.TaskRunOutputsResource("notify", tb.TaskResourceBindingRef("ce")),
+			tb.TaskRunOutputsResource("image", tb.TaskResourceBindingRef("img")),
+		),
+	))
+	outputs := map[string]*v1alpha1.PipelineResource{
+		"ce": tb.PipelineResource("ce", "foo", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeCloudEvent,
+			tb.PipelineResourceSpecParam("targetURI", "http://sink.example.com"),
+		)),
+		"img": tb.PipelineResource("img", "foo", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeImage,
+			tb.PipelineResourceSpecParam("url", "gcr.io/foo/bar"),
+		)),
+	}
+	gr := func(name string) (*v1alpha1.PipelineResource, error) {
+		if r, ok := outputs[name]; ok {
+			return r, nil
+		}
+		return nil, xerrors.Errorf("resource %s not found", name)
+	}
+
+	initializeCloudEvents(tr, gr, logger)
+
+	want := []v1alpha1.CloudEventDelivery{{
+		Target: "http://sink.example.com",
+		Status: v1alpha1.CloudEventDeliveryState{Condition: v1alpha1.CloudEventConditionUnknown},
+	}}
+	if d := cmp.Diff(want, tr.Status.CloudEvents); d != "" {
+		t.Fatalf("-want, +got: %v", d)
+	}
+
+	// Deliveries already recorded are kept as they are.
+	tr.Status.CloudEvents[0].Status.RetryCount = 2
+	initializeCloudEvents(tr, gr, logger)
+	if len(tr.Status.CloudEvents) != 1 || tr.Status.CloudEvents[0].Status.RetryCount != 2 {
+		t.Fatalf("Expected the recorded delivery to be kept, got %v", tr.Status.CloudEvents)
+	}
+}
+
+func TestSendCloudEvents(t *testing.T) {
+	logger, _ := logging.NewLogger("", "")
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Ce-Type"))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	tr := tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(
+		tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}),
+	))
+	tr.Status.CloudEvents = []v1alpha1.CloudEventDelivery{{
+		Target: server.URL,
+		Status: v1alpha1.CloudEventDeliveryState{Condition: v1alpha1.CloudEventConditionUnknown, RetryCount: 1, Error: "boom"},
+	}, {
+		Target: server.URL + "/already-sent",
+		Status: v1alpha1.CloudEventDeliveryState{Condition: v1alpha1.CloudEventConditionSent},
+	}}
+
+	if err := sendCloudEvents(server.Client(), tr, logger); err != nil {
+		t.Fatalf("Unexpected error sending CloudEvents: %v", err)
+	}
+	if d := cmp.Diff([]string{"dev.tekton.event.taskrun.successful.v1"}, received); d != "" {
+		t.Errorf("Unexpected events received -want, +got: %v", d)
+	}
+	got := tr.Status.CloudEvents[0].Status
+	if got.Condition != v1alpha1.CloudEventConditionSent || got.SentAt == nil || got.Error != "" {
+		t.Errorf("Expected the delivery to be sent, got %+v", got)
+	}
+}
+
+func TestSendCloudEvents_Retry(t *testing.T) {
+	logger, _ := logging.NewLogger("", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tr := tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(
+		tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}),
+	))
+	tr.Status.CloudEvents = []v1alpha1.CloudEventDelivery{{
+		Target: server.URL,
+		Status: v1alpha1.CloudEventDeliveryState{Condition: v1alpha1.CloudEventConditionUnknown},
+	}}
+
+	if err := sendCloudEvents(server.Client(), tr, logger); err == nil {
+		t.Fatal("Expected an error so that the delivery is retried")
+	}
+	got := tr.Status.CloudEvents[0].Status
+	if got.Condition != v1alpha1.CloudEventConditionUnknown || got.RetryCount != 1 || got.Error == "" {
+		t.Errorf("Expected the delivery to be pending with one retry, got %+v", got)
+	}
+
+	tr.Status.CloudEvents[0].Status.RetryCount = maxCloudEventAttempts - 1
+	if err := sendCloudEvents(server.Client(), tr, logger); err != nil {
+		t.Fatalf("Expected no error after the last attempt, got %v", err)
+	}
+	got = tr.Status.CloudEvents[0].Status
+	if got.Condition != v1alpha1.CloudEventConditionFailed || got.RetryCount != maxCloudEventAttempts {
+		t.Errorf("Expected the delivery to have failed, got %+v", got)
+	}
+}
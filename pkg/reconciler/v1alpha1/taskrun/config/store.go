@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+
+	"github.com/ghodss/yaml"
+	"github.com/knative/pkg/configmap"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultsConfigName is the name of the configmap containing the
+	// cluster-wide defaults for TaskRuns.
+	DefaultsConfigName = "config-defaults"
+	// DefaultPodTemplateKey is the key holding the YAML PodTemplate used
+	// for the fields a TaskRun's pod template doesn't set.
+	DefaultPodTemplateKey = "default-pod-template"
+)
+
+// Defaults holds the cluster-wide defaults for TaskRuns.
+// +k8s:deepcopy-gen=false
+type Defaults struct {
+	DefaultPodTemplate *v1alpha1.PodTemplate
+}
+
+// NewDefaultsFromConfigMap creates a Defaults from the supplied ConfigMap.
+func NewDefaultsFromConfigMap(configMap *corev1.ConfigMap) (*Defaults, error) {
+	d := &Defaults{}
+	if tpl, ok := configMap.Data[DefaultPodTemplateKey]; ok && tpl != "" {
+		podTemplate := &v1alpha1.PodTemplate{}
+		if err := yaml.Unmarshal([]byte(tpl), podTemplate); err != nil {
+			return nil, xerrors.Errorf("failed to parse %s in configmap %s: %w", DefaultPodTemplateKey, DefaultsConfigName, err)
+		}
+		d.DefaultPodTemplate = podTemplate
+	}
+	return d, nil
+}
+
+type cfgKey struct{}
+
+// +k8s:deepcopy-gen=false
+type Config struct {
+	Defaults *Defaults
+}
+
+func FromContext(ctx context.Context) *Config {
+	return ctx.Value(cfgKey{}).(*Config)
+}
+
+func ToContext(ctx context.Context, c *Config) context.Context {
+	return context.WithValue(ctx, cfgKey{}, c)
+}
+
+// +k8s:deepcopy-gen=false
+type Store struct {
+	*configmap.UntypedStore
+}
+
+func NewStore(logger configmap.Logger) *Store {
+	return &Store{
+		UntypedStore: configmap.NewUntypedStore(
+			"taskrun",
+			logger,
+			configmap.Constructors{
+				DefaultsConfigName: NewDefaultsFromConfigMap,
+			},
+		),
+	}
+}
+
+func (s *Store) ToContext(ctx context.Context) context.Context {
+	return ToContext(ctx, s.Load())
+}
+
+func (s *Store) Load() *Config {
+	d := s.UntypedLoad(DefaultsConfigName)
+	if d == nil {
+		return &Config{Defaults: &Defaults{}}
+	}
+	defaults := d.(*Defaults)
+	return &Config{
+		Defaults: &Defaults{
+			DefaultPodTemplate: defaults.DefaultPodTemplate.DeepCopy(),
+		},
+	}
+}
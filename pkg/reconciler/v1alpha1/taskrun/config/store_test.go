@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	logtesting "github.com/knative/pkg/logging/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStoreLoadWithContext(t *testing.T) {
+	store := NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(test.ConfigMapFromTestFile(t, DefaultsConfigName))
+
+	config := FromContext(store.ToContext(context.Background()))
+
+	runAsNonRoot := true
+	expected := &v1alpha1.PodTemplate{
+		SecurityContext:   &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+		PriorityClassName: "tekton-builds",
+		ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+	}
+	if d := cmp.Diff(expected, config.Defaults.DefaultPodTemplate); d != "" {
+		t.Errorf("Unexpected default pod template (-want, +got): %v", d)
+	}
+}
+
+func TestStoreImmutableConfig(t *testing.T) {
+	store := NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(test.ConfigMapFromTestFile(t, DefaultsConfigName))
+
+	config := store.Load()
+	config.Defaults.DefaultPodTemplate.PriorityClassName = "mutated"
+
+	if store.Load().Defaults.DefaultPodTemplate.PriorityClassName == "mutated" {
+		t.Error("Controller config is not immutable")
+	}
+}
+
+func TestNewDefaultsFromConfigMapInvalid(t *testing.T) {
+	_, err := NewDefaultsFromConfigMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultsConfigName},
+		Data:       map[string]string{DefaultPodTemplateKey: "securityContext: [not, a, map]"},
+	})
+	if err == nil {
+		t.Error("Expected an error parsing an invalid default pod template")
+	}
+}
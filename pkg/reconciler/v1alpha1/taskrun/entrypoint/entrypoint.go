@@ -21,11 +21,6 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/google/go-containerregistry/pkg/authn"
-	"github.com/google/go-containerregistry/pkg/authn/k8schain"
-	"github.com/google/go-containerregistry/pkg/name"
-	v1 "github.com/google/go-containerregistry/pkg/v1"
-	"github.com/google/go-containerregistry/pkg/v1/remote"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"go.uber.org/zap"
@@ -56,27 +51,67 @@ var (
 
 // Cache is a simple caching mechanism allowing for caching the results of
 // getting the Entrypoint of a container image from a remote registry. The
-// internal lru cache is thread-safe.
+// internal lru cache is thread-safe. Images referenced by digest are also
+// saved to the optional persistent Store, so that they survive controller
+// restarts.
 type Cache struct {
-	lru *lru.Cache
+	lru      *lru.Cache
+	resolver Resolver
+	store    Store
+	offline  bool
+}
+
+// CacheOption configures how a Cache resolves images.
+type CacheOption func(*Cache)
+
+// WithResolver sets the Resolver used to look up images which are not
+// cached. It defaults to looking the image up in its registry.
+func WithResolver(r Resolver) CacheOption {
+	return func(c *Cache) {
+		c.resolver = r
+	}
+}
+
+// WithStore sets the persistent Store in which images referenced by digest
+// are saved.
+func WithStore(s Store) CacheOption {
+	return func(c *Cache) {
+		c.store = s
+	}
+}
+
+// WithOfflineMode disables looking up images which are not cached, so that
+// steps must either specify a command or use an image which has already
+// been resolved.
+func WithOfflineMode() CacheOption {
+	return func(c *Cache) {
+		c.offline = true
+	}
 }
 
 // NewCache is a simple helper function that returns a pointer to a Cache that
 // has had the internal fixed-sized lru cache initialized.
-func NewCache() (*Cache, error) {
+func NewCache(opts ...CacheOption) (*Cache, error) {
 	lru, err := lru.New(cacheSize)
-	return &Cache{lru}, err
+	c := &Cache{
+		lru:      lru,
+		resolver: &registryResolver{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, err
 }
 
 func (c *Cache) get(sha string) ([]string, bool) {
-	if ep, ok := c.lru.Get(sha); ok {
-		return ep.([]string), true
+	if img, ok := c.lru.Get(sha); ok {
+		return img.(*Image).Entrypoint, true
 	}
 	return nil, false
 }
 
 func (c *Cache) set(sha string, ep []string) {
-	c.lru.Add(sha, ep)
+	c.lru.Add(sha, &Image{Entrypoint: ep})
 }
 
 // AddToEntrypointCache adds an image digest and its entrypoint
@@ -85,6 +120,44 @@ func AddToEntrypointCache(c *Cache, sha string, ep []string) {
 	c.set(sha, ep)
 }
 
+// Resolve returns the digest and entrypoint of image. It looks in the
+// in-memory cache first, then in the persistent store for images referenced
+// by digest, and only then in the image's registry, unless the cache is
+// offline.
+func (c *Cache) Resolve(image string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) (*Image, error) {
+	if img, ok := c.lru.Get(image); ok {
+		recordLookup(sourceMemory)
+		return img.(*Image), nil
+	}
+	digest := getDigest(image)
+	if digest != "" && c.store != nil {
+		if img, ok := c.store.Get(digest); ok {
+			recordLookup(sourceStore)
+			c.lru.Add(image, img)
+			return img, nil
+		}
+	}
+	if c.offline {
+		recordLookup(sourceMiss)
+		return nil, xerrors.Errorf("entrypoint of image %s is not cached and registry lookups are disabled; "+
+			"specify the command of the step or use an image whose entrypoint is cached", image)
+	}
+
+	img, err := c.resolver.Resolve(image, kubeclient, taskRun)
+	if err != nil {
+		return nil, err
+	}
+	recordLookup(sourceRegistry)
+	c.lru.Add(image, img)
+	if pinned, err := pinnedReference(image, img.Digest); err == nil && img.Digest != "" {
+		c.lru.Add(pinned, img)
+	}
+	if c.store != nil && img.Digest != "" {
+		c.store.Set(img.Digest, img)
+	}
+	return img, nil
+}
+
 // AddCopyStep will prepend a Step (Container) that will
 // copy the entrypoint binary from the entrypoint image into the
 // volume mounted at MountPoint, so that it can be mounted by
@@ -133,11 +206,62 @@ func RedirectStep(cache *Cache, stepNum int, step *corev1.Container, kubeclient
 	}
 
 	step.Args = GetArgs(stepNum, step.Command, step.Args)
+	if debugArgs := getDebugArgs(taskRun.Spec.Debug, step.Name); len(debugArgs) > 0 {
+		step.Args = append(debugArgs, step.Args...)
+		// The probe succeeds only while the step is paused at a breakpoint,
+		// so the container's readiness reports the paused state.
+		step.ReadinessProbe = &corev1.Probe{
+			Handler: corev1.Handler{
+				Exec: &corev1.ExecAction{
+					Command: []string{BinaryLocation, "-paused", "-post_file", getPostFile(stepNum)},
+				},
+			},
+			PeriodSeconds: 5,
+		}
+	}
 	step.Command = []string{BinaryLocation}
 	step.VolumeMounts = append(step.VolumeMounts, toolsMount)
 	return nil
 }
 
+// getDebugArgs returns the entrypoint flags which pause the step named
+// stepName at the breakpoints in debug, or nil if it has none.
+func getDebugArgs(debug *v1alpha1.TaskRunDebug, stepName string) []string {
+	var args []string
+	if debug.PausesAfter(stepName) {
+		args = append(args, "-breakpoint")
+	} else if debug.PausesOnFailure() {
+		args = append(args, "-breakpoint_on_failure")
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	timeout := v1alpha1.DefaultDebugTimeout
+	if debug.Timeout != nil {
+		timeout = debug.Timeout.Duration
+	}
+	return append(args, "-debug_timeout", timeout.String())
+}
+
+// GetContinueCommand returns the command which resumes the step running in
+// container once it is paused at a breakpoint, or nil if the step has no
+// breakpoints.
+func GetContinueCommand(container corev1.Container) []string {
+	probe := container.ReadinessProbe
+	if probe == nil || probe.Exec == nil {
+		return nil
+	}
+	cmd := probe.Exec.Command
+	if len(cmd) != 4 || cmd[0] != BinaryLocation || cmd[1] != "-paused" {
+		return nil
+	}
+	return []string{BinaryLocation, "-continue", "-post_file", cmd[3]}
+}
+
+func getPostFile(stepNum int) string {
+	return fmt.Sprintf("%s/%s", MountPoint, strconv.Itoa(stepNum))
+}
+
 // GetArgs returns the arguments that should be specified for the step which has been wrapped
 // such that it will execute our custom entrypoint instead of the user provided Command and Args.
 func GetArgs(stepNum int, commands, args []string) []string {
@@ -154,7 +278,7 @@ func GetArgs(stepNum int, commands, args []string) []string {
 	}
 	argsForEntrypoint := append([]string{
 		"-wait_file", waitFile,
-		"-post_file", fmt.Sprintf("%s/%s", MountPoint, strconv.Itoa(stepNum)),
+		"-post_file", getPostFile(stepNum),
 		"-entrypoint"},
 		commands...,
 	)
@@ -167,48 +291,9 @@ func GetArgs(stepNum int, commands, args []string) []string {
 // to look for. If the cache does not contain the digest, it will lookup the
 // metadata from the images registry, and then commit that to the cache
 func GetRemoteEntrypoint(cache *Cache, digest string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) ([]string, error) {
-	if ep, ok := cache.get(digest); ok {
-		return ep, nil
-	}
-	img, err := getRemoteImage(digest, kubeclient, taskRun)
-	if err != nil {
-		return nil, xerrors.Errorf("Failed to fetch remote image %s: %w", digest, err)
-	}
-	cfg, err := img.ConfigFile()
+	img, err := cache.Resolve(digest, kubeclient, taskRun)
 	if err != nil {
-		return nil, xerrors.Errorf("Failed to get config for image %s: %w", digest, err)
+		return nil, err
 	}
-	command := cfg.Config.Entrypoint
-	if len(command) == 0 {
-		command = cfg.Config.Cmd
-	}
-	cache.set(digest, command)
-	return command, nil
-}
-
-func getRemoteImage(image string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) (v1.Image, error) {
-	// verify the image name, then download the remote config file
-	ref, err := name.ParseReference(image, name.WeakValidation)
-	if err != nil {
-		return nil, xerrors.Errorf("Failed to parse image %s: %w", image, err)
-	}
-
-	kc, err := k8schain.New(kubeclient, k8schain.Options{
-		Namespace:          taskRun.Namespace,
-		ServiceAccountName: taskRun.Spec.ServiceAccount,
-	})
-	if err != nil {
-		return nil, xerrors.Errorf("Failed to create k8schain: %w", err)
-	}
-
-	// this will first try to authenticate using the k8schain,
-	// then fall back to the google keychain,
-	// then fall back to anonymous
-	mkc := authn.NewMultiKeychain(kc)
-	img, err := remote.Image(ref, remote.WithAuthFromKeychain(mkc))
-	if err != nil {
-		return nil, xerrors.Errorf("Failed to get container image info from registry %s: %w", image, err)
-	}
-
-	return img, nil
+	return img.Entrypoint, nil
 }
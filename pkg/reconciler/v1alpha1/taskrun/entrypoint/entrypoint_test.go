@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -19,6 +20,7 @@ import (
 	"golang.org/x/xerrors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -77,6 +79,66 @@ func TestRewriteSteps(t *testing.T) {
 	}
 }
 
+func TestRedirectStepWithDebug(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		debug       *v1alpha1.TaskRunDebug
+		stepName    string
+		wantArgs    []string
+		wantPausing bool
+	}{{
+		name:     "no debug",
+		stepName: "build",
+		wantArgs: []string{"-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"},
+	}, {
+		name:        "breakpoint after step",
+		debug:       &v1alpha1.TaskRunDebug{Breakpoints: []string{"build"}},
+		stepName:    "build",
+		wantArgs:    []string{"-breakpoint", "-debug_timeout", "1h0m0s", "-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"},
+		wantPausing: true,
+	}, {
+		name: "breakpoint on failure",
+		debug: &v1alpha1.TaskRunDebug{
+			Breakpoints: []string{v1alpha1.BreakpointOnFailure},
+			Timeout:     &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		stepName:    "build",
+		wantArgs:    []string{"-breakpoint_on_failure", "-debug_timeout", "5m0s", "-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"},
+		wantPausing: true,
+	}, {
+		name:     "breakpoint on another step",
+		debug:    &v1alpha1.TaskRunDebug{Breakpoints: []string{"push"}},
+		stepName: "build",
+		wantArgs: []string{"-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := &v1alpha1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "taskRun"},
+				Spec:       v1alpha1.TaskRunSpec{Debug: tc.debug},
+			}
+			step := &corev1.Container{Name: tc.stepName, Image: "ubuntu", Command: []string{"echo"}}
+			entrypointCache, _ := NewCache()
+			if err := RedirectStep(entrypointCache, 0, step, fakekubeclientset.NewSimpleClientset(), taskRun, zap.NewNop().Sugar()); err != nil {
+				t.Fatalf("RedirectStep() = %v", err)
+			}
+			if d := cmp.Diff(tc.wantArgs, step.Args); d != "" {
+				t.Errorf("args diff -want, +got: %v", d)
+			}
+			gotContinue := GetContinueCommand(*step)
+			if !tc.wantPausing {
+				if step.ReadinessProbe != nil || gotContinue != nil {
+					t.Errorf("expected no breakpoint probe, got %v", step.ReadinessProbe)
+				}
+				return
+			}
+			wantContinue := []string{BinaryLocation, "-continue", "-post_file", "/builder/tools/0"}
+			if d := cmp.Diff(wantContinue, gotContinue); d != "" {
+				t.Errorf("continue command diff -want, +got: %v", d)
+			}
+		})
+	}
+}
+
 func TestGetArgs(t *testing.T) {
 	// first step
 	// multiple commands
@@ -342,6 +404,76 @@ func TestGetRemoteEntrypointWithNonDefaultSA(t *testing.T) {
 	}
 }
 
+type fakeResolver struct {
+	img   *Image
+	calls int
+}
+
+func (f *fakeResolver) Resolve(image string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) (*Image, error) {
+	f.calls++
+	return f.img, nil
+}
+
+func TestCacheResolveWithStore(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	pinned := "gcr.io/foo/bar@" + digest
+	resolver := &fakeResolver{img: &Image{Digest: digest, Entrypoint: []string{"/bin/run"}}}
+	store, c, sync := newTestConfigMapStore(t)
+	taskRun := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "taskRun"}}
+
+	first, err := NewCache(WithResolver(resolver), WithStore(store))
+	if err != nil {
+		t.Fatalf("couldn't create new entrypoint cache: %v", err)
+	}
+	if _, err := first.Resolve("gcr.io/foo/bar:latest", c, taskRun); err != nil {
+		t.Fatalf("Resolve() = %v", err)
+	}
+
+	// A new cache, e.g. after a controller restart, finds the image in the
+	// store when it is referenced by digest.
+	sync()
+	second, err := NewCache(WithResolver(resolver), WithStore(store), WithOfflineMode())
+	if err != nil {
+		t.Fatalf("couldn't create new entrypoint cache: %v", err)
+	}
+	img, err := second.Resolve(pinned, c, taskRun)
+	if err != nil {
+		t.Fatalf("Resolve() = %v", err)
+	}
+	if d := cmp.Diff(resolver.img, img); d != "" {
+		t.Errorf("resolved image diff -want, +got: %v", d)
+	}
+	if resolver.calls != 1 {
+		t.Errorf("expected the registry to be contacted once, got %d", resolver.calls)
+	}
+}
+
+func TestCacheResolveOffline(t *testing.T) {
+	resolver := &fakeResolver{img: &Image{Entrypoint: []string{"/bin/run"}}}
+	entrypointCache, err := NewCache(WithResolver(resolver), WithOfflineMode())
+	if err != nil {
+		t.Fatalf("couldn't create new entrypoint cache: %v", err)
+	}
+	taskRun := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "taskRun"}}
+	c := fakekubeclientset.NewSimpleClientset()
+
+	if _, err := entrypointCache.Resolve("gcr.io/foo/bar:latest", c, taskRun); err == nil {
+		t.Error("expected an error resolving an uncached image in offline mode")
+	}
+	if resolver.calls != 0 {
+		t.Errorf("expected the registry not to be contacted, got %d calls", resolver.calls)
+	}
+
+	AddToEntrypointCache(entrypointCache, "gcr.io/foo/bar:latest", []string{"/bin/run"})
+	ep, err := GetRemoteEntrypoint(entrypointCache, "gcr.io/foo/bar:latest", c, taskRun)
+	if err != nil {
+		t.Fatalf("GetRemoteEntrypoint() = %v", err)
+	}
+	if d := cmp.Diff([]string{"/bin/run"}, ep); d != "" {
+		t.Errorf("entrypoint diff -want, +got: %v", d)
+	}
+}
+
 func TestEntrypointCacheLRU(t *testing.T) {
 	entrypoint := []string{"/bin/expected", "entrypoint"}
 	entrypointCache, err := NewCache()
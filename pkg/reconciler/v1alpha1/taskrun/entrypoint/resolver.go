@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Image holds what the controller needs to know about a container image.
+type Image struct {
+	// Digest is the digest of the image's manifest, e.g. "sha256:deadbeef".
+	Digest string `json:"digest"`
+	// Entrypoint is the image's entrypoint, or its command if it has no
+	// entrypoint.
+	Entrypoint []string `json:"entrypoint"`
+}
+
+// Resolver looks up the digest and entrypoint of a container image.
+type Resolver interface {
+	Resolve(image string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) (*Image, error)
+}
+
+// registryResolver looks images up in their registry, authenticating with
+// the secrets of the TaskRun's service account.
+type registryResolver struct{}
+
+func (*registryResolver) Resolve(image string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) (*Image, error) {
+	img, err := getRemoteImage(image, kubeclient, taskRun)
+	if err != nil {
+		return nil, xerrors.Errorf("Failed to fetch remote image %s: %w", image, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, xerrors.Errorf("Failed to get digest for image %s: %w", image, err)
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, xerrors.Errorf("Failed to get config for image %s: %w", image, err)
+	}
+	command := cfg.Config.Entrypoint
+	if len(command) == 0 {
+		command = cfg.Config.Cmd
+	}
+	return &Image{
+		Digest:     digest.String(),
+		Entrypoint: command,
+	}, nil
+}
+
+func getRemoteImage(image string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) (v1.Image, error) {
+	// verify the image name, then download the remote config file
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return nil, xerrors.Errorf("Failed to parse image %s: %w", image, err)
+	}
+
+	kc, err := k8schain.New(kubeclient, k8schain.Options{
+		Namespace:          taskRun.Namespace,
+		ServiceAccountName: taskRun.Spec.ServiceAccount,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("Failed to create k8schain: %w", err)
+	}
+
+	// this will first try to authenticate using the k8schain,
+	// then fall back to the google keychain,
+	// then fall back to anonymous
+	mkc := authn.NewMultiKeychain(kc)
+	img, err := remote.Image(ref, remote.WithAuthFromKeychain(mkc))
+	if err != nil {
+		return nil, xerrors.Errorf("Failed to get container image info from registry %s: %w", image, err)
+	}
+
+	return img, nil
+}
+
+// PinImage returns image referenced by digest, e.g. gcr.io/foo/bar@sha256:...,
+// looking up the digest of images referenced by tag.
+func PinImage(cache *Cache, image string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) (string, error) {
+	if getDigest(image) != "" {
+		return image, nil
+	}
+	img, err := cache.Resolve(image, kubeclient, taskRun)
+	if err != nil {
+		return "", err
+	}
+	if img.Digest == "" {
+		return "", xerrors.Errorf("digest of image %s is unknown", image)
+	}
+	return pinnedReference(image, img.Digest)
+}
+
+func pinnedReference(image, digest string) (string, error) {
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return "", xerrors.Errorf("Failed to parse image %s: %w", image, err)
+	}
+	return ref.Context().Name() + "@" + digest, nil
+}
+
+// getDigest returns the digest image is pinned to, or "" if image is
+// referenced by tag.
+func getDigest(image string) string {
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return ""
+	}
+	if d, ok := ref.(name.Digest); ok {
+		return d.DigestStr()
+	}
+	return ""
+}
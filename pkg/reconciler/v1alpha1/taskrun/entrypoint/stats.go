@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+
+	"github.com/knative/pkg/metrics"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Where the entrypoint of an image was found.
+const (
+	sourceMemory   = "memory"
+	sourceStore    = "store"
+	sourceRegistry = "registry"
+	// sourceMiss is recorded when an image isn't cached and the cache is
+	// offline.
+	sourceMiss = "miss"
+)
+
+var (
+	lookupCountStat = stats.Int64("entrypoint_lookup_count", "Number of image entrypoint lookups", stats.UnitNone)
+
+	sourceTagKey = mustNewTagKey("source")
+)
+
+func init() {
+	if err := view.Register(&view.View{
+		Description: "Number of image entrypoint lookups by where the entrypoint was found",
+		Measure:     lookupCountStat,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{sourceTagKey},
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// recordLookup counts a lookup whose entrypoint was found in source.
+func recordLookup(source string) {
+	ctx, err := tag.New(context.Background(), tag.Insert(sourceTagKey, source))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, lookupCountStat.M(1))
+}
+
+func mustNewTagKey(s string) tag.Key {
+	tagKey, err := tag.NewKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return tagKey
+}
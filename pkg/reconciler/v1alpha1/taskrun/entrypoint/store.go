@@ -0,0 +1,181 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// maxConfigMapStoreSize is the size of the entries above which the
+// ConfigMapStore evicts the oldest ones, well below the 1MiB limit on the
+// size of a ConfigMap.
+const maxConfigMapStoreSize = 512 * 1024
+
+// Store persists resolved images, keyed by their digest. Since an image's
+// digest identifies its content, stored entries never go stale.
+type Store interface {
+	// Get returns the image with the given digest, if it was stored.
+	Get(digest string) (*Image, bool)
+	// Set stores the image under the given digest.
+	Set(digest string, img *Image)
+}
+
+// ConfigMapStore is a Store which saves images in a ConfigMap, so that they
+// are shared by every replica of the controller and survive restarts.
+// Failures to read or write the ConfigMap are logged and otherwise treated
+// as cache misses. The oldest entries are evicted once the ConfigMap grows
+// above maxSize.
+type ConfigMapStore struct {
+	kubeclient kubernetes.Interface
+	lister     corev1listers.ConfigMapLister
+	namespace  string
+	name       string
+	logger     *zap.SugaredLogger
+	maxSize    int
+	now        func() time.Time
+
+	// mu serializes writes so that concurrent reconciles of this
+	// controller don't overwrite each other's entries.
+	mu sync.Mutex
+}
+
+var _ Store = (*ConfigMapStore)(nil)
+
+// storedImage is the value of an entry of the ConfigMap.
+type storedImage struct {
+	Image
+	// Stored is when the entry was written, in seconds since the epoch.
+	Stored int64 `json:"stored,omitempty"`
+}
+
+// NewConfigMapStore returns a Store backed by the ConfigMap name in
+// namespace, which is read through lister. The ConfigMap is created on the
+// first write if it doesn't exist.
+func NewConfigMapStore(kubeclient kubernetes.Interface, lister corev1listers.ConfigMapLister, namespace, name string, logger *zap.SugaredLogger) *ConfigMapStore {
+	return &ConfigMapStore{
+		kubeclient: kubeclient,
+		lister:     lister,
+		namespace:  namespace,
+		name:       name,
+		logger:     logger,
+		maxSize:    maxConfigMapStoreSize,
+		now:        time.Now,
+	}
+}
+
+// Get returns the image with the given digest from the ConfigMap.
+func (s *ConfigMapStore) Get(digest string) (*Image, bool) {
+	cm, err := s.lister.ConfigMaps(s.namespace).Get(s.name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			s.logger.Errorf("Failed to get entrypoint cache ConfigMap %s/%s: %v", s.namespace, s.name, err)
+		}
+		return nil, false
+	}
+	value, ok := cm.Data[configMapKey(digest)]
+	if !ok {
+		return nil, false
+	}
+	stored := &storedImage{}
+	if err := json.Unmarshal([]byte(value), stored); err != nil {
+		s.logger.Errorf("Failed to parse cached entrypoint of image %s: %v", digest, err)
+		return nil, false
+	}
+	return &stored.Image, true
+}
+
+// Set saves the image under the given digest in the ConfigMap.
+func (s *ConfigMapStore) Set(digest string, img *Image) {
+	value, err := json.Marshal(&storedImage{Image: *img, Stored: s.now().Unix()})
+	if err != nil {
+		s.logger.Errorf("Failed to serialize entrypoint of image %s: %v", digest, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	configMaps := s.kubeclient.CoreV1().ConfigMaps(s.namespace)
+	cm, err := configMaps.Get(s.name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = configMaps.Create(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.name,
+				Namespace: s.namespace,
+			},
+			Data: map[string]string{configMapKey(digest): string(value)},
+		})
+	} else if err == nil {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[configMapKey(digest)] = string(value)
+		s.evict(cm.Data)
+		_, err = configMaps.Update(cm)
+	}
+	if err != nil {
+		s.logger.Errorf("Failed to store entrypoint of image %s in ConfigMap %s/%s: %v", digest, s.namespace, s.name, err)
+	}
+}
+
+// evict deletes the oldest entries of data until its size is at most
+// maxSize. Entries which can't be parsed are evicted first.
+func (s *ConfigMapStore) evict(data map[string]string) {
+	size := 0
+	keys := make([]string, 0, len(data))
+	stored := make(map[string]int64, len(data))
+	for k, v := range data {
+		size += len(k) + len(v)
+		keys = append(keys, k)
+		entry := &storedImage{}
+		if err := json.Unmarshal([]byte(v), entry); err == nil {
+			stored[k] = entry.Stored
+		}
+	}
+	if size <= s.maxSize {
+		return
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if stored[keys[i]] != stored[keys[j]] {
+			return stored[keys[i]] < stored[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	for _, k := range keys {
+		if size <= s.maxSize {
+			break
+		}
+		size -= len(k) + len(data[k])
+		delete(data, k)
+	}
+}
+
+// configMapKey turns a digest such as "sha256:deadbeef" into a valid
+// ConfigMap key.
+func configMapKey(digest string) string {
+	return strings.Replace(digest, ":", "-", 1)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// newTestConfigMapStore returns a ConfigMapStore, and a function updating its
+// lister with the ConfigMap written to the client as an informer would.
+func newTestConfigMapStore(t *testing.T) (*ConfigMapStore, *fakekubeclientset.Clientset, func()) {
+	t.Helper()
+	c := fakekubeclientset.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	store := NewConfigMapStore(c, corev1listers.NewConfigMapLister(indexer), "tekton-pipelines", "entrypoint-cache", zap.NewNop().Sugar())
+	sync := func() {
+		cm, err := c.CoreV1().ConfigMaps("tekton-pipelines").Get("entrypoint-cache", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected the ConfigMap to be created: %v", err)
+		}
+		if err := indexer.Update(cm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store, c, sync
+}
+
+func TestConfigMapStore(t *testing.T) {
+	store, c, sync := newTestConfigMapStore(t)
+
+	if _, ok := store.Get("sha256:1234"); ok {
+		t.Fatal("expected a miss before the ConfigMap exists")
+	}
+
+	first := &Image{Digest: "sha256:1234", Entrypoint: []string{"/bin/first"}}
+	second := &Image{Digest: "sha256:5678", Entrypoint: []string{"/bin/second"}}
+	store.Set(first.Digest, first)
+	store.Set(second.Digest, second)
+
+	if _, ok := store.Get(first.Digest); ok {
+		t.Fatal("expected a miss before the lister is updated")
+	}
+	sync()
+	for _, want := range []*Image{first, second} {
+		got, ok := store.Get(want.Digest)
+		if !ok {
+			t.Fatalf("expected %s to be stored", want.Digest)
+		}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("stored image diff -want, +got: %v", d)
+		}
+	}
+
+	cm, err := c.CoreV1().ConfigMaps("tekton-pipelines").Get("entrypoint-cache", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the ConfigMap to be created: %v", err)
+	}
+	if _, ok := cm.Data["sha256-1234"]; !ok {
+		t.Errorf("expected key sha256-1234 in %v", cm.Data)
+	}
+}
+
+func TestConfigMapStoreEvictsOldestEntries(t *testing.T) {
+	store, c, sync := newTestConfigMapStore(t)
+	store.maxSize = 400
+	now := time.Unix(1560000000, 0)
+	store.now = func() time.Time { return now }
+
+	var images []*Image
+	for i := 0; i < 10; i++ {
+		img := &Image{Digest: fmt.Sprintf("sha256:%04d", i), Entrypoint: []string{"/bin/entrypoint"}}
+		store.Set(img.Digest, img)
+		images = append(images, img)
+		now = now.Add(time.Second)
+	}
+
+	cm, err := c.CoreV1().ConfigMaps("tekton-pipelines").Get("entrypoint-cache", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the ConfigMap to be created: %v", err)
+	}
+	size := 0
+	for k, v := range cm.Data {
+		size += len(k) + len(v)
+	}
+	if size > store.maxSize {
+		t.Errorf("expected the entries to be at most %d bytes, got %d", store.maxSize, size)
+	}
+
+	sync()
+	if _, ok := store.Get(images[0].Digest); ok {
+		t.Errorf("expected the oldest entry %s to be evicted", images[0].Digest)
+	}
+	if _, ok := store.Get(images[len(images)-1].Digest); !ok {
+		t.Errorf("expected the newest entry %s to be kept", images[len(images)-1].Digest)
+	}
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/templating"
+	"golang.org/x/xerrors"
 )
 
 // ApplyParameters applies the params from a TaskRun.Input.Parameters to a TaskSpec
@@ -41,6 +44,51 @@ func ApplyParameters(spec *v1alpha1.TaskSpec, tr *v1alpha1.TaskRun, defaults ...
 	return ApplyReplacements(spec, replacements)
 }
 
+// ApplyResourceParameters resolves the references to the params of the TaskRun, e.g.
+// ${params.revision}, in the params of the resources resolved for it.
+func ApplyResourceParameters(rtr *ResolvedTaskResources, tr *v1alpha1.TaskRun, defaults ...v1alpha1.TaskParam) error {
+	replacements := map[string]string{}
+	for _, p := range defaults {
+		if p.Default != "" {
+			replacements[fmt.Sprintf("params.%s", p.Name)] = p.Default
+		}
+	}
+	for _, p := range tr.Spec.Inputs.Params {
+		replacements[fmt.Sprintf("params.%s", p.Name)] = p.Value
+	}
+
+	for _, resources := range []map[string]*v1alpha1.PipelineResource{rtr.Inputs, rtr.Outputs} {
+		for name, r := range resources {
+			resolved, err := ApplyResourceReplacements(r, replacements)
+			if err != nil {
+				return xerrors.Errorf("couldn't resolve params of PipelineResource %q: %w", name, err)
+			}
+			resources[name] = resolved
+		}
+	}
+	return nil
+}
+
+// ApplyResourceReplacements replaces the references to params in the params of the
+// PipelineResource with the specified replacements and checks the resulting spec is
+// valid. The PipelineResource is returned as is if it doesn't reference any params.
+func ApplyResourceReplacements(r *v1alpha1.PipelineResource, replacements map[string]string) (*v1alpha1.PipelineResource, error) {
+	if len(r.Spec.ParamReferences()) == 0 {
+		return r, nil
+	}
+	r = r.DeepCopy()
+	for i, p := range r.Spec.Params {
+		r.Spec.Params[i].Value = templating.ApplyReplacements(p.Value, replacements)
+	}
+	if refs := r.Spec.ParamReferences(); len(refs) > 0 {
+		return nil, xerrors.Errorf("params %s aren't provided", strings.Join(refs, ", "))
+	}
+	if err := r.Spec.Validate(context.Background()); err != nil {
+		return nil, xerrors.Errorf("invalid spec: %w", err)
+	}
+	return r, nil
+}
+
 // ApplyResources applies the templating from values in resources which are referenced in spec as subitems
 // of the replacementStr.
 func ApplyResources(spec *v1alpha1.TaskSpec, resolvedResources map[string]v1alpha1.PipelineResourceInterface, replacementStr string) *v1alpha1.TaskSpec {
@@ -96,7 +96,7 @@ var gitResource, _ = v1alpha1.ResourceFromType(&v1alpha1.PipelineResource{
 			},
 		},
 	},
-})
+}, nil)
 
 var imageResource, _ = v1alpha1.ResourceFromType(&v1alpha1.PipelineResource{
 	ObjectMeta: metav1.ObjectMeta{
@@ -111,7 +111,7 @@ var imageResource, _ = v1alpha1.ResourceFromType(&v1alpha1.PipelineResource{
 			},
 		},
 	},
-})
+}, nil)
 
 var gcsResource, _ = v1alpha1.ResourceFromType(&v1alpha1.PipelineResource{
 	ObjectMeta: metav1.ObjectMeta{
@@ -130,7 +130,7 @@ var gcsResource, _ = v1alpha1.ResourceFromType(&v1alpha1.PipelineResource{
 			},
 		},
 	},
-})
+}, nil)
 
 func applyMutation(ts *v1alpha1.TaskSpec, f func(*v1alpha1.TaskSpec)) *v1alpha1.TaskSpec {
 	ts = ts.DeepCopy()
@@ -209,6 +209,98 @@ func TestApplyParameters(t *testing.T) {
 	}
 }
 
+func TestApplyResourceParameters(t *testing.T) {
+	gitResource := func(revision string) *v1alpha1.PipelineResource {
+		return &v1alpha1.PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "source"},
+			Spec: v1alpha1.PipelineResourceSpec{
+				Type: v1alpha1.PipelineResourceTypeGit,
+				Params: []v1alpha1.Param{{
+					Name:  "url",
+					Value: "https://github.com/tektoncd/pipeline",
+				}, {
+					Name:  "revision",
+					Value: revision,
+				}},
+			},
+		}
+	}
+	imageResource := func(url string) *v1alpha1.PipelineResource {
+		return &v1alpha1.PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "image"},
+			Spec: v1alpha1.PipelineResourceSpec{
+				Type: v1alpha1.PipelineResourceTypeImage,
+				Params: []v1alpha1.Param{{
+					Name:  "url",
+					Value: url,
+				}},
+			},
+		}
+	}
+	rtr := &ResolvedTaskResources{
+		Inputs:  map[string]*v1alpha1.PipelineResource{"source": gitResource("${params.revision}")},
+		Outputs: map[string]*v1alpha1.PipelineResource{"image": imageResource("gcr.io/${params.project}/app")},
+	}
+	tr := &v1alpha1.TaskRun{
+		Spec: v1alpha1.TaskRunSpec{
+			Inputs: v1alpha1.TaskRunInputs{
+				Params: []v1alpha1.Param{{
+					Name:  "revision",
+					Value: "v0.5.0",
+				}},
+			},
+		},
+	}
+	if err := ApplyResourceParameters(rtr, tr, v1alpha1.TaskParam{Name: "project", Default: "tekton"}); err != nil {
+		t.Fatalf("Unexpected error resolving params of resources: %v", err)
+	}
+	want := &ResolvedTaskResources{
+		Inputs:  map[string]*v1alpha1.PipelineResource{"source": gitResource("v0.5.0")},
+		Outputs: map[string]*v1alpha1.PipelineResource{"image": imageResource("gcr.io/tekton/app")},
+	}
+	if d := cmp.Diff(want, rtr); d != "" {
+		t.Errorf("ApplyResourceParameters() diff %s", d)
+	}
+}
+
+func TestApplyResourceParameters_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		param v1alpha1.Param
+	}{{
+		name:  "param not provided",
+		param: v1alpha1.Param{Name: "revision", Value: "${params.revision}"},
+	}, {
+		name:  "invalid resolved param",
+		param: v1alpha1.Param{Name: "depth", Value: "${params.depth}"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rtr := &ResolvedTaskResources{
+				Inputs: map[string]*v1alpha1.PipelineResource{"source": {
+					ObjectMeta: metav1.ObjectMeta{Name: "source"},
+					Spec: v1alpha1.PipelineResourceSpec{
+						Type:   v1alpha1.PipelineResourceTypeGit,
+						Params: []v1alpha1.Param{tc.param},
+					},
+				}},
+			}
+			tr := &v1alpha1.TaskRun{
+				Spec: v1alpha1.TaskRunSpec{
+					Inputs: v1alpha1.TaskRunInputs{
+						Params: []v1alpha1.Param{{
+							Name:  "depth",
+							Value: "shallow",
+						}},
+					},
+				},
+			}
+			if err := ApplyResourceParameters(rtr, tr); err == nil {
+				t.Errorf("Expected error resolving params of resources but got none")
+			}
+		})
+	}
+}
+
 func TestApplyResources(t *testing.T) {
 	type args struct {
 		ts   *v1alpha1.TaskSpec
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// artifactCopyToContainerPrefix is the prefix of the name of the containers
+// copying output resources to the artifact storage, which report the
+// checksum of the archive they stored in their termination message.
+const artifactCopyToContainerPrefix = containerPrefix + "artifact-copy-to-"
+
+// UpdateTaskRunStatusWithArtifacts adds the artifacts reported by the
+// artifact copy containers of pod which have terminated to the TaskRun status
+func UpdateTaskRunStatusWithArtifacts(taskRun *v1alpha1.TaskRun, pod *corev1.Pod) error {
+	for _, s := range pod.Status.ContainerStatuses {
+		if !strings.HasPrefix(s.Name, artifactCopyToContainerPrefix) || s.State.Terminated == nil || s.State.Terminated.Message == "" {
+			continue
+		}
+		var artifacts []v1alpha1.ArtifactResult
+		if err := json.Unmarshal([]byte(s.State.Terminated.Message), &artifacts); err != nil {
+			return xerrors.Errorf("Failed to unmarshal the artifacts reported by %s: %w", s.Name, err)
+		}
+		mergeArtifacts(taskRun, artifacts)
+	}
+	return nil
+}
+
+// mergeArtifacts adds artifacts to the TaskRun status, replacing the existing
+// artifacts stored at the same path.
+func mergeArtifacts(taskRun *v1alpha1.TaskRun, artifacts []v1alpha1.ArtifactResult) {
+	for _, a := range artifacts {
+		found := false
+		for i, existing := range taskRun.Status.Artifacts {
+			if existing.Path == a.Path {
+				taskRun.Status.Artifacts[i] = a
+				found = true
+				break
+			}
+		}
+		if !found {
+			taskRun.Status.Artifacts = append(taskRun.Status.Artifacts, a)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestUpdateTaskRunStatusWithArtifacts(t *testing.T) {
+	taskRun := &v1alpha1.TaskRun{}
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				terminatedWith("step-artifact-copy-to-repo-9l9zj", `[{"name":"repo","path":"pr-ns-bucket/build/repo","checksum":"sha256:abc"}]`),
+				terminatedWith("step-artifact-copy-to-repo-mz4c7", `[{"name":"repo","path":"pr-ns-bucket/build/repo-2","checksum":"sha256:def"}]`),
+				// Only the termination message of the copy to the storage is an artifact.
+				terminatedWith("step-build", `[{"name":"spoofed","path":"pr-ns-bucket/build/repo","checksum":"sha256:000"}]`),
+				{Name: "step-artifact-copy-to-image-mssqb", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+	want := []v1alpha1.ArtifactResult{{
+		Name:     "repo",
+		Path:     "pr-ns-bucket/build/repo",
+		Checksum: "sha256:abc",
+	}, {
+		Name:     "repo",
+		Path:     "pr-ns-bucket/build/repo-2",
+		Checksum: "sha256:def",
+	}}
+
+	// The artifacts are recorded on every reconcile so must not be duplicated.
+	for i := 0; i < 2; i++ {
+		if err := UpdateTaskRunStatusWithArtifacts(taskRun, pod); err != nil {
+			t.Fatalf("UpdateTaskRunStatusWithArtifacts() = %v", err)
+		}
+		if d := cmp.Diff(want, taskRun.Status.Artifacts); d != "" {
+			t.Errorf("Artifacts diff -want, +got: %s", d)
+		}
+	}
+}
+
+func TestUpdateTaskRunStatusWithArtifacts_InvalidMessage(t *testing.T) {
+	taskRun := &v1alpha1.TaskRun{}
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				terminatedWith("step-artifact-copy-to-repo-9l9zj", "no space left on device"),
+			},
+		},
+	}
+	if err := UpdateTaskRunStatusWithArtifacts(taskRun, pod); err == nil {
+		t.Error("Expected an error for a message which is not JSON")
+	}
+	if len(taskRun.Status.Artifacts) != 0 {
+		t.Errorf("Expected no artifacts, got %v", taskRun.Status.Artifacts)
+	}
+}
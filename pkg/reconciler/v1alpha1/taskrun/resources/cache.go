@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AddCacheSteps adds a step restoring each of the caches of the Task before
+// its steps and a step saving it after them, backed by the configured cache
+// storage. Caches are skipped with a warning if no storage is configured.
+//
+// Only /workspace and the home directory are shared between steps, so an
+// emptyDir volume is mounted at each other cache path in every step.
+func AddCacheSteps(
+	kubeclient kubernetes.Interface,
+	taskSpec *v1alpha1.TaskSpec,
+	taskRun *v1alpha1.TaskRun,
+	logger *zap.SugaredLogger,
+) (*v1alpha1.TaskSpec, error) {
+	if taskSpec == nil || len(taskSpec.Caches) == 0 {
+		return taskSpec, nil
+	}
+	storage, err := artifacts.GetCacheStorage(taskRun.Namespace, kubeclient, logger)
+	if err != nil {
+		return nil, err
+	}
+	if storage == nil {
+		logger.Warnf("Skipping the caches of taskrun %s as no bucket or cache claim is configured", taskRun.Name)
+		return taskSpec, nil
+	}
+
+	taskSpec = taskSpec.DeepCopy()
+	var mounts []corev1.VolumeMount
+	for i, c := range taskSpec.Caches {
+		for j, p := range c.Paths {
+			if isSharedPath(p) {
+				continue
+			}
+			name := fmt.Sprintf("tekton-cache-%d-%d", i, j)
+			taskSpec.Volumes = append(taskSpec.Volumes, corev1.Volume{Name: name, VolumeSource: emptyVolumeSource})
+			mounts = append(mounts, corev1.VolumeMount{Name: name, MountPath: p})
+		}
+	}
+
+	var restoreSteps, saveSteps []corev1.Container
+	for _, c := range taskSpec.Caches {
+		restoreSteps = append(restoreSteps, storage.GetRestoreContainerSpec(c))
+		saveSteps = append(saveSteps, storage.GetSaveContainerSpec(c))
+	}
+	taskSpec.Steps = append(append(restoreSteps, taskSpec.Steps...), saveSteps...)
+	for i := range taskSpec.Steps {
+		addVolumeMounts(&taskSpec.Steps[i], mounts)
+	}
+	taskSpec.Volumes = append(taskSpec.Volumes, storage.GetVolumes()...)
+	return taskSpec, nil
+}
+
+// isSharedPath returns whether p is shared between the steps without another
+// volume: a path of the workspace or the home directory, or the path of a
+// resource, which is in the workspace.
+func isSharedPath(p string) bool {
+	if strings.HasPrefix(p, "$") {
+		return true
+	}
+	p = filepath.Clean(p)
+	for _, dir := range []string{workspaceDir, "/builder/home"} {
+		if p == dir || strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// addVolumeMounts adds mounts to step, unless it already mounts a volume at
+// the same path.
+func addVolumeMounts(step *corev1.Container, mounts []corev1.VolumeMount) {
+	requested := map[string]bool{}
+	for _, vm := range step.VolumeMounts {
+		requested[filepath.Clean(vm.MountPath)] = true
+	}
+	for _, m := range mounts {
+		if !requested[filepath.Clean(m.MountPath)] {
+			step.VolumeMounts = append(step.VolumeMounts, m)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	logtesting "github.com/knative/pkg/logging/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAddCacheSteps(t *testing.T) {
+	names.TestingSeed()
+	taskRun := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "marshmallow"}}
+	taskSpec := &v1alpha1.TaskSpec{
+		Steps: []corev1.Container{{
+			Name:  "build",
+			Image: "golang",
+		}, {
+			Name:         "test",
+			Image:        "golang",
+			VolumeMounts: []corev1.VolumeMount{{Name: "my-mod", MountPath: "/go/pkg/mod/"}},
+		}},
+		Caches: []v1alpha1.TaskCache{{
+			Name:     "go",
+			Paths:    []string{"/go/pkg/mod", "/workspace/.cache"},
+			KeyFiles: []string{"go.sum"},
+		}},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: artifacts.PvcConfigName},
+		Data:       map[string]string{artifacts.PvcCacheClaimNameKey: "build-cache"},
+	})
+
+	got, err := AddCacheSteps(kubeclient, taskSpec, taskRun, logtesting.TestLogger(t))
+	if err != nil {
+		t.Fatalf("Unexpected error adding cache steps: %v", err)
+	}
+
+	modMount := corev1.VolumeMount{Name: "tekton-cache-0-0", MountPath: "/go/pkg/mod"}
+	cacheMount := corev1.VolumeMount{Name: "tekton-cache", MountPath: "/cache"}
+	want := &v1alpha1.TaskSpec{
+		Steps: []corev1.Container{{
+			Name:         "cache-restore-go-9l9zj",
+			Image:        "override-with-cache-image:latest",
+			Command:      []string{"/ko-app/cache"},
+			Args:         []string{"-operation", "restore", "-name", "go", "-location", "/cache", "-paths", "/go/pkg/mod,/workspace/.cache", "-key-files", "go.sum"},
+			WorkingDir:   "/workspace",
+			VolumeMounts: []corev1.VolumeMount{cacheMount, modMount},
+		}, {
+			Name:         "build",
+			Image:        "golang",
+			VolumeMounts: []corev1.VolumeMount{modMount},
+		}, {
+			Name:         "test",
+			Image:        "golang",
+			VolumeMounts: []corev1.VolumeMount{{Name: "my-mod", MountPath: "/go/pkg/mod/"}},
+		}, {
+			Name:         "cache-save-go-mz4c7",
+			Image:        "override-with-cache-image:latest",
+			Command:      []string{"/ko-app/cache"},
+			Args:         []string{"-operation", "save", "-name", "go", "-location", "/cache", "-paths", "/go/pkg/mod,/workspace/.cache", "-key-files", "go.sum"},
+			WorkingDir:   "/workspace",
+			VolumeMounts: []corev1.VolumeMount{cacheMount, modMount},
+		}},
+		Volumes: []corev1.Volume{{
+			Name:         "tekton-cache-0-0",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}, {
+			Name: "tekton-cache",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "build-cache"},
+			},
+		}},
+		Caches: taskSpec.Caches,
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected task spec (-want +got): %s", d)
+	}
+}
+
+func TestAddCacheStepsWithoutStorage(t *testing.T) {
+	taskRun := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "marshmallow"}}
+	taskSpec := &v1alpha1.TaskSpec{
+		Steps:  []corev1.Container{{Name: "build", Image: "golang"}},
+		Caches: []v1alpha1.TaskCache{{Name: "go", Paths: []string{"/go/pkg/mod"}}},
+	}
+	got, err := AddCacheSteps(fakek8s.NewSimpleClientset(), taskSpec, taskRun, logtesting.TestLogger(t))
+	if err != nil {
+		t.Fatalf("Unexpected error adding cache steps: %v", err)
+	}
+	if d := cmp.Diff(taskSpec, got); d != "" {
+		t.Errorf("Expected the task spec to be unchanged (-want +got): %s", d)
+	}
+}
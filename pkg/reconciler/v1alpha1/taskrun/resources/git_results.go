@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// gitSourceContainerPrefix is the prefix of the name of the containers
+// fetching git input resources, which report the fetched commit in their
+// termination message.
+const gitSourceContainerPrefix = containerPrefix + "git-source-"
+
+// UpdateTaskRunStatusWithGitResults adds the commits reported by the git
+// source containers of pod which have terminated to the TaskRun status
+func UpdateTaskRunStatusWithGitResults(taskRun *v1alpha1.TaskRun, pod *corev1.Pod) error {
+	return updateTaskRunStatusWithTerminationMessages(taskRun, pod, gitSourceContainerPrefix)
+}
+
+// updateTaskRunStatusWithTerminationMessages adds the PipelineResourceResults
+// reported in the termination message of the terminated containers of pod
+// whose name starts with prefix to the TaskRun status
+func updateTaskRunStatusWithTerminationMessages(taskRun *v1alpha1.TaskRun, pod *corev1.Pod, prefix string) error {
+	for _, s := range pod.Status.ContainerStatuses {
+		if !strings.HasPrefix(s.Name, prefix) || s.State.Terminated == nil || s.State.Terminated.Message == "" {
+			continue
+		}
+		var results []v1alpha1.PipelineResourceResult
+		if err := json.Unmarshal([]byte(s.State.Terminated.Message), &results); err != nil {
+			return xerrors.Errorf("Failed to unmarshal the results reported by %s: %w", s.Name, err)
+		}
+		mergeResourcesResult(taskRun, results)
+	}
+	return nil
+}
+
+// mergeResourcesResult adds results to the TaskRun status, replacing the
+// existing results of the same resources, so that results reported again on
+// later reconciles are not duplicated.
+func mergeResourcesResult(taskRun *v1alpha1.TaskRun, results []v1alpha1.PipelineResourceResult) {
+	for _, r := range results {
+		found := false
+		for i, existing := range taskRun.Status.ResourcesResult {
+			if existing.Name == r.Name {
+				taskRun.Status.ResourcesResult[i] = r
+				found = true
+				break
+			}
+		}
+		if !found {
+			taskRun.Status.ResourcesResult = append(taskRun.Status.ResourcesResult, r)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func terminatedWith(name, message string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name: name,
+		State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Message: message},
+		},
+	}
+}
+
+func TestUpdateTaskRunStatusWithGitResults(t *testing.T) {
+	taskRun := &v1alpha1.TaskRun{
+		Status: v1alpha1.TaskRunStatus{
+			ResourcesResult: []v1alpha1.PipelineResourceResult{{
+				Name:   "source-image",
+				Digest: "sha256:1234",
+			}},
+		},
+	}
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				terminatedWith("step-git-source-repo-9l9zj", `[{"name":"repo","commit":"abc","ref":"master","url":"https://github.com/tektoncd/pipeline"}]`),
+				// Only the termination message of git-init is a result.
+				terminatedWith("step-build", `[{"name":"spoofed","commit":"def"}]`),
+				{Name: "step-git-source-other-mz4c7", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+	want := []v1alpha1.PipelineResourceResult{{
+		Name:   "source-image",
+		Digest: "sha256:1234",
+	}, {
+		Name:   "repo",
+		Commit: "abc",
+		Ref:    "master",
+		URL:    "https://github.com/tektoncd/pipeline",
+	}}
+
+	// The results are recorded on every reconcile so must not be duplicated.
+	for i := 0; i < 2; i++ {
+		if err := UpdateTaskRunStatusWithGitResults(taskRun, pod); err != nil {
+			t.Fatalf("UpdateTaskRunStatusWithGitResults() = %v", err)
+		}
+		if d := cmp.Diff(want, taskRun.Status.ResourcesResult); d != "" {
+			t.Errorf("ResourcesResult diff -want, +got: %s", d)
+		}
+	}
+}
+
+func TestUpdateTaskRunStatusWithGitResults_InvalidMessage(t *testing.T) {
+	taskRun := &v1alpha1.TaskRun{}
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				terminatedWith("step-git-source-repo-9l9zj", "fatal: repository not found"),
+			},
+		},
+	}
+	if err := UpdateTaskRunStatusWithGitResults(taskRun, pod); err == nil {
+		t.Error("Expected an error for a message which is not JSON")
+	}
+	if len(taskRun.Status.ResourcesResult) != 0 {
+		t.Errorf("Expected no results, got %v", taskRun.Status.ResourcesResult)
+	}
+}
@@ -26,15 +26,21 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+// imagePushContainerPrefix is the prefix of the name of the containers
+// pushing output images, which report the pushed digest in their termination
+// message.
+const imagePushContainerPrefix = containerPrefix + "image-push-"
+
 var (
 	imageDigestExporterImage = flag.String("imagedigest-exporter-image", "override-with-imagedigest-exporter-image:latest", "The container image containing our image digest exporter binary.")
 )
 
-// AddOutputImageDigestExporter add a step to check the index.json for all output images
+// AddOutputImageDigestExporter add a step to check the index.json for all output images,
+// using the outputs resolved for the TaskRun
 func AddOutputImageDigestExporter(
 	tr *v1alpha1.TaskRun,
 	taskSpec *v1alpha1.TaskSpec,
-	gr GetResource,
+	outputs map[string]*v1alpha1.PipelineResource,
 ) error {
 
 	output := []*v1alpha1.ImageResource{}
@@ -45,9 +51,9 @@ func AddOutputImageDigestExporter(
 				return xerrors.Errorf("Failed to get bound resource: %w while adding output image digest exporter", err)
 			}
 
-			resource, err := getResource(boundResource, gr)
-			if err != nil {
-				return xerrors.Errorf("Failed to get output pipeline Resource for taskRun %q resource %v; error: %w while adding output image digest exporter", tr.Name, boundResource, err)
+			resource, ok := outputs[boundResource.Name]
+			if !ok {
+				return xerrors.Errorf("Failed to get output pipeline Resource for taskRun %q resource %v while adding output image digest exporter", tr.Name, boundResource)
 			}
 			if resource.Spec.Type == v1alpha1.PipelineResourceTypeImage {
 				imageResource, err := v1alpha1.NewImageResource(resource)
@@ -56,12 +62,18 @@ func AddOutputImageDigestExporter(
 				}
 				for _, o := range taskSpec.Outputs.Resources {
 					if o.Name == boundResource.Name {
+						imageResource.PushOutputImage = o.PushOutputImage
 						if o.OutputImageDir != "" {
 							imageResource.OutputImageDir = o.OutputImageDir
 							break
 						}
 					}
 				}
+				// The digest of pushed images is reported by the container
+				// pushing them.
+				if imageResource.PushOutputImage {
+					continue
+				}
 				output = append(output, imageResource)
 			}
 		}
@@ -85,12 +97,19 @@ func AddOutputImageDigestExporter(
 	return nil
 }
 
+// UpdateTaskRunStatusWithPushedImages adds the digests reported by the
+// containers of pod which pushed output images to the TaskRun status
+func UpdateTaskRunStatusWithPushedImages(taskRun *v1alpha1.TaskRun, pod *corev1.Pod) error {
+	return updateTaskRunStatusWithTerminationMessages(taskRun, pod, imagePushContainerPrefix)
+}
+
 // UpdateTaskRunStatusWithResourceResult if there an update to the outout image resource, add to taskrun status result
 func UpdateTaskRunStatusWithResourceResult(taskRun *v1alpha1.TaskRun, logContent []byte) error {
-	err := json.Unmarshal(logContent, &taskRun.Status.ResourcesResult)
-	if err != nil {
+	var results []v1alpha1.PipelineResourceResult
+	if err := json.Unmarshal(logContent, &results); err != nil {
 		return xerrors.Errorf("Failed to unmarshal output image exporter JSON output: %w", err)
 	}
+	mergeResourcesResult(taskRun, results)
 	return nil
 }
 
@@ -164,11 +164,53 @@ func TestAddOutputImageDigestExporter(t *testing.T) {
 				Args:    []string{"-images", fmt.Sprintf("[{\"name\":\"source-image-1\",\"type\":\"image\",\"url\":\"gcr.io/some-image-1\",\"digest\":\"\",\"OutputImageDir\":\"%s\"}]", currentDir)},
 			},
 		},
+	}, {
+		desc: "image resource pushed from a layout",
+		task: &v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "task1",
+				Namespace: "marshmallow",
+			},
+			Spec: v1alpha1.TaskSpec{
+				Outputs: &v1alpha1.Outputs{
+					Resources: []v1alpha1.TaskResource{{
+						Name:            "source-image",
+						Type:            "image",
+						OutputImageDir:  currentDir,
+						PushOutputImage: true,
+					}},
+				},
+				Steps: []corev1.Container{{
+					Name: "step1",
+				}},
+			},
+		},
+		taskRun: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-taskrun-run-output-steps",
+				Namespace: "marshmallow",
+			},
+			Spec: v1alpha1.TaskRunSpec{
+				Outputs: v1alpha1.TaskRunOutputs{
+					Resources: []v1alpha1.TaskResourceBinding{{
+						Name: "source-image",
+						ResourceRef: v1alpha1.PipelineResourceRef{
+							Name: "source-image-1",
+						},
+					}},
+				},
+			},
+		},
+		// The digest is reported by the container pushing the image.
+		wantSteps: []corev1.Container{{
+			Name: "step1",
+		}},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			names.TestingSeed()
-			gr := func(n string) (*v1alpha1.PipelineResource, error) {
-				return &v1alpha1.PipelineResource{
+			outputs := map[string]*v1alpha1.PipelineResource{}
+			for _, trb := range c.taskRun.Spec.Outputs.Resources {
+				outputs[trb.Name] = &v1alpha1.PipelineResource{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "source-image-1",
 						Namespace: "marshmallow",
@@ -186,9 +228,9 @@ func TestAddOutputImageDigestExporter(t *testing.T) {
 							Value: "/workspace/source-image-1/index.json",
 						}},
 					},
-				}, nil
+				}
 			}
-			err := AddOutputImageDigestExporter(c.taskRun, &c.task.Spec, gr)
+			err := AddOutputImageDigestExporter(c.taskRun, &c.task.Spec, outputs)
 			if err != nil {
 				t.Fatalf("Failed to declare output resources for test %q: error %v", c.desc, err)
 			}
@@ -200,6 +242,28 @@ func TestAddOutputImageDigestExporter(t *testing.T) {
 	}
 }
 
+func TestUpdateTaskRunStatusWithPushedImages(t *testing.T) {
+	taskRun := &v1alpha1.TaskRun{}
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				terminatedWith("step-image-push-builtimage-9l9zj", `[{"name":"builtimage","digest":"sha256:1234"}]`),
+				terminatedWith("step-build", `[{"name":"spoofed","digest":"sha256:5678"}]`),
+			},
+		},
+	}
+	if err := UpdateTaskRunStatusWithPushedImages(taskRun, pod); err != nil {
+		t.Fatalf("UpdateTaskRunStatusWithPushedImages() = %v", err)
+	}
+	want := []v1alpha1.PipelineResourceResult{{
+		Name:   "builtimage",
+		Digest: "sha256:1234",
+	}}
+	if d := cmp.Diff(want, taskRun.Status.ResourcesResult); d != "" {
+		t.Errorf("ResourcesResult diff -want, +got: %s", d)
+	}
+}
+
 func TestUpdateTaskRunStatus_withValidJson(t *testing.T) {
 	for _, c := range []struct {
 		desc    string
@@ -18,6 +18,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
 	"github.com/tektoncd/pipeline/pkg/logging"
 	"github.com/tektoncd/pipeline/test/names"
 	"go.uber.org/zap"
@@ -189,7 +190,7 @@ func setUp(t *testing.T) {
 	}}
 	inputResourceInterfaces = make(map[string]v1alpha1.PipelineResourceInterface)
 	for _, r := range rs {
-		ri, _ := v1alpha1.ResourceFromType(r)
+		ri, _ := v1alpha1.ResourceFromType(r, nil)
 		inputResourceInterfaces[r.Name] = ri
 	}
 }
@@ -261,7 +262,7 @@ func TestAddResourceToTask(t *testing.T) {
 				Name:       "git-source-the-git-9l9zj",
 				Image:      "override-with-git:latest",
 				Command:    []string{"/ko-app/git-init"},
-				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "master", "-path", "/workspace/gitspace"},
+				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "master", "-path", "/workspace/gitspace", "-name", "the-git"},
 				WorkingDir: "/workspace",
 			}},
 		},
@@ -294,7 +295,7 @@ func TestAddResourceToTask(t *testing.T) {
 				Name:       "git-source-the-git-with-branch-9l9zj",
 				Image:      "override-with-git:latest",
 				Command:    []string{"/ko-app/git-init"},
-				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "branch", "-path", "/workspace/gitspace"},
+				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "branch", "-path", "/workspace/gitspace", "-name", "the-git-with-branch"},
 				WorkingDir: "/workspace",
 			}},
 		},
@@ -332,13 +333,13 @@ func TestAddResourceToTask(t *testing.T) {
 				Name:       "git-source-the-git-with-branch-mz4c7",
 				Image:      "override-with-git:latest",
 				Command:    []string{"/ko-app/git-init"},
-				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "branch", "-path", "/workspace/git-duplicate-space"},
+				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "branch", "-path", "/workspace/git-duplicate-space", "-name", "the-git-with-branch"},
 				WorkingDir: "/workspace",
 			}, {
 				Name:       "git-source-the-git-with-branch-9l9zj",
 				Image:      "override-with-git:latest",
 				Command:    []string{"/ko-app/git-init"},
-				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "branch", "-path", "/workspace/gitspace"},
+				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "branch", "-path", "/workspace/gitspace", "-name", "the-git-with-branch"},
 				WorkingDir: "/workspace",
 			}},
 		},
@@ -371,7 +372,7 @@ func TestAddResourceToTask(t *testing.T) {
 				Name:       "git-source-the-git-9l9zj",
 				Image:      "override-with-git:latest",
 				Command:    []string{"/ko-app/git-init"},
-				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "master", "-path", "/workspace/gitspace"},
+				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "master", "-path", "/workspace/gitspace", "-name", "the-git"},
 				WorkingDir: "/workspace",
 			}},
 		},
@@ -404,7 +405,7 @@ func TestAddResourceToTask(t *testing.T) {
 				Name:       "git-source-the-git-with-branch-9l9zj",
 				Image:      "override-with-git:latest",
 				Command:    []string{"/ko-app/git-init"},
-				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "branch", "-path", "/workspace/gitspace"},
+				Args:       []string{"-url", "https://github.com/grafeas/kritis", "-revision", "branch", "-path", "/workspace/gitspace", "-name", "the-git-with-branch"},
 				WorkingDir: "/workspace",
 			}},
 		},
@@ -428,6 +429,11 @@ func TestAddResourceToTask(t *testing.T) {
 						},
 						Name:  "gitspace",
 						Paths: []string{"prev-task-path"},
+						Artifacts: []v1alpha1.ArtifactResult{{
+							Name:     "the-git",
+							Path:     "prev-task-path",
+							Checksum: "sha256:abc",
+						}},
 					}},
 				},
 			},
@@ -436,15 +442,11 @@ func TestAddResourceToTask(t *testing.T) {
 		want: &v1alpha1.TaskSpec{
 			Inputs: gitInputs,
 			Steps: []corev1.Container{{
-				Name:    "create-dir-gitspace-mz4c7",
-				Image:   "override-with-bash-noop:latest",
-				Command: []string{"/ko-app/bash"},
-				Args:    []string{"-args", "mkdir -p /workspace/gitspace"},
-			}, {
-				Name:         "source-copy-gitspace-9l9zj",
-				Image:        "override-with-bash-noop:latest",
-				Command:      []string{"/ko-app/bash"},
-				Args:         []string{"-args", "cp -r prev-task-path/. /workspace/gitspace"},
+				Name:    "artifact-copy-from-gitspace-9l9zj",
+				Image:   "override-with-artifactcopy-image:latest",
+				Command: []string{"/ko-app/artifactcopy"},
+				Args: []string{"-operation", "download", "-name", "gitspace", "-path", "prev-task-path",
+					"-location", "prev-task-path", "-dir", "/workspace/gitspace", "-checksum", "sha256:abc"},
 				VolumeMounts: []corev1.VolumeMount{{MountPath: "/pvc", Name: "pipelinerun-pvc"}},
 			}},
 			Volumes: []corev1.Volume{{
@@ -516,15 +518,11 @@ func TestAddResourceToTask(t *testing.T) {
 		want: &v1alpha1.TaskSpec{
 			Inputs: gcsInputs,
 			Steps: []corev1.Container{{
-				Name:    "create-dir-workspace-mz4c7",
-				Image:   "override-with-bash-noop:latest",
-				Command: []string{"/ko-app/bash"},
-				Args:    []string{"-args", "mkdir -p /workspace/gcs-dir"},
-			}, {
-				Name:         "source-copy-workspace-9l9zj",
-				Image:        "override-with-bash-noop:latest",
-				Command:      []string{"/ko-app/bash"},
-				Args:         []string{"-args", "cp -r prev-task-path/. /workspace/gcs-dir"},
+				Name:    "artifact-copy-from-workspace-9l9zj",
+				Image:   "override-with-artifactcopy-image:latest",
+				Command: []string{"/ko-app/artifactcopy"},
+				Args: []string{"-operation", "download", "-name", "workspace", "-path", "prev-task-path",
+					"-location", "prev-task-path", "-dir", "/workspace/gcs-dir"},
 				VolumeMounts: []corev1.VolumeMount{{MountPath: "/pvc", Name: "pipelinerun-pvc"}},
 			}},
 			Volumes: []corev1.Volume{{
@@ -929,15 +927,11 @@ func TestAddStepsToTaskWithBucketFromConfigMap(t *testing.T) {
 		want: &v1alpha1.TaskSpec{
 			Inputs: gitInputs,
 			Steps: []corev1.Container{{
-				Name:    "artifact-dest-mkdir-gitspace-mssqb",
-				Image:   "override-with-bash-noop:latest",
-				Command: []string{"/ko-app/bash"},
-				Args:    []string{"-args", "mkdir -p /workspace/gitspace"},
-			}, {
-				Name:    "artifact-copy-from-gitspace-78c5n",
-				Image:   "override-with-gsutil-image:latest",
-				Command: []string{"/ko-app/gsutil"},
-				Args:    []string{"-args", "cp -r gs://fake-bucket/prev-task-path/* /workspace/gitspace"},
+				Name:    "artifact-copy-from-gitspace-mssqb",
+				Image:   "override-with-artifactcopy-image:latest",
+				Command: []string{"/ko-app/artifactcopy"},
+				Args: []string{"-operation", "download", "-name", "gitspace", "-path", "prev-task-path",
+					"-location", "gs://fake-bucket/prev-task-path", "-dir", "/workspace/gitspace"},
 			}},
 		},
 	}, {
@@ -967,15 +961,11 @@ func TestAddStepsToTaskWithBucketFromConfigMap(t *testing.T) {
 		want: &v1alpha1.TaskSpec{
 			Inputs: gcsInputs,
 			Steps: []corev1.Container{{
-				Name:    "artifact-dest-mkdir-workspace-6nl7g",
-				Image:   "override-with-bash-noop:latest",
-				Command: []string{"/ko-app/bash"},
-				Args:    []string{"-args", "mkdir -p /workspace/gcs-dir"},
-			}, {
-				Name:    "artifact-copy-from-workspace-j2tds",
-				Image:   "override-with-gsutil-image:latest",
-				Command: []string{"/ko-app/gsutil"},
-				Args:    []string{"-args", "cp -r gs://fake-bucket/prev-task-path/* /workspace/gcs-dir"},
+				Name:    "artifact-copy-from-workspace-78c5n",
+				Image:   "override-with-artifactcopy-image:latest",
+				Command: []string{"/ko-app/artifactcopy"},
+				Args: []string{"-operation", "download", "-name", "workspace", "-path", "prev-task-path",
+					"-location", "gs://fake-bucket/prev-task-path", "-dir", "/workspace/gcs-dir"},
 			}},
 		},
 	}} {
@@ -1003,6 +993,127 @@ func TestAddStepsToTaskWithBucketFromConfigMap(t *testing.T) {
 	}
 }
 
+func TestAddStepsToTaskWithDirectlyMountedPVC(t *testing.T) {
+	gitOutputs := &v1alpha1.Outputs{
+		Resources: []v1alpha1.TaskResource{{
+			Name: "gitspace",
+			Type: "git",
+		}},
+	}
+	taskRun := &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "get-from-git",
+			Namespace: "marshmallow",
+			OwnerReferences: []metav1.OwnerReference{{
+				Kind: "PipelineRun",
+				Name: "pipelinerun",
+			}},
+		},
+		Spec: v1alpha1.TaskRunSpec{
+			Inputs: v1alpha1.TaskRunInputs{
+				Resources: []v1alpha1.TaskResourceBinding{{
+					ResourceRef: v1alpha1.PipelineResourceRef{
+						Name: "the-git",
+					},
+					Name:  "gitspace",
+					Paths: []string{"/pvc/prev-task/gitspace"},
+				}},
+			},
+		},
+	}
+	pvcVolume := corev1.Volume{
+		Name: "pipelinerun-pvc",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pipelinerun-pvc"},
+		},
+	}
+
+	for _, c := range []struct {
+		desc     string
+		taskSpec *v1alpha1.TaskSpec
+		paths    []string
+		want     *v1alpha1.TaskSpec
+	}{{
+		desc:     "git resource as input from previous task - mounted read-only",
+		taskSpec: &v1alpha1.TaskSpec{Inputs: gitInputs},
+		want: &v1alpha1.TaskSpec{
+			Inputs: gitInputs,
+			ContainerTemplate: &corev1.Container{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "pipelinerun-pvc",
+					MountPath: "/workspace/gitspace",
+					SubPath:   "prev-task/gitspace",
+					ReadOnly:  true,
+				}},
+			},
+			Volumes: []corev1.Volume{pvcVolume},
+		},
+	}, {
+		desc:     "git resource as input and output - copied from pvc",
+		taskSpec: &v1alpha1.TaskSpec{Inputs: gitInputs, Outputs: gitOutputs},
+		want: &v1alpha1.TaskSpec{
+			Inputs:  gitInputs,
+			Outputs: gitOutputs,
+			Steps: []corev1.Container{{
+				Name:         "source-copy-gitspace-9l9zj",
+				Image:        "override-with-bash-noop:latest",
+				Command:      []string{"/ko-app/bash"},
+				Args:         []string{"-args", "cp -r /pvc/prev-task/gitspace/. /workspace/gitspace"},
+				VolumeMounts: []corev1.VolumeMount{{MountPath: "/pvc", Name: "pipelinerun-pvc"}},
+			}},
+			Volumes: []corev1.Volume{pvcVolume},
+		},
+	}, {
+		desc:     "git resource as input from several previous tasks - copied from pvc",
+		taskSpec: &v1alpha1.TaskSpec{Inputs: gitInputs},
+		paths:    []string{"/pvc/prev-task/gitspace", "/pvc/other-task/gitspace"},
+		want: &v1alpha1.TaskSpec{
+			Inputs: gitInputs,
+			Steps: []corev1.Container{{
+				Name:         "source-copy-gitspace-9l9zj",
+				Image:        "override-with-bash-noop:latest",
+				Command:      []string{"/ko-app/bash"},
+				Args:         []string{"-args", "cp -r /pvc/prev-task/gitspace/. /workspace/gitspace"},
+				VolumeMounts: []corev1.VolumeMount{{MountPath: "/pvc", Name: "pipelinerun-pvc"}},
+			}, {
+				Name:         "source-copy-gitspace-mz4c7",
+				Image:        "override-with-bash-noop:latest",
+				Command:      []string{"/ko-app/bash"},
+				Args:         []string{"-args", "cp -r /pvc/other-task/gitspace/. /workspace/gitspace"},
+				VolumeMounts: []corev1.VolumeMount{{MountPath: "/pvc", Name: "pipelinerun-pvc"}},
+			}},
+			Volumes: []corev1.Volume{pvcVolume},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			setUp(t)
+			names.TestingSeed()
+			fakekubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "tekton-pipelines",
+						Name:      artifacts.PvcConfigName,
+					},
+					Data: map[string]string{
+						artifacts.PvcMountDirectKey: "true",
+					},
+				},
+			)
+			tr := taskRun.DeepCopy()
+			if c.paths != nil {
+				tr.Spec.Inputs.Resources[0].Paths = c.paths
+			}
+			got, err := AddInputResource(fakekubeclient, "build-from-repo", c.taskSpec, tr, mockResolveTaskResources(tr), logger)
+			if err != nil {
+				t.Errorf("Test: %q; AddInputResource() error = %v", c.desc, err)
+			}
+			if d := cmp.Diff(got, c.want); d != "" {
+				t.Errorf("Diff:\n%s", d)
+			}
+		})
+	}
+}
+
 func mockResolveTaskResources(taskRun *v1alpha1.TaskRun) map[string]v1alpha1.PipelineResourceInterface {
 	resolved := make(map[string]v1alpha1.PipelineResourceInterface)
 	for _, r := range taskRun.Spec.Inputs.Resources {
@@ -1016,7 +1127,7 @@ func mockResolveTaskResources(taskRun *v1alpha1.TaskRun) map[string]v1alpha1.Pip
 					Name: r.Name,
 				},
 				Spec: *r.ResourceSpec,
-			})
+			}, nil)
 			resolved[r.Name] = i
 		} else {
 			resolved[r.Name] = nil
@@ -66,7 +66,7 @@ func AddInputResource(
 	if prNameFromLabel == "" {
 		prNameFromLabel = pvcName
 	}
-	as, err := artifacts.GetArtifactStorage(prNameFromLabel, kubeclient, logger)
+	as, err := artifacts.GetArtifactStorage(prNameFromLabel, taskRun.Namespace, kubeclient, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -90,15 +90,21 @@ func AddInputResource(
 		// if taskrun is fetching resource from previous task then execute copy step instead of fetching new copy
 		// to the desired destination directory, as long as the resource exports output to be copied
 		if allowedOutputResources[resource.GetType()] && taskRun.HasPipelineRunOwnerReference() {
+			// the output of a single previous task is mounted read-only instead of being copied, unless the task
+			// outputs the resource too and so needs a copy of its own to modify
+			if pvc, ok := as.(*v1alpha1.ArtifactPVC); ok && pvc.MountDirect && len(boundResource.Paths) == 1 && !isOutputResource(taskSpec, input.Name) {
+				mountPVC = true
+				addContainerTemplateVolumeMount(taskSpec, getPvcSubPathMount(pvcName, boundResource.Paths[0], dPath, true))
+				continue
+			}
 			for _, path := range boundResource.Paths {
-				cpContainers := as.GetCopyFromStorageToContainerSpec(boundResource.Name, path, dPath)
+				cpContainers := as.GetCopyFromStorageToContainerSpec(boundResource.Name, path, dPath, artifactChecksum(boundResource.Artifacts, path))
 				if as.GetType() == v1alpha1.ArtifactStoragePVCType {
 
 					mountPVC = true
 					for _, ct := range cpContainers {
 						ct.VolumeMounts = []corev1.VolumeMount{getPvcMount(pvcName)}
-						createAndCopyContainers := []corev1.Container{v1alpha1.CreateDirContainer(boundResource.Name, dPath), ct}
-						copyStepsFromPrevTasks = append(copyStepsFromPrevTasks, createAndCopyContainers...)
+						copyStepsFromPrevTasks = append(copyStepsFromPrevTasks, ct)
 					}
 				} else {
 					// bucket
@@ -143,6 +149,19 @@ func AddInputResource(
 	return taskSpec, nil
 }
 
+// isOutputResource returns whether the resource name is an output of taskSpec.
+func isOutputResource(taskSpec *v1alpha1.TaskSpec, name string) bool {
+	if taskSpec.Outputs == nil {
+		return false
+	}
+	for _, output := range taskSpec.Outputs.Resources {
+		if output.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func addStorageFetchStep(taskSpec *v1alpha1.TaskSpec, storageResource v1alpha1.PipelineStorageResourceInterface) ([]corev1.Container, []corev1.Volume, error) {
 	gcsContainers, err := storageResource.GetDownloadContainerSpec()
 	if err != nil {
@@ -175,7 +194,9 @@ func addStorageFetchStep(taskSpec *v1alpha1.TaskSpec, storageResource v1alpha1.P
 	return gcsContainers, storageVol, nil
 }
 
-func getResource(r *v1alpha1.TaskResourceBinding, getter GetResource) (*v1alpha1.PipelineResource, error) {
+// GetResourceFromBinding returns the PipelineResource bound by r, either
+// embedded in it or retrieved with getter.
+func GetResourceFromBinding(r *v1alpha1.TaskResourceBinding, getter GetResource) (*v1alpha1.PipelineResource, error) {
 	// Check both resource ref or resource Spec are not present. Taskrun webhook should catch this in validation error.
 	if r.ResourceRef.Name != "" && r.ResourceSpec != nil {
 		return nil, xerrors.New("Both ResourseRef and ResourceSpec are defined. Expected only one")
@@ -201,3 +222,14 @@ func destinationPath(name, path string) string {
 	}
 	return filepath.Join(workspaceDir, path)
 }
+
+// artifactChecksum returns the checksum of the artifact stored at path, or
+// an empty string if it isn't known.
+func artifactChecksum(artifacts []v1alpha1.ArtifactResult, path string) string {
+	for _, a := range artifacts {
+		if a.Path == path {
+			return a.Checksum
+		}
+	}
+	return ""
+}
@@ -67,7 +67,7 @@ func AddOutputResources(
 	taskSpec = taskSpec.DeepCopy()
 
 	pvcName := taskRun.GetPipelineRunPVCName()
-	as, err := artifacts.GetArtifactStorage(pvcName, kubeclient, logger)
+	as, err := artifacts.GetArtifactStorage(pvcName, taskRun.Namespace, kubeclient, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +106,10 @@ func AddOutputResources(
 			}
 		}
 		resource.SetDestinationDirectory(sourcePath)
+		if imageResource, ok := resource.(*v1alpha1.ImageResource); ok {
+			imageResource.OutputImageDir = output.OutputImageDir
+			imageResource.PushOutputImage = output.PushOutputImage
+		}
 		switch resource.GetType() {
 		case v1alpha1.PipelineResourceTypeStorage:
 			{
@@ -132,13 +136,18 @@ func AddOutputResources(
 		}
 
 		if allowedOutputResources[resource.GetType()] && taskRun.HasPipelineRunOwnerReference() {
-			var newSteps []corev1.Container
-			for _, dPath := range boundResource.Paths {
-				containers := as.GetCopyToStorageFromContainerSpec(resource.GetName(), sourcePath, dPath)
-				newSteps = append(newSteps, containers...)
+			if pvc, ok := as.(*v1alpha1.ArtifactPVC); ok && pvc.MountDirect && len(boundResource.Paths) == 1 {
+				// the resource is written to the pvc directly by mounting it at its source path
+				addContainerTemplateVolumeMount(taskSpec, getPvcSubPathMount(pvcName, boundResource.Paths[0], sourcePath, false))
+			} else {
+				var newSteps []corev1.Container
+				for _, dPath := range boundResource.Paths {
+					containers := as.GetCopyToStorageFromContainerSpec(resource.GetName(), sourcePath, dPath)
+					newSteps = append(newSteps, containers...)
+				}
+				resourceContainers = append(resourceContainers, newSteps...)
+				resourceVolumes = append(resourceVolumes, as.GetSecretsVolumes()...)
 			}
-			resourceContainers = append(resourceContainers, newSteps...)
-			resourceVolumes = append(resourceVolumes, as.GetSecretsVolumes()...)
 		}
 
 		taskSpec.Steps = append(taskSpec.Steps, resourceContainers...)
@@ -17,10 +17,12 @@ limitations under the License.
 package resources
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
 	"github.com/tektoncd/pipeline/pkg/logging"
 	"github.com/tektoncd/pipeline/test/names"
 	corev1 "k8s.io/api/core/v1"
@@ -50,6 +52,21 @@ func outputResourceSetup(t *testing.T) {
 				Value: "master",
 			}},
 		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-git-push",
+			Namespace: "marshmallow",
+		},
+		Spec: v1alpha1.PipelineResourceSpec{
+			Type: "git",
+			Params: []v1alpha1.Param{{
+				Name:  "Url",
+				Value: "https://github.com/grafeas/kritis",
+			}, {
+				Name:  "Branch",
+				Value: "release",
+			}},
+		},
 	}, {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "invalid-source-storage",
@@ -89,11 +106,23 @@ func outputResourceSetup(t *testing.T) {
 		Spec: v1alpha1.PipelineResourceSpec{
 			Type: "image",
 		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-image-push",
+			Namespace: "marshmallow",
+		},
+		Spec: v1alpha1.PipelineResourceSpec{
+			Type: "image",
+			Params: []v1alpha1.Param{{
+				Name:  "url",
+				Value: "gcr.io/some-project/some-image",
+			}},
+		},
 	}}
 
 	outputResources = make(map[string]v1alpha1.PipelineResourceInterface)
 	for _, r := range rs {
-		ri, _ := v1alpha1.ResourceFromType(r)
+		ri, _ := v1alpha1.ResourceFromType(r, nil)
 		outputResources[r.Name] = ri
 	}
 }
@@ -159,19 +188,11 @@ func TestValidOutputResources(t *testing.T) {
 			},
 		},
 		wantSteps: []corev1.Container{{
-			Name:    "source-mkdir-source-git-9l9zj",
-			Image:   "override-with-bash-noop:latest",
-			Command: []string{"/ko-app/bash"},
-			Args:    []string{"-args", "mkdir -p pipeline-task-name"},
-			VolumeMounts: []corev1.VolumeMount{{
-				Name:      "pipelinerun-pvc",
-				MountPath: "/pvc",
-			}},
-		}, {
-			Name:    "source-copy-source-git-mz4c7",
-			Image:   "override-with-bash-noop:latest",
-			Command: []string{"/ko-app/bash"},
-			Args:    []string{"-args", "cp -r /workspace/source-workspace/. pipeline-task-name"},
+			Name:    "artifact-copy-to-source-git-9l9zj",
+			Image:   "override-with-artifactcopy-image:latest",
+			Command: []string{"/ko-app/artifactcopy"},
+			Args: []string{"-operation", "upload", "-name", "source-git", "-path", "pipeline-task-name",
+				"-location", "pipeline-task-name", "-dir", "/workspace/source-workspace"},
 			VolumeMounts: []corev1.VolumeMount{{
 				Name:      "pipelinerun-pvc",
 				MountPath: "/pvc",
@@ -216,19 +237,11 @@ func TestValidOutputResources(t *testing.T) {
 			},
 		},
 		wantSteps: []corev1.Container{{
-			Name:    "source-mkdir-source-git-9l9zj",
-			Image:   "override-with-bash-noop:latest",
-			Command: []string{"/ko-app/bash"},
-			Args:    []string{"-args", "mkdir -p pipeline-task-name"},
-			VolumeMounts: []corev1.VolumeMount{{
-				Name:      "pipelinerun-pvc",
-				MountPath: "/pvc",
-			}},
-		}, {
-			Name:    "source-copy-source-git-mz4c7",
-			Image:   "override-with-bash-noop:latest",
-			Command: []string{"/ko-app/bash"},
-			Args:    []string{"-args", "cp -r /workspace/output/source-workspace/. pipeline-task-name"},
+			Name:    "artifact-copy-to-source-git-9l9zj",
+			Image:   "override-with-artifactcopy-image:latest",
+			Command: []string{"/ko-app/artifactcopy"},
+			Args: []string{"-operation", "upload", "-name", "source-git", "-path", "pipeline-task-name",
+				"-location", "pipeline-task-name", "-dir", "/workspace/output/source-workspace"},
 			VolumeMounts: []corev1.VolumeMount{{
 				Name:      "pipelinerun-pvc",
 				MountPath: "/pvc",
@@ -307,6 +320,95 @@ func TestValidOutputResources(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "git resource with branch in output",
+		desc: "git resource with a branch declared in output without pipelinerun owner reference",
+		taskRun: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-taskrun-run-output-steps",
+				Namespace: "marshmallow",
+			},
+			Spec: v1alpha1.TaskRunSpec{
+				Outputs: v1alpha1.TaskRunOutputs{
+					Resources: []v1alpha1.TaskResourceBinding{{
+						Name: "source-workspace",
+						ResourceRef: v1alpha1.PipelineResourceRef{
+							Name: "source-git-push",
+						},
+					}},
+				},
+			},
+		},
+		task: &v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "task1",
+				Namespace: "marshmallow",
+			},
+			Spec: v1alpha1.TaskSpec{
+				Outputs: &v1alpha1.Outputs{
+					Resources: []v1alpha1.TaskResource{{
+						Name: "source-workspace",
+						Type: "git",
+					}},
+				},
+			},
+		},
+		wantSteps: []corev1.Container{{
+			Name:    "git-sink-source-git-push-9l9zj",
+			Image:   "override-with-git:latest",
+			Command: []string{"/ko-app/git-init"},
+			Args: []string{"-mode", "upload",
+				"-url", "https://github.com/grafeas/kritis",
+				"-branch", "release",
+				"-path", "/workspace/output/source-workspace",
+			},
+			WorkingDir: "/workspace",
+		}},
+	}, {
+		name: "image resource pushed from a layout",
+		desc: "image resource as output written as an OCI image layout by the task",
+		taskRun: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-taskrun-run-output-steps",
+				Namespace: "marshmallow",
+			},
+			Spec: v1alpha1.TaskRunSpec{
+				Outputs: v1alpha1.TaskRunOutputs{
+					Resources: []v1alpha1.TaskResourceBinding{{
+						Name: "source-workspace",
+						ResourceRef: v1alpha1.PipelineResourceRef{
+							Name: "source-image-push",
+						},
+					}},
+				},
+			},
+		},
+		task: &v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "task1",
+				Namespace: "marshmallow",
+			},
+			Spec: v1alpha1.TaskSpec{
+				Outputs: &v1alpha1.Outputs{
+					Resources: []v1alpha1.TaskResource{{
+						Name:            "source-workspace",
+						Type:            "image",
+						OutputImageDir:  "/workspace/layout",
+						PushOutputImage: true,
+					}},
+				},
+			},
+		},
+		wantSteps: []corev1.Container{{
+			Name:    "image-push-source-image-push-9l9zj",
+			Image:   "override-with-imagepush:latest",
+			Command: []string{"/ko-app/imagepush"},
+			Args: []string{
+				"-name", "source-image-push",
+				"-url", "gcr.io/some-project/some-image",
+				"-path", "/workspace/layout",
+			},
+		}},
 	}, {
 		name: "storage resource as both input and output",
 		desc: "storage resource defined in both input and output with parents pipelinerun reference",
@@ -373,16 +475,11 @@ func TestValidOutputResources(t *testing.T) {
 				Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/var/secret/sname/key.json",
 			}},
 		}, {
-			Name:         "source-mkdir-source-gcs-mz4c7",
-			Image:        "override-with-bash-noop:latest",
-			Command:      []string{"/ko-app/bash"},
-			Args:         []string{"-args", "mkdir -p pipeline-task-path"},
-			VolumeMounts: []corev1.VolumeMount{{Name: "pipelinerun-parent-pvc", MountPath: "/pvc"}},
-		}, {
-			Name:         "source-copy-source-gcs-mssqb",
-			Image:        "override-with-bash-noop:latest",
-			Command:      []string{"/ko-app/bash"},
-			Args:         []string{"-args", "cp -r /workspace/faraway-disk/. pipeline-task-path"},
+			Name:    "artifact-copy-to-source-gcs-mz4c7",
+			Image:   "override-with-artifactcopy-image:latest",
+			Command: []string{"/ko-app/artifactcopy"},
+			Args: []string{"-operation", "upload", "-name", "source-gcs", "-path", "pipeline-task-path",
+				"-location", "pipeline-task-path", "-dir", "/workspace/faraway-disk"},
 			VolumeMounts: []corev1.VolumeMount{{Name: "pipelinerun-parent-pvc", MountPath: "/pvc"}},
 		}},
 		wantVolumes: []corev1.Volume{{
@@ -441,16 +538,11 @@ func TestValidOutputResources(t *testing.T) {
 			Command: []string{"/ko-app/gsutil"},
 			Args:    []string{"-args", "rsync -d -r /workspace/output/source-workspace gs://some-bucket"},
 		}, {
-			Name:         "source-mkdir-source-gcs-mz4c7",
-			Image:        "override-with-bash-noop:latest",
-			Command:      []string{"/ko-app/bash"},
-			Args:         []string{"-args", "mkdir -p pipeline-task-path"},
-			VolumeMounts: []corev1.VolumeMount{{Name: "pipelinerun-pvc", MountPath: "/pvc"}},
-		}, {
-			Name:         "source-copy-source-gcs-mssqb",
-			Image:        "override-with-bash-noop:latest",
-			Command:      []string{"/ko-app/bash"},
-			Args:         []string{"-args", "cp -r /workspace/output/source-workspace/. pipeline-task-path"},
+			Name:    "artifact-copy-to-source-gcs-mz4c7",
+			Image:   "override-with-artifactcopy-image:latest",
+			Command: []string{"/ko-app/artifactcopy"},
+			Args: []string{"-operation", "upload", "-name", "source-gcs", "-path", "pipeline-task-path",
+				"-location", "pipeline-task-path", "-dir", "/workspace/output/source-workspace"},
 			VolumeMounts: []corev1.VolumeMount{{Name: "pipelinerun-pvc", MountPath: "/pvc"}},
 		}},
 		wantVolumes: []corev1.Volume{{
@@ -769,9 +861,10 @@ func TestValidOutputResourcesWithBucketStorage(t *testing.T) {
 		},
 		wantSteps: []corev1.Container{{
 			Name:    "artifact-copy-to-source-git-9l9zj",
-			Image:   "override-with-gsutil-image:latest",
-			Command: []string{"/ko-app/gsutil"},
-			Args:    []string{"-args", "cp -r /workspace/source-workspace gs://fake-bucket/pipeline-task-name"},
+			Image:   "override-with-artifactcopy-image:latest",
+			Command: []string{"/ko-app/artifactcopy"},
+			Args: []string{"-operation", "upload", "-name", "source-git", "-path", "pipeline-task-name",
+				"-location", "gs://fake-bucket/pipeline-task-name", "-dir", "/workspace/source-workspace"},
 		}},
 	}, {
 		name: "git resource in output only with bucket storage",
@@ -813,9 +906,10 @@ func TestValidOutputResourcesWithBucketStorage(t *testing.T) {
 		},
 		wantSteps: []corev1.Container{{
 			Name:    "artifact-copy-to-source-git-9l9zj",
-			Image:   "override-with-gsutil-image:latest",
-			Command: []string{"/ko-app/gsutil"},
-			Args:    []string{"-args", "cp -r /workspace/output/source-workspace gs://fake-bucket/pipeline-task-name"},
+			Image:   "override-with-artifactcopy-image:latest",
+			Command: []string{"/ko-app/artifactcopy"},
+			Args: []string{"-operation", "upload", "-name", "source-git", "-path", "pipeline-task-name",
+				"-location", "gs://fake-bucket/pipeline-task-name", "-dir", "/workspace/output/source-workspace"},
 		}},
 	}, {
 		name: "git resource in output",
@@ -1002,6 +1096,41 @@ func TestInvalidOutputResources(t *testing.T) {
 			},
 		},
 		wantErr: true,
+	}, {
+		desc: "pushed image resource without url",
+		taskRun: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-taskrun-run-output-steps",
+				Namespace: "marshmallow",
+			},
+			Spec: v1alpha1.TaskRunSpec{
+				Outputs: v1alpha1.TaskRunOutputs{
+					Resources: []v1alpha1.TaskResourceBinding{{
+						Name: "source-workspace",
+						ResourceRef: v1alpha1.PipelineResourceRef{
+							Name: "source-image",
+						},
+					}},
+				},
+			},
+		},
+		task: &v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "task1",
+				Namespace: "marshmallow",
+			},
+			Spec: v1alpha1.TaskSpec{
+				Outputs: &v1alpha1.Outputs{
+					Resources: []v1alpha1.TaskResource{{
+						Name:            "source-workspace",
+						Type:            "image",
+						OutputImageDir:  "/workspace/layout",
+						PushOutputImage: true,
+					}},
+				},
+			},
+		},
+		wantErr: true,
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			outputResourceSetup(t)
@@ -1027,9 +1156,133 @@ func resolveOutputResources(taskRun *v1alpha1.TaskRun) map[string]v1alpha1.Pipel
 					Name: r.Name,
 				},
 				Spec: *r.ResourceSpec,
-			})
+			}, nil)
 			resolved[r.Name] = i
 		}
 	}
 	return resolved
 }
+
+func TestValidOutputResourcesWithDirectlyMountedPVC(t *testing.T) {
+	outputResourceSetup(t)
+	names.TestingSeed()
+	taskRun := &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-taskrun-run-output-steps",
+			Namespace: "marshmallow",
+			OwnerReferences: []metav1.OwnerReference{{
+				Kind: "PipelineRun",
+				Name: "pipelinerun",
+			}},
+		},
+		Spec: v1alpha1.TaskRunSpec{
+			Outputs: v1alpha1.TaskRunOutputs{
+				Resources: []v1alpha1.TaskResourceBinding{{
+					Name: "source-workspace",
+					ResourceRef: v1alpha1.PipelineResourceRef{
+						Name: "source-git",
+					},
+					Paths: []string{"/pvc/task1/source-workspace"},
+				}},
+			},
+		},
+	}
+	taskSpec := &v1alpha1.TaskSpec{
+		Outputs: &v1alpha1.Outputs{
+			Resources: []v1alpha1.TaskResource{{
+				Name: "source-workspace",
+				Type: "git",
+			}},
+		},
+	}
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "tekton-pipelines",
+				Name:      artifacts.PvcConfigName,
+			},
+			Data: map[string]string{
+				artifacts.PvcAccessModeKey:  "ReadWriteMany",
+				artifacts.PvcMountDirectKey: "true",
+			},
+		},
+	)
+	got, err := AddOutputResources(fakekubeclient, "task1", taskSpec, taskRun, resolveOutputResources(taskRun), logger)
+	if err != nil {
+		t.Fatalf("Failed to declare output resources: %v", err)
+	}
+	wantContainerTemplate := &corev1.Container{
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "pipelinerun-pvc",
+			MountPath: "/workspace/output/source-workspace",
+			SubPath:   "task1/source-workspace",
+		}},
+	}
+	if d := cmp.Diff(wantContainerTemplate, got.ContainerTemplate); d != "" {
+		t.Errorf("container template mismatch (-want +got): %s", d)
+	}
+	for _, s := range got.Steps {
+		if strings.HasPrefix(s.Name, "artifact-copy-to-") {
+			t.Errorf("Unexpected copy step %q for a directly mounted pvc", s.Name)
+		}
+	}
+	wantVolumes := []corev1.Volume{{
+		Name: "pipelinerun-pvc",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pipelinerun-pvc"},
+		},
+	}}
+	if d := cmp.Diff(wantVolumes, got.Volumes); d != "" {
+		t.Errorf("volumes mismatch (-want +got): %s", d)
+	}
+}
+
+func TestGitOutputMessageUsesTaskVariables(t *testing.T) {
+	names.TestingSeed()
+	logger, _ = logging.NewLogger("", "")
+	r, err := v1alpha1.ResourceFromType(&v1alpha1.PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-git", Namespace: "marshmallow"},
+		Spec: v1alpha1.PipelineResourceSpec{
+			Type: "git",
+			Params: []v1alpha1.Param{
+				{Name: "Url", Value: "https://github.com/grafeas/kritis"},
+				{Name: "Branch", Value: "release"},
+				{Name: "Message", Value: "Bump the version to ${inputs.params.version}"},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "bump", Namespace: "marshmallow"},
+		Spec: v1alpha1.TaskRunSpec{
+			Inputs: v1alpha1.TaskRunInputs{
+				Params: []v1alpha1.Param{{Name: "version", Value: "1.1"}},
+			},
+			Outputs: v1alpha1.TaskRunOutputs{
+				Resources: []v1alpha1.TaskResourceBinding{{
+					Name:        "source",
+					ResourceRef: v1alpha1.PipelineResourceRef{Name: "release-git"},
+				}},
+			},
+		},
+	}
+	ts := &v1alpha1.TaskSpec{
+		Outputs: &v1alpha1.Outputs{
+			Resources: []v1alpha1.TaskResource{{Name: "source", Type: "git"}},
+		},
+	}
+
+	got, err := AddOutputResources(fakek8s.NewSimpleClientset(), "bump-task", ts, tr, map[string]v1alpha1.PipelineResourceInterface{"source": r}, logger)
+	if err != nil {
+		t.Fatalf("AddOutputResources() = %v", err)
+	}
+	got = ApplyParameters(got, tr)
+	if len(got.Steps) != 1 {
+		t.Fatalf("Expected a single upload step, got %v", got.Steps)
+	}
+	if !strings.Contains(strings.Join(got.Steps[0].Args, " "), "-message Bump the version to 1.1") {
+		t.Errorf("Expected the message to be expanded in the args of the upload step, got %v", got.Steps[0].Args)
+	}
+}
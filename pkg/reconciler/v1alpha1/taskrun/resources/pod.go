@@ -227,8 +227,9 @@ func TryGetPod(taskRunStatus v1alpha1.TaskRunStatus, gp GetPod) (*corev1.Pod, er
 }
 
 // MakePod converts TaskRun and TaskSpec objects to a Pod which implements the taskrun specified
-// by the supplied CRD.
-func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient kubernetes.Interface, cache *entrypoint.Cache, logger *zap.SugaredLogger) (*corev1.Pod, error) {
+// by the supplied CRD. Pod-level settings come from the TaskRun's PodTemplate,
+// with the fields it doesn't set taken from defaultPodTemplate.
+func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, defaultPodTemplate *v1alpha1.PodTemplate, kubeclient kubernetes.Interface, cache *entrypoint.Cache, logger *zap.SugaredLogger) (*corev1.Pod, error) {
 	cred, secrets, err := makeCredentialInitializer(taskRun.Spec.ServiceAccount, taskRun.Namespace, kubeclient)
 	if err != nil {
 		return nil, err
@@ -305,6 +306,11 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 		return nil, err
 	}
 
+	podTemplate := v1alpha1.MergePodTemplateWithDefault(taskRun.Spec.PodTemplate, defaultPodTemplate)
+	if podTemplate == nil {
+		podTemplate = &v1alpha1.PodTemplate{}
+	}
+
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			// We execute the build's pod in the same namespace as where the build was
@@ -331,6 +337,13 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 			NodeSelector:       taskRun.Spec.NodeSelector,
 			Tolerations:        taskRun.Spec.Tolerations,
 			Affinity:           taskRun.Spec.Affinity,
+			SecurityContext:    podTemplate.SecurityContext,
+			PriorityClassName:  podTemplate.PriorityClassName,
+			RuntimeClassName:   podTemplate.RuntimeClassName,
+			DNSPolicy:          podTemplate.DNSPolicy,
+			DNSConfig:          podTemplate.DNSConfig,
+			HostAliases:        podTemplate.HostAliases,
+			ImagePullSecrets:   podTemplate.ImagePullSecrets,
 		},
 	}, nil
 }
@@ -117,6 +117,7 @@ func TestTryGetPod(t *testing.T) {
 
 func TestMakePod(t *testing.T) {
 	names.TestingSeed()
+	runAsNonRoot := true
 
 	implicitVolumeMountsWithSecrets := append(implicitVolumeMounts, corev1.VolumeMount{
 		Name:      "secret-volume-multi-creds-9l9zj",
@@ -131,12 +132,13 @@ func TestMakePod(t *testing.T) {
 	defer func() { randReader = rand.Reader }()
 
 	for _, c := range []struct {
-		desc         string
-		trs          v1alpha1.TaskRunSpec
-		ts           v1alpha1.TaskSpec
-		bAnnotations map[string]string
-		want         *corev1.PodSpec
-		wantErr      error
+		desc               string
+		trs                v1alpha1.TaskRunSpec
+		ts                 v1alpha1.TaskSpec
+		defaultPodTemplate *v1alpha1.PodTemplate
+		bAnnotations       map[string]string
+		want               *corev1.PodSpec
+		wantErr            error
 	}{{
 		desc: "simple",
 		ts: v1alpha1.TaskSpec{
@@ -177,6 +179,58 @@ func TestMakePod(t *testing.T) {
 			},
 			Volumes: implicitVolumes,
 		},
+	}, {
+		desc: "with-pod-template",
+		ts: v1alpha1.TaskSpec{
+			Steps: []corev1.Container{{
+				Name:  "name",
+				Image: "image",
+			}},
+		},
+		trs: v1alpha1.TaskRunSpec{
+			PodTemplate: &v1alpha1.PodTemplate{
+				PriorityClassName: "high",
+				HostAliases:       []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry"}}},
+			},
+		},
+		defaultPodTemplate: &v1alpha1.PodTemplate{
+			PriorityClassName: "low",
+			SecurityContext:   &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+			ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "registry-creds"}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{{
+				Name:         containerPrefix + credsInit + "-9l9zj",
+				Image:        *credsImage,
+				Command:      []string{"/ko-app/creds-init"},
+				Args:         []string{},
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+			}},
+			Containers: []corev1.Container{{
+				Name:         "step-name",
+				Image:        "image",
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("0"),
+						corev1.ResourceMemory:           resource.MustParse("0"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+					},
+				},
+			},
+				nopContainer,
+			},
+			Volumes:           implicitVolumes,
+			PriorityClassName: "high",
+			SecurityContext:   &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+			HostAliases:       []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry"}}},
+			ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "registry-creds"}},
+		},
 	}, {
 		desc: "with-service-account",
 		ts: v1alpha1.TaskSpec{
@@ -382,7 +436,7 @@ func TestMakePod(t *testing.T) {
 				Spec: c.trs,
 			}
 			cache, _ := entrypoint.NewCache()
-			got, err := MakePod(tr, c.ts, cs, cache, logger)
+			got, err := MakePod(tr, c.ts, c.defaultPodTemplate, cs, cache, logger)
 			if err != c.wantErr {
 				t.Fatalf("MakePod: %v", err)
 			}
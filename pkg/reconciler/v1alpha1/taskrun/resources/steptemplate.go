@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/merge"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetStepTemplate is a function used to retrieve the spec of a StepTemplate
+// or ClusterStepTemplate.
+type GetStepTemplate func(ref v1alpha1.StepTemplateRef) (*v1alpha1.StepTemplateSpec, error)
+
+// ApplyStepTemplates returns a copy of spec with the step templates it
+// references merged into its steps. Templates are applied in the order they
+// are listed, so later templates override earlier ones, and settings on the
+// step itself override all of them.
+func ApplyStepTemplates(spec *v1alpha1.TaskSpec, getStepTemplate GetStepTemplate) (*v1alpha1.TaskSpec, error) {
+	if len(spec.StepTemplates) == 0 {
+		return spec, nil
+	}
+	spec = spec.DeepCopy()
+
+	templates := make([]*v1alpha1.StepTemplateSpec, len(spec.StepTemplates))
+	for i, ref := range spec.StepTemplates {
+		t, err := getStepTemplate(ref)
+		if err != nil {
+			return nil, xerrors.Errorf("couldn't retrieve %s %q: %w", ref.Kind, ref.Name, err)
+		}
+		templates[i] = t
+	}
+
+	for i, step := range spec.Steps {
+		var base *corev1.Container
+		for j, ref := range spec.StepTemplates {
+			if !ref.AppliesTo(step.Name) {
+				continue
+			}
+			c := templates[j].Container
+			if base != nil {
+				merged, err := merge.CombineStepsWithContainerTemplate(base, []corev1.Container{c})
+				if err != nil {
+					return nil, xerrors.Errorf("couldn't merge %s %q: %w", ref.Kind, ref.Name, err)
+				}
+				c = merged[0]
+			}
+			base = &c
+		}
+		merged, err := merge.CombineStepsWithContainerTemplate(base, []corev1.Container{step})
+		if err != nil {
+			return nil, xerrors.Errorf("couldn't merge step templates into step %q: %w", step.Name, err)
+		}
+		if merged[0].Image == "" {
+			return nil, xerrors.Errorf("step %q has no image after applying step templates", step.Name)
+		}
+		spec.Steps[i] = merged[0]
+	}
+	return spec, nil
+}
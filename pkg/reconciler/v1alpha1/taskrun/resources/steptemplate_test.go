@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyStepTemplates(t *testing.T) {
+	templates := map[string]*v1alpha1.StepTemplateSpec{
+		"go": {Container: corev1.Container{
+			Image:      "golang:1.12",
+			WorkingDir: "/workspace/src",
+			Env:        []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=vendor"}},
+		}},
+		"proxy": {Container: corev1.Container{
+			Env: []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "proxy:3128"}},
+		}},
+	}
+	getStepTemplate := func(ref v1alpha1.StepTemplateRef) (*v1alpha1.StepTemplateSpec, error) {
+		if t, ok := templates[ref.Name]; ok {
+			return t, nil
+		}
+		return nil, xerrors.New("not found")
+	}
+	spec := &v1alpha1.TaskSpec{
+		Steps: []corev1.Container{{
+			Name:    "build",
+			Command: []string{"go", "build"},
+			Env:     []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=readonly"}},
+		}, {
+			Name:  "push",
+			Image: "ko",
+		}},
+		StepTemplates: []v1alpha1.StepTemplateRef{{
+			Name:  "go",
+			Kind:  v1alpha1.ClusterStepTemplateKind,
+			Steps: []string{"build"},
+		}, {
+			Name: "proxy",
+		}},
+	}
+
+	got, err := ApplyStepTemplates(spec, getStepTemplate)
+	if err != nil {
+		t.Fatalf("Unexpected error applying step templates: %v", err)
+	}
+	expected := []corev1.Container{{
+		Name:       "build",
+		Image:      "golang:1.12",
+		Command:    []string{"go", "build"},
+		WorkingDir: "/workspace/src",
+		Env: []corev1.EnvVar{
+			{Name: "HTTPS_PROXY", Value: "proxy:3128"},
+			{Name: "GOFLAGS", Value: "-mod=readonly"},
+		},
+	}, {
+		Name:  "push",
+		Image: "ko",
+		Env:   []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "proxy:3128"}},
+	}}
+	if d := cmp.Diff(expected, got.Steps); d != "" {
+		t.Errorf("Steps did not match expected value, diff: %s", d)
+	}
+	if spec.Steps[0].Image != "" {
+		t.Errorf("Expected the original spec not to be modified")
+	}
+}
+
+func TestApplyStepTemplates_Errors(t *testing.T) {
+	getStepTemplate := func(ref v1alpha1.StepTemplateRef) (*v1alpha1.StepTemplateSpec, error) {
+		if ref.Name == "missing" {
+			return nil, xerrors.New("not found")
+		}
+		return &v1alpha1.StepTemplateSpec{}, nil
+	}
+	for _, tc := range []struct {
+		name string
+		spec *v1alpha1.TaskSpec
+	}{{
+		name: "missing template",
+		spec: &v1alpha1.TaskSpec{
+			Steps:         []corev1.Container{{Name: "step", Image: "image"}},
+			StepTemplates: []v1alpha1.StepTemplateRef{{Name: "missing"}},
+		},
+	}, {
+		name: "no image after merge",
+		spec: &v1alpha1.TaskSpec{
+			Steps:         []corev1.Container{{Name: "step"}},
+			StepTemplates: []v1alpha1.StepTemplateRef{{Name: "empty"}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ApplyStepTemplates(tc.spec, getStepTemplate); err == nil {
+				t.Errorf("Expected an error applying step templates")
+			}
+		})
+	}
+}
@@ -49,7 +49,7 @@ func ResolveTaskResources(ts *v1alpha1.TaskSpec, taskName string, inputs []v1alp
 	}
 
 	for _, r := range inputs {
-		rr, err := getResource(&r, gr)
+		rr, err := GetResourceFromBinding(&r, gr)
 		if err != nil {
 			return nil, xerrors.Errorf("couldn't retrieve referenced input PipelineResource %q: %w", r.ResourceRef.Name, err)
 		}
@@ -58,7 +58,7 @@ func ResolveTaskResources(ts *v1alpha1.TaskSpec, taskName string, inputs []v1alp
 	}
 
 	for _, r := range outputs {
-		rr, err := getResource(&r, gr)
+		rr, err := GetResourceFromBinding(&r, gr)
 
 		if err != nil {
 			return nil, xerrors.Errorf("couldn't retrieve referenced output PipelineResource %q: %w", r.ResourceRef.Name, err)
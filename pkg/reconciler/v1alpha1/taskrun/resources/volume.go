@@ -17,6 +17,9 @@ limitations under the License.
 package resources
 
 import (
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -31,6 +34,26 @@ func getPvcMount(name string) corev1.VolumeMount {
 	}
 }
 
+// getPvcSubPathMount returns the mount of the artifact stored at path in the
+// pipelinerun pvc at mountPath, used instead of copying it.
+func getPvcSubPathMount(name, path, mountPath string, readOnly bool) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      name,
+		MountPath: mountPath,
+		SubPath:   strings.TrimPrefix(strings.TrimPrefix(path, pvcDir), "/"),
+		ReadOnly:  readOnly,
+	}
+}
+
+// addContainerTemplateVolumeMount mounts vm in every step of taskSpec,
+// including the ones added after it, through its container template.
+func addContainerTemplateVolumeMount(taskSpec *v1alpha1.TaskSpec, vm corev1.VolumeMount) {
+	if taskSpec.ContainerTemplate == nil {
+		taskSpec.ContainerTemplate = &corev1.Container{}
+	}
+	taskSpec.ContainerTemplate.VolumeMounts = append(taskSpec.ContainerTemplate.VolumeMounts, vm)
+}
+
 // GetPVCVolume gets pipelinerun pvc volume
 func GetPVCVolume(name string) corev1.Volume {
 	return corev1.Volume{
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/knative/pkg/apis"
+	"github.com/knative/pkg/configmap"
 	"github.com/knative/pkg/controller"
 	"github.com/knative/pkg/tracker"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -31,6 +32,7 @@ import (
 	informers "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1alpha1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/config"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
 	"go.uber.org/zap"
@@ -61,6 +63,9 @@ const (
 	// is just starting to be reconciled
 	reasonRunning = "Running"
 
+	// reasonPaused indicates that the TaskRun is paused at a debug breakpoint
+	reasonPaused = "Paused"
+
 	// reasonTimedOut indicates that the TaskRun has taken longer than its configured timeout
 	reasonTimedOut = "TaskRunTimeout"
 
@@ -80,20 +85,37 @@ const (
 	// imageDigestExporterContainerName defines the name of the container that will collect the
 	// built images digest
 	imageDigestExporterContainerName = "step-image-digest-exporter"
+
+	// cloudEventTimeout is how long the targets of cloud event outputs have to
+	// accept a CloudEvent.
+	cloudEventTimeout = 10 * time.Second
 )
 
+type configStore interface {
+	ToContext(ctx context.Context) context.Context
+	WatchConfigs(w configmap.Watcher)
+}
+
 // Reconciler implements controller.Reconciler for Configuration resources.
 type Reconciler struct {
 	*reconciler.Base
 
 	// listers index properties about resources
-	taskRunLister     listers.TaskRunLister
-	taskLister        listers.TaskLister
-	clusterTaskLister listers.ClusterTaskLister
-	resourceLister    listers.PipelineResourceLister
-	tracker           tracker.Interface
-	cache             *entrypoint.Cache
-	timeoutHandler    *reconciler.TimeoutSet
+	taskRunLister             listers.TaskRunLister
+	taskLister                listers.TaskLister
+	clusterTaskLister         listers.ClusterTaskLister
+	stepTemplateLister        listers.StepTemplateLister
+	clusterStepTemplateLister listers.ClusterStepTemplateLister
+	resourceLister            listers.PipelineResourceLister
+	resourceTypeLister        listers.ResourceTypeLister
+	tracker                   tracker.Interface
+	cache                     *entrypoint.Cache
+	configStore               configStore
+	timeoutHandler            *reconciler.TimeoutSet
+	cloudEventSender          *CloudEventSender
+	// pinStepImages is whether the images of steps are resolved to digests
+	// before creating pods.
+	pinStepImages bool
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -105,19 +127,29 @@ func NewController(
 	taskRunInformer informers.TaskRunInformer,
 	taskInformer informers.TaskInformer,
 	clusterTaskInformer informers.ClusterTaskInformer,
+	stepTemplateInformer informers.StepTemplateInformer,
+	clusterStepTemplateInformer informers.ClusterStepTemplateInformer,
 	resourceInformer informers.PipelineResourceInformer,
+	resourceTypeInformer informers.ResourceTypeInformer,
 	podInformer coreinformers.PodInformer,
 	entrypointCache *entrypoint.Cache,
+	pinStepImages bool,
 	timeoutHandler *reconciler.TimeoutSet,
+	cloudEventSender *CloudEventSender,
 ) *controller.Impl {
 
 	c := &Reconciler{
-		Base:              reconciler.NewBase(opt, taskRunAgentName),
-		taskRunLister:     taskRunInformer.Lister(),
-		taskLister:        taskInformer.Lister(),
-		clusterTaskLister: clusterTaskInformer.Lister(),
-		resourceLister:    resourceInformer.Lister(),
-		timeoutHandler:    timeoutHandler,
+		Base:                      reconciler.NewBase(opt, taskRunAgentName),
+		taskRunLister:             taskRunInformer.Lister(),
+		taskLister:                taskInformer.Lister(),
+		clusterTaskLister:         clusterTaskInformer.Lister(),
+		stepTemplateLister:        stepTemplateInformer.Lister(),
+		clusterStepTemplateLister: clusterStepTemplateInformer.Lister(),
+		resourceLister:            resourceInformer.Lister(),
+		resourceTypeLister:        resourceTypeInformer.Lister(),
+		timeoutHandler:            timeoutHandler,
+		cloudEventSender:          cloudEventSender,
+		pinStepImages:             pinStepImages,
 	}
 	impl := controller.NewImpl(c, c.Logger, taskRunControllerName, reconciler.MustNewStatsReporter(taskRunControllerName, c.Logger))
 
@@ -140,6 +172,9 @@ func NewController(
 		c.cache, _ = entrypoint.NewCache()
 	}
 
+	c.Logger.Info("Setting up ConfigMap receivers")
+	c.configStore = config.NewStore(c.Logger.Named("config-store"))
+	c.configStore.WatchConfigs(opt.ConfigMapWatcher)
 	return impl
 }
 
@@ -154,6 +189,8 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 		return nil
 	}
 
+	ctx = c.configStore.ToContext(ctx)
+
 	// Get the Task Run resource with this namespace/name
 	original, err := c.taskRunLister.TaskRuns(namespace).Get(name)
 	if errors.IsNotFound(err) {
@@ -179,6 +216,10 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 
 	if tr.IsDone() {
 		c.timeoutHandler.Release(tr)
+		// Notify the targets of the cloud event outputs that the TaskRun
+		// finished, in the background so that slow targets don't hold up
+		// the reconciliation of other TaskRuns.
+		c.cloudEventSender.Enqueue(tr)
 		return nil
 	}
 
@@ -231,7 +272,32 @@ func (c *Reconciler) getTaskFunc(tr *v1alpha1.TaskRun) resources.GetTask {
 	return gtFunc
 }
 
+func (c *Reconciler) getStepTemplateFunc(tr *v1alpha1.TaskRun) resources.GetStepTemplate {
+	return func(ref v1alpha1.StepTemplateRef) (*v1alpha1.StepTemplateSpec, error) {
+		if ref.Kind == v1alpha1.ClusterStepTemplateKind {
+			t, err := c.clusterStepTemplateLister.Get(ref.Name)
+			if err != nil {
+				return nil, err
+			}
+			return &t.Spec, nil
+		}
+		t, err := c.stepTemplateLister.StepTemplates(tr.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &t.Spec, nil
+	}
+}
+
+func (c *Reconciler) getResourceType(name string) (*v1alpha1.ResourceType, error) {
+	return c.resourceTypeLister.Get(name)
+}
+
 func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error {
+	// The deliveries are recorded first, so that the targets are notified of
+	// TaskRuns which are cancelled or fail to resolve too.
+	initializeCloudEvents(tr, c.resourceLister.PipelineResources(tr.Namespace).Get, c.Logger)
+
 	// If the taskrun is cancelled, kill resources and update status
 	if tr.IsCancelled() {
 		before := tr.Status.GetCondition(apis.ConditionSucceeded)
@@ -254,6 +320,18 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		return nil
 	}
 
+	taskSpec, err = resources.ApplyStepTemplates(taskSpec, c.getStepTemplateFunc(tr))
+	if err != nil {
+		c.Logger.Errorf("Failed to apply step templates for taskrun %s: %v", tr.Name, err)
+		tr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonFailedResolution,
+			Message: err.Error(),
+		})
+		return nil
+	}
+
 	// Propagate labels from Task to TaskRun.
 	if tr.ObjectMeta.Labels == nil {
 		tr.ObjectMeta.Labels = make(map[string]string, len(taskMeta.Labels)+1)
@@ -293,6 +371,21 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		return nil
 	}
 
+	var defaults []v1alpha1.TaskParam
+	if taskSpec.Inputs != nil {
+		defaults = taskSpec.Inputs.Params
+	}
+	if err := resources.ApplyResourceParameters(rtr, tr, defaults...); err != nil {
+		c.Logger.Errorf("Failed to resolve params of resources for taskrun %s: %v", tr.Name, err)
+		tr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonFailedValidation,
+			Message: err.Error(),
+		})
+		return nil
+	}
+
 	if err := ValidateResolvedTaskResources(tr.Spec.Inputs.Params, rtr); err != nil {
 		c.Logger.Errorf("Failed to validate taskrun %q: %v", tr.Name, err)
 		tr.Status.SetCondition(&apis.Condition{
@@ -311,7 +404,7 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		return err
 	}
 	if pod == nil {
-		pod, err = c.createPod(tr, rtr)
+		pod, err = c.createPod(ctx, tr, rtr)
 		if err != nil {
 			c.handlePodCreationError(tr, err)
 			return nil
@@ -401,9 +494,46 @@ func updateStatusFromPod(taskRun *v1alpha1.TaskRun, pod *corev1.Pod, resourceLis
 		taskRun.Status.CompletionTime = &metav1.Time{Time: time.Now()}
 	}
 
+	taskRun.Status.Debug = getDebugStatus(taskRun, pod)
+	if taskRun.Status.Debug != nil {
+		taskRun.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  reasonPaused,
+			Message: fmt.Sprintf("Step %q is paused at a breakpoint; to resume run: %s", taskRun.Status.Debug.Step, taskRun.Status.Debug.ContinueCommand),
+		})
+	}
+
 	updateTaskRunResourceResult(taskRun, pod, resourceLister, kubeclient, logger)
 }
 
+// getDebugStatus returns the step of the TaskRun's pod which is paused at a
+// breakpoint, or nil if no step is paused. A paused step is reported as ready
+// by the readiness probe added to steps which have breakpoints.
+func getDebugStatus(taskRun *v1alpha1.TaskRun, pod *corev1.Pod) *v1alpha1.TaskRunDebugStatus {
+	if taskRun.Spec.Debug == nil {
+		return nil
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if !s.Ready || s.State.Running == nil {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name != s.Name {
+				continue
+			}
+			if cont := entrypoint.GetContinueCommand(container); cont != nil {
+				return &v1alpha1.TaskRunDebugStatus{
+					Step:            resources.TrimContainerNamePrefix(s.Name),
+					AttachCommand:   fmt.Sprintf("kubectl -n %s exec -it %s -c %s -- sh", pod.Namespace, pod.Name, s.Name),
+					ContinueCommand: fmt.Sprintf("kubectl -n %s exec %s -c %s -- %s", pod.Namespace, pod.Name, s.Name, strings.Join(cont, " ")),
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (c *Reconciler) handlePodCreationError(tr *v1alpha1.TaskRun, err error) {
 	var reason, msg string
 	var succeededStatus corev1.ConditionStatus
@@ -435,6 +565,15 @@ func (c *Reconciler) handlePodCreationError(tr *v1alpha1.TaskRun, err error) {
 }
 
 func updateTaskRunResourceResult(taskRun *v1alpha1.TaskRun, pod *corev1.Pod, resourceLister listers.PipelineResourceLister, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) {
+	if err := resources.UpdateTaskRunStatusWithGitResults(taskRun, pod); err != nil {
+		logger.Errorf("Error getting the commits fetched by git-init for %s/%s: %s", taskRun.Name, taskRun.Namespace, err)
+	}
+	if err := resources.UpdateTaskRunStatusWithPushedImages(taskRun, pod); err != nil {
+		logger.Errorf("Error getting the digests of the images pushed for %s/%s: %s", taskRun.Name, taskRun.Namespace, err)
+	}
+	if err := resources.UpdateTaskRunStatusWithArtifacts(taskRun, pod); err != nil {
+		logger.Errorf("Error getting the checksums of the artifacts stored for %s/%s: %s", taskRun.Name, taskRun.Namespace, err)
+	}
 	if resources.TaskRunHasOutputImageResource(resourceLister.PipelineResources(taskRun.Namespace).Get, taskRun) && taskRun.IsSuccessful() {
 		for _, container := range pod.Spec.Containers {
 			if strings.HasPrefix(container.Name, imageDigestExporterContainerName) {
@@ -524,14 +663,14 @@ func (c *Reconciler) updateLabelsAndAnnotations(tr *v1alpha1.TaskRun) (*v1alpha1
 
 // createPod creates a Pod based on the Task's configuration, with pvcName as a volumeMount
 // TODO(dibyom): Refactor resource setup/templating logic to its own function in the resources package
-func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, rtr *resources.ResolvedTaskResources) (*corev1.Pod, error) {
+func (c *Reconciler) createPod(ctx context.Context, tr *v1alpha1.TaskRun, rtr *resources.ResolvedTaskResources) (*corev1.Pod, error) {
 	ts := rtr.TaskSpec.DeepCopy()
-	inputResources, err := resourceImplBinding(rtr.Inputs)
+	inputResources, err := resourceImplBinding(rtr.Inputs, c.getResourceType)
 	if err != nil {
 		c.Logger.Errorf("Failed to initialize input resources: %v", err)
 		return nil, err
 	}
-	outputResources, err := resourceImplBinding(rtr.Outputs)
+	outputResources, err := resourceImplBinding(rtr.Outputs, c.getResourceType)
 	if err != nil {
 		c.Logger.Errorf("Failed to initialize output resources: %v", err)
 		return nil, err
@@ -539,12 +678,20 @@ func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, rtr *resources.ResolvedTask
 
 	// Get actual resource
 
-	err = resources.AddOutputImageDigestExporter(tr, ts, c.resourceLister.PipelineResources(tr.Namespace).Get)
+	err = resources.AddOutputImageDigestExporter(tr, ts, rtr.Outputs)
 	if err != nil {
 		c.Logger.Errorf("Failed to create a build for taskrun: %s due to output image resource error %v", tr.Name, err)
 		return nil, err
 	}
 
+	// Caches are added first so that the input resources, which the keys of
+	// the caches may depend on, are fetched before they are restored.
+	ts, err = resources.AddCacheSteps(c.KubeClientSet, ts, tr, c.Logger)
+	if err != nil {
+		c.Logger.Errorf("Failed to create a build for taskrun: %s due to cache error %v", tr.Name, err)
+		return nil, err
+	}
+
 	ts, err = resources.AddInputResource(c.KubeClientSet, rtr.TaskName, ts, tr, inputResources, c.Logger)
 	if err != nil {
 		c.Logger.Errorf("Failed to create a build for taskrun: %s due to input resource error %v", tr.Name, err)
@@ -557,11 +704,6 @@ func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, rtr *resources.ResolvedTask
 		return nil, err
 	}
 
-	ts, err = createRedirectedTaskSpec(c.KubeClientSet, ts, tr, c.cache, c.Logger)
-	if err != nil {
-		return nil, xerrors.Errorf("couldn't create redirected TaskSpec: %w", err)
-	}
-
 	var defaults []v1alpha1.TaskParam
 	if ts.Inputs != nil {
 		defaults = append(defaults, ts.Inputs.Params...)
@@ -573,7 +715,21 @@ func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, rtr *resources.ResolvedTask
 	ts = resources.ApplyResources(ts, inputResources, "inputs")
 	ts = resources.ApplyResources(ts, outputResources, "outputs")
 
-	pod, err := resources.MakePod(tr, *ts, c.KubeClientSet, c.cache, c.Logger)
+	// The images are pinned before the steps are redirected, so that their
+	// entrypoints are looked up from the images the pod runs.
+	if c.pinStepImages {
+		if err := pinImages(c.KubeClientSet, ts, tr, c.cache); err != nil {
+			return nil, xerrors.Errorf("couldn't pin step images: %w", err)
+		}
+	}
+
+	ts, err = createRedirectedTaskSpec(c.KubeClientSet, ts, tr, c.cache, c.Logger)
+	if err != nil {
+		return nil, xerrors.Errorf("couldn't create redirected TaskSpec: %w", err)
+	}
+
+	defaultPodTemplate := config.FromContext(ctx).Defaults.DefaultPodTemplate
+	pod, err := resources.MakePod(tr, *ts, defaultPodTemplate, c.KubeClientSet, c.cache, c.Logger)
 	if err != nil {
 		return nil, xerrors.Errorf("translating Build to Pod: %w", err)
 	}
@@ -609,6 +765,34 @@ func createRedirectedTaskSpec(kubeclient kubernetes.Interface, ts *v1alpha1.Task
 	return ts, nil
 }
 
+// pinImages rewrites the images of the steps in ts to references by digest,
+// and records them in the TaskRun's status. Images pinned by a previous
+// attempt of the TaskRun are reused.
+func pinImages(kubeclient kubernetes.Interface, ts *v1alpha1.TaskSpec, tr *v1alpha1.TaskRun, cache *entrypoint.Cache) error {
+	pinned := make(map[string]string, len(tr.Status.ResolvedImages))
+	for _, ri := range tr.Status.ResolvedImages {
+		pinned[ri.Image] = ri.Reference
+	}
+	for i := range ts.Steps {
+		step := &ts.Steps[i]
+		ref, ok := pinned[step.Image]
+		if !ok {
+			var err error
+			ref, err = entrypoint.PinImage(cache, step.Image, kubeclient, tr)
+			if err != nil {
+				return xerrors.Errorf("failed to resolve image of step %q: %w", step.Name, err)
+			}
+			pinned[step.Image] = ref
+			tr.Status.ResolvedImages = append(tr.Status.ResolvedImages, v1alpha1.ResolvedImage{
+				Image:     step.Image,
+				Reference: ref,
+			})
+		}
+		step.Image = ref
+	}
+	return nil
+}
+
 type DeletePod func(podName string, options *metav1.DeleteOptions) error
 
 func (c *Reconciler) checkTimeout(tr *v1alpha1.TaskRun, ts *v1alpha1.TaskSpec, dp DeletePod) (bool, error) {
@@ -665,10 +849,10 @@ func getExceededResourcesMessage(tr *v1alpha1.TaskRun) string {
 }
 
 // resourceImplBinding maps pipeline resource names to the actual resource type implementations
-func resourceImplBinding(resources map[string]*v1alpha1.PipelineResource) (map[string]v1alpha1.PipelineResourceInterface, error) {
+func resourceImplBinding(resources map[string]*v1alpha1.PipelineResource, getResourceType v1alpha1.GetResourceType) (map[string]v1alpha1.PipelineResourceInterface, error) {
 	p := make(map[string]v1alpha1.PipelineResourceInterface)
 	for rName, r := range resources {
-		i, err := v1alpha1.ResourceFromType(r)
+		i, err := v1alpha1.ResourceFromType(r, getResourceType)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to create resource %s : %v with error: %w", rName, r, err)
 		}
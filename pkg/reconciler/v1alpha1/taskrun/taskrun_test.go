@@ -17,6 +17,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -47,6 +49,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sruntimeschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	ktesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
@@ -208,10 +211,15 @@ func getTaskRunController(t *testing.T, d test.Data) test.TestAssets {
 			i.TaskRun,
 			i.Task,
 			i.ClusterTask,
+			i.StepTemplate,
+			i.ClusterStepTemplate,
 			i.PipelineResource,
+			i.ResourceType,
 			i.Pod,
 			entrypointCache,
+			false,
 			th,
+			NewCloudEventSender(c.Pipeline, logger),
 		),
 		Logs:      logs,
 		Clients:   c,
@@ -468,7 +476,8 @@ func TestReconcile(t *testing.T) {
 				tb.PodContainer("step-git-source-git-resource-mssqb", "override-with-git:latest",
 					tb.Command(entrypointLocation),
 					tb.Args("-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "/ko-app/git-init", "--",
-						"-url", "https://foo.git", "-revision", "master", "-path", "/workspace/workspace"),
+						"-url", "https://foo.git", "-revision", "master", "-path", "/workspace/workspace",
+						"-name", "git-resource"),
 					tb.WorkingDir(workspaceDir),
 					tb.EnvVar("HOME", "/builder/home"),
 					tb.VolumeMount("tools", "/builder/tools"),
@@ -568,27 +577,12 @@ func TestReconcile(t *testing.T) {
 					},
 				}, toolsVolume, workspaceVolume, homeVolume),
 				tb.PodRestartPolicy(corev1.RestartPolicyNever),
-				getCredentialsInitContainer("vr6ds"),
+				getCredentialsInitContainer("78c5n"),
 				getPlaceToolsInitContainer(),
-				tb.PodContainer("step-create-dir-another-git-resource-78c5n", "override-with-bash-noop:latest",
-					tb.Command(entrypointLocation),
-					tb.Args("-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "/ko-app/bash", "--",
-						"-args", "mkdir -p /workspace/another-git-resource"),
-					tb.WorkingDir(workspaceDir),
-					tb.EnvVar("HOME", "/builder/home"),
-					tb.VolumeMount("tools", "/builder/tools"),
-					tb.VolumeMount("workspace", workspaceDir),
-					tb.VolumeMount("home", "/builder/home"),
-					tb.Resources(tb.Requests(
-						tb.CPU("0"),
-						tb.Memory("0"),
-						tb.EphemeralStorage("0"),
-					)),
-				),
-				tb.PodContainer("step-source-copy-another-git-resource-mssqb", "override-with-bash-noop:latest",
+				tb.PodContainer("step-artifact-copy-from-another-git-resource-mz4c7", "override-with-artifactcopy-image:latest",
 					tb.Command(entrypointLocation),
-					tb.Args("-wait_file", "/builder/tools/0", "-post_file", "/builder/tools/1", "-entrypoint", "/ko-app/bash", "--",
-						"-args", "cp -r source-folder/. /workspace/another-git-resource"),
+					tb.Args("-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "/ko-app/artifactcopy", "--",
+						"-operation", "download", "-name", "another-git-resource", "-path", "source-folder", "-location", "source-folder", "-dir", "/workspace/another-git-resource"),
 					tb.WorkingDir(workspaceDir),
 					tb.EnvVar("HOME", "/builder/home"),
 					tb.VolumeMount("test-pvc", "/pvc"),
@@ -601,25 +595,10 @@ func TestReconcile(t *testing.T) {
 						tb.EphemeralStorage("0"),
 					)),
 				),
-				tb.PodContainer("step-create-dir-git-resource-mz4c7", "override-with-bash-noop:latest",
+				tb.PodContainer("step-artifact-copy-from-git-resource-9l9zj", "override-with-artifactcopy-image:latest",
 					tb.Command(entrypointLocation),
-					tb.Args("-wait_file", "/builder/tools/1", "-post_file", "/builder/tools/2", "-entrypoint", "/ko-app/bash", "--",
-						"-args", "mkdir -p /workspace/git-resource"),
-					tb.WorkingDir(workspaceDir),
-					tb.EnvVar("HOME", "/builder/home"),
-					tb.VolumeMount("tools", "/builder/tools"),
-					tb.VolumeMount("workspace", workspaceDir),
-					tb.VolumeMount("home", "/builder/home"),
-					tb.Resources(tb.Requests(
-						tb.CPU("0"),
-						tb.Memory("0"),
-						tb.EphemeralStorage("0"),
-					)),
-				),
-				tb.PodContainer("step-source-copy-git-resource-9l9zj", "override-with-bash-noop:latest",
-					tb.Command(entrypointLocation),
-					tb.Args("-wait_file", "/builder/tools/2", "-post_file", "/builder/tools/3", "-entrypoint", "/ko-app/bash", "--",
-						"-args", "cp -r source-folder/. /workspace/git-resource"),
+					tb.Args("-wait_file", "/builder/tools/0", "-post_file", "/builder/tools/1", "-entrypoint", "/ko-app/artifactcopy", "--",
+						"-operation", "download", "-name", "git-resource", "-path", "source-folder", "-location", "source-folder", "-dir", "/workspace/git-resource"),
 					tb.WorkingDir(workspaceDir),
 					tb.EnvVar("HOME", "/builder/home"),
 					tb.VolumeMount("test-pvc", "/pvc"),
@@ -634,25 +613,9 @@ func TestReconcile(t *testing.T) {
 				),
 				tb.PodContainer("step-simple-step", "foo",
 					tb.Command(entrypointLocation),
-					tb.Args("-wait_file", "/builder/tools/3", "-post_file", "/builder/tools/4", "-entrypoint", "/mycmd", "--"),
-					tb.WorkingDir(workspaceDir),
-					tb.EnvVar("HOME", "/builder/home"),
-					tb.VolumeMount("tools", "/builder/tools"),
-					tb.VolumeMount("workspace", workspaceDir),
-					tb.VolumeMount("home", "/builder/home"),
-					tb.Resources(tb.Requests(
-						tb.CPU("0"),
-						tb.Memory("0"),
-						tb.EphemeralStorage("0"),
-					)),
-				),
-				tb.PodContainer("step-source-mkdir-git-resource-6nl7g", "override-with-bash-noop:latest",
-					tb.Command(entrypointLocation),
-					tb.Args("-wait_file", "/builder/tools/4", "-post_file", "/builder/tools/5", "-entrypoint", "/ko-app/bash", "--",
-						"-args", "mkdir -p output-folder"),
+					tb.Args("-wait_file", "/builder/tools/1", "-post_file", "/builder/tools/2", "-entrypoint", "/mycmd", "--"),
 					tb.WorkingDir(workspaceDir),
 					tb.EnvVar("HOME", "/builder/home"),
-					tb.VolumeMount("test-pvc", "/pvc"),
 					tb.VolumeMount("tools", "/builder/tools"),
 					tb.VolumeMount("workspace", workspaceDir),
 					tb.VolumeMount("home", "/builder/home"),
@@ -662,10 +625,10 @@ func TestReconcile(t *testing.T) {
 						tb.EphemeralStorage("0"),
 					)),
 				),
-				tb.PodContainer("step-source-copy-git-resource-j2tds", "override-with-bash-noop:latest",
+				tb.PodContainer("step-artifact-copy-to-git-resource-mssqb", "override-with-artifactcopy-image:latest",
 					tb.Command(entrypointLocation),
-					tb.Args("-wait_file", "/builder/tools/5", "-post_file", "/builder/tools/6", "-entrypoint", "/ko-app/bash", "--",
-						"-args", "cp -r /workspace/git-resource/. output-folder"),
+					tb.Args("-wait_file", "/builder/tools/2", "-post_file", "/builder/tools/3", "-entrypoint", "/ko-app/artifactcopy", "--",
+						"-operation", "upload", "-name", "git-resource", "-path", "output-folder", "-location", "output-folder", "-dir", "/workspace/git-resource"),
 					tb.WorkingDir(workspaceDir),
 					tb.EnvVar("HOME", "/builder/home"),
 					tb.VolumeMount("test-pvc", "/pvc"),
@@ -680,7 +643,7 @@ func TestReconcile(t *testing.T) {
 				),
 				tb.PodContainer("nop", "override-with-nop:latest",
 					tb.Command("/builder/tools/entrypoint"),
-					tb.Args("-wait_file", "/builder/tools/6", "-post_file", "/builder/tools/7", "-entrypoint", "/ko-app/nop", "--"),
+					tb.Args("-wait_file", "/builder/tools/3", "-post_file", "/builder/tools/4", "-entrypoint", "/ko-app/nop", "--"),
 					tb.VolumeMount(entrypoint.MountName, entrypoint.MountPoint),
 				),
 			),
@@ -701,7 +664,8 @@ func TestReconcile(t *testing.T) {
 				tb.PodContainer("step-git-source-git-resource-9l9zj", "override-with-git:latest",
 					tb.Command(entrypointLocation),
 					tb.Args("-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "/ko-app/git-init", "--",
-						"-url", "https://foo.git", "-revision", "master", "-path", "/workspace/workspace"),
+						"-url", "https://foo.git", "-revision", "master", "-path", "/workspace/workspace",
+						"-name", "git-resource"),
 					tb.WorkingDir(workspaceDir),
 					tb.EnvVar("HOME", "/builder/home"),
 					tb.VolumeMount("tools", "/builder/tools"),
@@ -787,7 +751,7 @@ func TestReconcile(t *testing.T) {
 					tb.Command(entrypointLocation),
 					tb.Args("-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "/ko-app/git-init", "--",
 						"-url", "github.com/foo/bar.git", "-revision", "rel-can", "-path",
-						"/workspace/workspace"),
+						"/workspace/workspace", "-name", "workspace"),
 					tb.WorkingDir(workspaceDir),
 					tb.EnvVar("HOME", "/builder/home"),
 					tb.VolumeMount("tools", "/builder/tools"),
@@ -1159,7 +1123,13 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 	withWrongRef := tb.TaskRun("taskrun-with-wrong-ref", "foo", tb.TaskRunSpec(
 		tb.TaskRunTaskRef("taskrun-with-wrong-ref", tb.TaskRefKind(v1alpha1.ClusterTaskKind)),
 	))
-	taskRuns := []*v1alpha1.TaskRun{noTaskRun, withWrongRef}
+	withMissingStepTemplate := tb.TaskRun("taskrun-with-missing-step-template", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskSpec(
+			tb.Step("simple-step", "foo", tb.Command("/mycmd")),
+			tb.TaskStepTemplate("missing"),
+		),
+	))
+	taskRuns := []*v1alpha1.TaskRun{noTaskRun, withWrongRef, withMissingStepTemplate}
 	tasks := []*v1alpha1.Task{simpleTask}
 
 	d := test.Data{
@@ -1182,6 +1152,11 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 			taskRun: withWrongRef,
 			reason:  reasonFailedResolution,
 		},
+		{
+			name:    "task run with missing step template",
+			taskRun: withMissingStepTemplate,
+			reason:  reasonFailedResolution,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1212,6 +1187,169 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 
 }
 
+func TestReconcileWithStepTemplates(t *testing.T) {
+	stepTemplate := &v1alpha1.StepTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "go", Namespace: "foo"},
+		Spec: v1alpha1.StepTemplateSpec{Container: corev1.Container{
+			Image:      "golang",
+			WorkingDir: "/workspace/src",
+		}},
+	}
+	clusterStepTemplate := &v1alpha1.ClusterStepTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "proxy"},
+		Spec: v1alpha1.StepTemplateSpec{Container: corev1.Container{
+			Env: []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "proxy:3128"}},
+		}},
+	}
+	taskRun := tb.TaskRun("test-taskrun-step-templates", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskSpec(
+			tb.Step("build", "", tb.Command("/mycmd")),
+			tb.TaskStepTemplate("go", tb.StepTemplateRefSteps("build")),
+			tb.TaskStepTemplate("proxy", tb.StepTemplateRefKind(v1alpha1.ClusterStepTemplateKind)),
+		),
+	))
+	d := test.Data{
+		TaskRuns:             []*v1alpha1.TaskRun{taskRun},
+		StepTemplates:        []*v1alpha1.StepTemplate{stepTemplate},
+		ClusterStepTemplates: []*v1alpha1.ClusterStepTemplate{clusterStepTemplate},
+	}
+	testAssets := getTaskRunController(t, d)
+	clients := testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ServiceAccounts(taskRun.Namespace).Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: taskRun.Namespace,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+	tr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	pod, err := clients.Kube.CoreV1().Pods(tr.Namespace).Get(tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fetch build pod: %v", err)
+	}
+	var step *corev1.Container
+	for i, c := range pod.Spec.Containers {
+		if c.Name == "step-build" {
+			step = &pod.Spec.Containers[i]
+		}
+	}
+	if step == nil {
+		t.Fatalf("Expected pod to have a container for step build, got %v", pod.Spec.Containers)
+	}
+	if step.Image != "golang" || step.WorkingDir != "/workspace/src" {
+		t.Errorf("Expected step to inherit image and workingDir from StepTemplate, got %q and %q", step.Image, step.WorkingDir)
+	}
+	found := false
+	for _, e := range step.Env {
+		if e.Name == "HTTPS_PROXY" && e.Value == "proxy:3128" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected step to inherit env from ClusterStepTemplate, got %v", step.Env)
+	}
+}
+
+func TestReconcileWithCustomResourceType(t *testing.T) {
+	resourceType := &v1alpha1.ResourceType{
+		ObjectMeta: metav1.ObjectMeta{Name: "helm.example.com"},
+		Spec: v1alpha1.ResourceTypeSpec{
+			Params: []v1alpha1.ResourceTypeParam{{Name: "chart"}},
+			Download: []corev1.Container{{
+				Name:    "pull",
+				Image:   "alpine/helm",
+				Command: []string{"helm"},
+				Args:    []string{"pull", "${resource.chart}", "--untardir", "${resource.path}"},
+			}},
+		},
+	}
+	chart := tb.PipelineResource("my-chart", "foo", tb.PipelineResourceSpec(
+		"helm.example.com",
+		tb.PipelineResourceSpecParam("chart", "stable/mysql"),
+	))
+	taskRun := tb.TaskRun("test-taskrun-custom-resource", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskSpec(
+			tb.TaskInputs(tb.InputsResource("chart", "helm.example.com")),
+			tb.Step("install", "alpine/helm", tb.Command("helm"), tb.Args("install", "${inputs.resources.chart.path}")),
+		),
+		tb.TaskRunInputs(tb.TaskRunInputsResource("chart", tb.TaskResourceBindingRef("my-chart"))),
+	))
+	d := test.Data{
+		TaskRuns:          []*v1alpha1.TaskRun{taskRun},
+		PipelineResources: []*v1alpha1.PipelineResource{chart},
+		ResourceTypes:     []*v1alpha1.ResourceType{resourceType},
+	}
+	testAssets := getTaskRunController(t, d)
+	clients := testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ServiceAccounts(taskRun.Namespace).Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: taskRun.Namespace,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+	tr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	pod, err := clients.Kube.CoreV1().Pods(tr.Namespace).Get(tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fetch build pod: %v", err)
+	}
+	var pull *corev1.Container
+	for i, c := range pod.Spec.Containers {
+		if strings.HasPrefix(c.Name, "step-fetch-my-chart-pull-") {
+			pull = &pod.Spec.Containers[i]
+		}
+	}
+	if pull == nil {
+		t.Fatalf("Expected pod to have a container for the download step of the ResourceType, got %v", pod.Spec.Containers)
+	}
+	// The arguments are passed to the entrypoint after the command.
+	if d := cmp.Diff([]string{"pull", "stable/mysql", "--untardir", "/workspace/chart"}, pull.Args[len(pull.Args)-4:]); d != "" {
+		t.Errorf("Expected the params of the resource to be replaced in the step, diff -want, +got: %s", d)
+	}
+}
+
+func TestReconcileWithMissingResourceType(t *testing.T) {
+	chart := tb.PipelineResource("my-chart", "foo", tb.PipelineResourceSpec("helm.example.com"))
+	taskRun := tb.TaskRun("test-taskrun-missing-resource-type", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskSpec(
+			tb.TaskInputs(tb.InputsResource("chart", "helm.example.com")),
+			tb.Step("install", "alpine/helm", tb.Command("helm")),
+		),
+		tb.TaskRunInputs(tb.TaskRunInputsResource("chart", tb.TaskResourceBindingRef("my-chart"))),
+	))
+	d := test.Data{
+		TaskRuns:          []*v1alpha1.TaskRun{taskRun},
+		PipelineResources: []*v1alpha1.PipelineResource{chart},
+	}
+	testAssets := getTaskRunController(t, d)
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Errorf("Did not expect to see error when reconciling invalid TaskRun but saw %q", err)
+	}
+	condition := taskRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse {
+		t.Fatalf("Expected TaskRun to have failed status, but had %v", condition)
+	}
+	if !strings.Contains(condition.Message, `"helm.example.com" not found`) {
+		t.Errorf("Expected the failure to be because of the missing ResourceType, got %q", condition.Message)
+	}
+}
+
 func TestReconcilePodFetchError(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-run-success", "foo",
 		tb.TaskRunSpec(tb.TaskRunTaskRef("test-task")),
@@ -1251,7 +1389,7 @@ func TestReconcilePodUpdateStatus(t *testing.T) {
 	// specify the Pod we want to exist directly, and not call MakePod from
 	// the build. This will break the cycle and allow us to simply use
 	// clients normally.
-	pod, err := resources.MakePod(taskRun, simpleTask.Spec, fakekubeclientset.NewSimpleClientset(&corev1.ServiceAccount{
+	pod, err := resources.MakePod(taskRun, simpleTask.Spec, nil, fakekubeclientset.NewSimpleClientset(&corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "default",
 			Namespace: taskRun.Namespace,
@@ -1340,6 +1478,96 @@ func TestCreateRedirectedTaskSpec(t *testing.T) {
 	}
 }
 
+type fakeImageResolver struct{ resolved []string }
+
+func (f *fakeImageResolver) Resolve(image string, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun) (*entrypoint.Image, error) {
+	f.resolved = append(f.resolved, image)
+	return &entrypoint.Image{Digest: "sha256:" + strings.Repeat("1", 64)}, nil
+}
+
+func TestPinImages(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("1", 64)
+	previous := "gcr.io/foo/baz@sha256:" + strings.Repeat("2", 64)
+	pinned := "gcr.io/foo/qux@sha256:" + strings.Repeat("3", 64)
+	tr := tb.TaskRun("tr", "foo")
+	// A previous attempt of the TaskRun already pinned gcr.io/foo/baz:v1.
+	tr.Status.ResolvedImages = []v1alpha1.ResolvedImage{{
+		Image:     "gcr.io/foo/baz:v1",
+		Reference: previous,
+	}}
+	task := tb.Task("tr-ts", "foo", tb.TaskSpec(
+		tb.Step("build", "gcr.io/foo/bar:latest"),
+		tb.Step("test", "gcr.io/foo/bar:latest"),
+		tb.Step("push", "gcr.io/foo/baz:v1"),
+		tb.Step("deploy", pinned),
+	))
+
+	resolver := &fakeImageResolver{}
+	entrypointCache, _ := entrypoint.NewCache(entrypoint.WithResolver(resolver))
+	if err := pinImages(fakekubeclientset.NewSimpleClientset(), &task.Spec, tr, entrypointCache); err != nil {
+		t.Fatalf("pinImages() = %v", err)
+	}
+
+	var gotImages []string
+	for _, s := range task.Spec.Steps {
+		gotImages = append(gotImages, s.Image)
+	}
+	wantImages := []string{"gcr.io/foo/bar@" + digest, "gcr.io/foo/bar@" + digest, previous, pinned}
+	if d := cmp.Diff(wantImages, gotImages); d != "" {
+		t.Errorf("step images diff -want, +got: %s", d)
+	}
+	if d := cmp.Diff([]string{"gcr.io/foo/bar:latest"}, resolver.resolved); d != "" {
+		t.Errorf("resolved images diff -want, +got: %s", d)
+	}
+	wantStatus := []v1alpha1.ResolvedImage{{
+		Image:     "gcr.io/foo/baz:v1",
+		Reference: previous,
+	}, {
+		Image:     "gcr.io/foo/bar:latest",
+		Reference: "gcr.io/foo/bar@" + digest,
+	}, {
+		Image:     pinned,
+		Reference: pinned,
+	}}
+	if d := cmp.Diff(wantStatus, tr.Status.ResolvedImages); d != "" {
+		t.Errorf("resolved images status diff -want, +got: %s", d)
+	}
+}
+
+func TestReconcilePinsImagesBeforeRedirectingSteps(t *testing.T) {
+	previous := "gcr.io/foo/bar@sha256:" + strings.Repeat("2", 64)
+	task := tb.Task("test-pinned-task", "foo", tb.TaskSpec(
+		tb.Step("build", "gcr.io/foo/bar:latest"),
+	))
+	taskRun := tb.TaskRun("test-taskrun-pinned", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef(task.Name),
+	))
+	// A previous attempt of the TaskRun pinned the image, which was pushed
+	// again since.
+	taskRun.Status.ResolvedImages = []v1alpha1.ResolvedImage{{
+		Image:     "gcr.io/foo/bar:latest",
+		Reference: previous,
+	}}
+	d := test.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{task},
+	}
+	testAssets := getTaskRunController(t, d)
+	resolver := &fakeImageResolver{}
+	r := testAssets.Controller.Reconciler.(*Reconciler)
+	r.cache, _ = entrypoint.NewCache(entrypoint.WithResolver(resolver))
+	r.pinStepImages = true
+
+	if err := r.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when reconciling TaskRun: %v", err)
+	}
+	// The entrypoint of the step is looked up from the pinned image.
+	want := []string{previous}
+	if d := cmp.Diff(want, resolver.resolved); d != "" {
+		t.Errorf("resolved images diff -want, +got: %s", d)
+	}
+}
+
 func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	taskSt := &apis.Condition{
 		Type:    apis.ConditionSucceeded,
@@ -1373,6 +1601,117 @@ func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	}
 }
 
+func TestReconcileOnCompletedTaskRunSendsCloudEvents(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	taskRun := tb.TaskRun("test-taskrun-run-success", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef(simpleTask.Name),
+	), tb.TaskRunStatus(tb.Condition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+	})))
+	taskRun.Status.CloudEvents = []v1alpha1.CloudEventDelivery{{
+		Target: server.URL,
+		Status: v1alpha1.CloudEventDeliveryState{Condition: v1alpha1.CloudEventConditionUnknown},
+	}}
+	d := test.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{simpleTask},
+	}
+
+	testAssets := getTaskRunController(t, d)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when reconciling completed TaskRun : %v", err)
+	}
+	// The CloudEvent is sent by the sender rather than by the reconciler.
+	if received != 0 {
+		t.Errorf("Expected the CloudEvent not to be sent while reconciling, got %d", received)
+	}
+	sender := c.Reconciler.(*Reconciler).cloudEventSender
+	if sender.queue.Len() != 1 {
+		t.Fatalf("Expected the TaskRun to be queued for delivery, got %d queued", sender.queue.Len())
+	}
+	sender.processNextItem()
+
+	newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected completed TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if received != 1 {
+		t.Errorf("Expected the CloudEvent to be received once, got %d", received)
+	}
+	if got := newTr.Status.CloudEvents[0].Status; got.Condition != v1alpha1.CloudEventConditionSent || got.SentAt == nil {
+		t.Errorf("Expected the CloudEvent delivery to be recorded as sent, got %+v", got)
+	}
+
+	// Once delivered, the CloudEvent isn't queued again by later reconciles.
+	if err := testAssets.Informers.TaskRun.Informer().GetIndexer().Update(newTr); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when reconciling completed TaskRun : %v", err)
+	}
+	if sender.queue.Len() != 0 {
+		t.Errorf("Expected the delivered CloudEvent not to be queued again, got %d queued", sender.queue.Len())
+	}
+}
+
+func TestReconcileInvalidTaskRunRecordsCloudEvents(t *testing.T) {
+	notify := tb.PipelineResource("notify", "foo", tb.PipelineResourceSpec(
+		v1alpha1.PipelineResourceTypeCloudEvent,
+		tb.PipelineResourceSpecParam("targetURI", "http://sink.example.com"),
+	))
+	taskRun := tb.TaskRun("test-taskrun-no-task", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("notask"),
+		tb.TaskRunOutputs(tb.TaskRunOutputsResource("notify", tb.TaskResourceBindingRef(notify.Name))),
+	))
+	d := test.Data{
+		TaskRuns:          []*v1alpha1.TaskRun{taskRun},
+		PipelineResources: []*v1alpha1.PipelineResource{notify},
+	}
+
+	testAssets := getTaskRunController(t, d)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling invalid TaskRun but saw %q", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if condition := newTr.Status.GetCondition(apis.ConditionSucceeded); condition == nil || condition.Reason != reasonFailedResolution {
+		t.Fatalf("Expected the TaskRun to fail to resolve, got %v", condition)
+	}
+	want := []v1alpha1.CloudEventDelivery{{
+		Target: "http://sink.example.com",
+		Status: v1alpha1.CloudEventDeliveryState{Condition: v1alpha1.CloudEventConditionUnknown},
+	}}
+	if d := cmp.Diff(want, newTr.Status.CloudEvents); d != "" {
+		t.Fatalf("-want, +got: %v", d)
+	}
+
+	// The failed TaskRun is queued for delivery like any finished one.
+	if err := testAssets.Informers.TaskRun.Informer().GetIndexer().Update(newTr); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when reconciling failed TaskRun: %v", err)
+	}
+	if sender := c.Reconciler.(*Reconciler).cloudEventSender; sender.queue.Len() != 1 {
+		t.Errorf("Expected the TaskRun to be queued for delivery, got %d queued", sender.queue.Len())
+	}
+}
+
 func TestReconcileOnCancelledTaskRun(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-run-cancelled", "foo", tb.TaskRunSpec(
 		tb.TaskRunTaskRef(simpleTask.Name),
@@ -1533,6 +1872,36 @@ func TestUpdateStatusFromPod(t *testing.T) {
 				Name: "state-name",
 			}},
 		},
+	}, {
+		desc: "git-source-commit",
+		podStatus: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-git-source-repo-9l9zj",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"name":"repo","digest":"","commit":"c15aced0e5aaee6456fbe6f7a7e95e0b5b3b2b2f","ref":"master","url":"https://github.com/tektoncd/pipeline"}]`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionRunning},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"name":"repo","digest":"","commit":"c15aced0e5aaee6456fbe6f7a7e95e0b5b3b2b2f","ref":"master","url":"https://github.com/tektoncd/pipeline"}]`,
+					}},
+				Name: "git-source-repo-9l9zj",
+			}},
+			ResourcesResult: []v1alpha1.PipelineResourceResult{{
+				Name:   "repo",
+				Commit: "c15aced0e5aaee6456fbe6f7a7e95e0b5b3b2b2f",
+				Ref:    "master",
+				URL:    "https://github.com/tektoncd/pipeline",
+			}},
+		},
 	}, {
 		desc: "ignore-init-containers",
 		podStatus: corev1.PodStatus{
@@ -1845,6 +2214,60 @@ func TestUpdateStatusFromPod(t *testing.T) {
 	}
 }
 
+func TestUpdateStatusFromPodPausedAtBreakpoint(t *testing.T) {
+	observer, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(observer).Sugar()
+	fakeClient := fakeclientset.NewSimpleClientset()
+	resourceLister := informers.NewSharedInformerFactory(fakeClient, 0).Tekton().V1alpha1().PipelineResources().Lister()
+
+	probe := &corev1.Probe{
+		Handler: corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{entrypoint.BinaryLocation, "-paused", "-post_file", "/builder/tools/1"},
+			},
+		},
+	}
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "step-build",
+			}, {
+				Name:           "step-push",
+				ReadinessProbe: probe,
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-build",
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, {
+				Name:  "step-push",
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+	tr := tb.TaskRun("taskRun", "foo", tb.TaskRunSpec(
+		tb.TaskRunDebug(v1alpha1.BreakpointOnFailure),
+	))
+	updateStatusFromPod(tr, p, resourceLister, fakekubeclientset.NewSimpleClientset(), logger)
+
+	want := &v1alpha1.TaskRunDebugStatus{
+		Step:            "push",
+		AttachCommand:   "kubectl -n foo exec -it pod -c step-push -- sh",
+		ContinueCommand: "kubectl -n foo exec pod -c step-push -- /builder/tools/entrypoint -continue -post_file /builder/tools/1",
+	}
+	if d := cmp.Diff(want, tr.Status.Debug); d != "" {
+		t.Errorf("Debug status diff -want, +got: %s", d)
+	}
+	if reason := tr.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != reasonPaused {
+		t.Errorf("expected reason %q, got %q", reasonPaused, reason)
+	}
+}
+
 func TestHandlePodCreationError(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-pod-creation-failed", "foo", tb.TaskRunSpec(
 		tb.TaskRunTaskRef(simpleTask.Name),
@@ -0,0 +1,263 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3 implements the small subset of the S3 API needed to move
+// PipelineResources and artifacts in and out of S3-compatible object stores
+// such as MinIO.
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// DefaultRegion is used when no region is configured. S3-compatible
+	// stores like MinIO accept it regardless of where they run.
+	DefaultRegion = "us-east-1"
+
+	// Environment variables holding the credentials used to sign requests.
+	AccessKeyIDEnvVar     = "AWS_ACCESS_KEY_ID"
+	SecretAccessKeyEnvVar = "AWS_SECRET_ACCESS_KEY"
+	SessionTokenEnvVar    = "AWS_SESSION_TOKEN"
+)
+
+// Config describes how to reach a bucket of an S3-compatible object store.
+type Config struct {
+	// Endpoint is the URL of the object store, e.g. http://minio:9000. If
+	// empty, the AWS endpoint of Region is used.
+	Endpoint string
+	// Region the bucket lives in. Defaults to DefaultRegion.
+	Region string
+	// Bucket is the name of the bucket.
+	Bucket string
+	// PathStyle addresses the bucket as a path of the endpoint instead of
+	// as a subdomain, which is what most self-hosted stores expect.
+	PathStyle bool
+	// AccessKeyID, SecretAccessKey and SessionToken are used to sign the
+	// requests. If AccessKeyID is empty, requests are sent anonymously.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// WithEnvCredentials returns a copy of c with the credentials read from the
+// standard AWS environment variables.
+func (c Config) WithEnvCredentials() Config {
+	c.AccessKeyID = os.Getenv(AccessKeyIDEnvVar)
+	c.SecretAccessKey = os.Getenv(SecretAccessKeyEnvVar)
+	c.SessionToken = os.Getenv(SessionTokenEnvVar)
+	return c
+}
+
+// Client reads and writes objects of a single bucket.
+type Client struct {
+	cfg        Config
+	endpoint   *url.URL
+	signer     *v4.Signer
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client for the bucket described by cfg.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Bucket == "" {
+		return nil, xerrors.New("s3: a bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = DefaultRegion
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.Region)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, xerrors.Errorf("s3: invalid endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, xerrors.Errorf("s3: endpoint %q must be an absolute URL", endpoint)
+	}
+	c := &Client{cfg: cfg, endpoint: u, HTTPClient: http.DefaultClient}
+	if cfg.AccessKeyID != "" {
+		c.signer = v4.NewSigner(credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken), func(s *v4.Signer) {
+			// S3 expects the path to be escaped only once.
+			s.DisableURIPathEscaping = true
+		})
+	}
+	return c, nil
+}
+
+// ParseLocation splits a location of the form s3://bucket/key into its
+// bucket and key.
+func ParseLocation(location string) (string, string, error) {
+	if !strings.HasPrefix(location, "s3://") {
+		return "", "", xerrors.Errorf("s3: location %q must start with s3://", location)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(location, "s3://"), "/", 2)
+	if parts[0] == "" {
+		return "", "", xerrors.Errorf("s3: location %q has no bucket", location)
+	}
+	key := ""
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+	return parts[0], key, nil
+}
+
+// GetObject writes the content of the object at key to w.
+func (c *Client) GetObject(key string, w io.Writer) error {
+	resp, err := c.do(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return xerrors.Errorf("s3: reading object %q: %w", key, err)
+	}
+	return nil
+}
+
+// PutObject stores the content of body at key.
+func (c *Client) PutObject(key string, body io.ReadSeeker) error {
+	resp, err := c.do(http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// DeleteObject deletes the object at key. Deleting a missing object is not
+// an error.
+func (c *Client) DeleteObject(key string) error {
+	resp, err := c.do(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects returns the keys of all the objects whose key starts with
+// prefix.
+func (c *Client) ListObjects(prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := c.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, xerrors.Errorf("s3: decoding object list: %w", err)
+		}
+		for _, o := range result.Contents {
+			keys = append(keys, o.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (c *Client) objectURL(key string, query url.Values) *url.URL {
+	u := *c.endpoint
+	path := "/" + key
+	if c.cfg.PathStyle {
+		path = "/" + c.cfg.Bucket + path
+	} else {
+		u.Host = c.cfg.Bucket + "." + u.Host
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawPath = escapePath(u.Path)
+	u.RawQuery = strings.Replace(query.Encode(), "+", "%20", -1)
+	return &u
+}
+
+func (c *Client) do(method, key string, query url.Values, body io.ReadSeeker) (*http.Response, error) {
+	u := c.objectURL(key, query)
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return nil, xerrors.Errorf("s3: creating request: %w", err)
+	}
+	if body != nil {
+		size, err := body.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, xerrors.Errorf("s3: sizing request body: %w", err)
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, xerrors.Errorf("s3: rewinding request body: %w", err)
+		}
+		req.ContentLength = size
+		req.Body = ioutil.NopCloser(body)
+	}
+	if c.signer != nil {
+		if _, err := c.signer.Sign(req, body, "s3", c.cfg.Region, time.Now()); err != nil {
+			return nil, xerrors.Errorf("s3: signing request: %w", err)
+		}
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("s3: %s %s: %w", method, u.Path, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, xerrors.Errorf("s3: %s %s: unexpected status %s: %s", method, u.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// escapePath escapes every byte of path outside the unreserved set of RFC
+// 3986 except for the separators, as required by the S3 signature.
+func escapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		ch := path[i]
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '.' || ch == '_' || ch == '~' || ch == '/' {
+			b.WriteByte(ch)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
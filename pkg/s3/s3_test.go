@@ -0,0 +1,199 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeStore is a minimal in-memory S3 server for a single path-style bucket.
+type fakeStore struct {
+	sync.Mutex
+	bucket  string
+	objects map[string][]byte
+	// requireAuth rejects requests that are not signed.
+	requireAuth bool
+}
+
+func (f *fakeStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if f.requireAuth && !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+	prefix := "/" + f.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path+"/", prefix) {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+	switch {
+	case r.Method == http.MethodPut:
+		b, _ := ioutil.ReadAll(r.Body)
+		f.objects[key] = b
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		var result listBucketResult
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result.Contents = append(result.Contents, struct {
+				Key string `xml:"Key"`
+			}{k})
+		}
+		xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodGet:
+		b, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "NotImplemented", http.StatusNotImplemented)
+	}
+}
+
+func newFakeServer(t *testing.T) (*fakeStore, *Client, func()) {
+	t.Helper()
+	store := &fakeStore{bucket: "bucket", objects: map[string][]byte{}, requireAuth: true}
+	server := httptest.NewServer(store)
+	c, err := NewClient(Config{
+		Endpoint:        server.URL,
+		Bucket:          "bucket",
+		PathStyle:       true,
+		AccessKeyID:     "minio",
+		SecretAccessKey: "minio123",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating client: %v", err)
+	}
+	return store, c, server.Close
+}
+
+func TestClient(t *testing.T) {
+	store, c, closeServer := newFakeServer(t)
+	defer closeServer()
+
+	if err := c.PutObject("dir/a file.txt", bytes.NewReader([]byte("hello"))); err != nil {
+		t.Fatalf("PutObject() = %v", err)
+	}
+	if d := cmp.Diff("hello", string(store.objects["dir/a file.txt"])); d != "" {
+		t.Errorf("Stored object diff -want, +got: %s", d)
+	}
+
+	var buf bytes.Buffer
+	if err := c.GetObject("dir/a file.txt", &buf); err != nil {
+		t.Fatalf("GetObject() = %v", err)
+	}
+	if buf.String() != "hello" {
+		t.Errorf("GetObject() got %q, want %q", buf.String(), "hello")
+	}
+
+	keys, err := c.ListObjects("dir/")
+	if err != nil {
+		t.Fatalf("ListObjects() = %v", err)
+	}
+	if d := cmp.Diff([]string{"dir/a file.txt"}, keys); d != "" {
+		t.Errorf("ListObjects() diff -want, +got: %s", d)
+	}
+
+	if err := c.GetObject("missing", &buf); err == nil {
+		t.Error("Expected an error getting a missing object")
+	}
+}
+
+func TestClientAnonymous(t *testing.T) {
+	_, c, closeServer := newFakeServer(t)
+	defer closeServer()
+	c.signer = nil
+
+	if err := c.PutObject("key", bytes.NewReader(nil)); err == nil {
+		t.Error("Expected unsigned request to be rejected")
+	}
+}
+
+func TestObjectURL(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  Config
+		key  string
+		want string
+	}{{
+		name: "path style",
+		cfg:  Config{Endpoint: "http://minio:9000", Bucket: "bucket", PathStyle: true},
+		key:  "a/b c.txt",
+		want: "http://minio:9000/bucket/a/b%20c.txt",
+	}, {
+		name: "virtual host",
+		cfg:  Config{Bucket: "bucket", Region: "eu-west-1"},
+		key:  "a/b+c.txt",
+		want: "https://bucket.s3.eu-west-1.amazonaws.com/a/b%2Bc.txt",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClient(tc.cfg)
+			if err != nil {
+				t.Fatalf("NewClient() = %v", err)
+			}
+			if got := c.objectURL(tc.key, nil).String(); got != tc.want {
+				t.Errorf("objectURL() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	for _, tc := range []struct {
+		location   string
+		wantBucket string
+		wantKey    string
+		wantErr    bool
+	}{
+		{location: "s3://bucket/path/to/obj", wantBucket: "bucket", wantKey: "path/to/obj"},
+		{location: "s3://bucket", wantBucket: "bucket"},
+		{location: "gs://bucket/obj", wantErr: true},
+		{location: "s3:///obj", wantErr: true},
+	} {
+		t.Run(tc.location, func(t *testing.T) {
+			bucket, key, err := ParseLocation(tc.location)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseLocation() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if bucket != tc.wantBucket || key != tc.wantKey {
+				t.Errorf("ParseLocation() got (%q, %q), want (%q, %q)", bucket, key, tc.wantBucket, tc.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Download copies the object at key into the directory dest. If dir is
+// true, every object under the key prefix is copied instead, keeping its
+// path relative to the prefix.
+func Download(c *Client, key, dest string, dir bool) error {
+	if !dir {
+		p, err := objectPath(dest, path.Base(key))
+		if err != nil {
+			return err
+		}
+		return downloadFile(c, key, p)
+	}
+	prefix := dirPrefix(key)
+	keys, err := c.ListObjects(prefix)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if strings.HasSuffix(k, "/") {
+			continue
+		}
+		p, err := objectPath(dest, strings.TrimPrefix(k, prefix))
+		if err != nil {
+			return err
+		}
+		if err := downloadFile(c, k, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upload is the reverse of Download: it stores the file of src named after
+// key at key or, if dir is true, every file under src below the key prefix.
+func Upload(c *Client, src, key string, dir bool) error {
+	if !dir {
+		return uploadFile(c, filepath.Join(src, path.Base(key)), key)
+	}
+	prefix := dirPrefix(key)
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		return uploadFile(c, p, prefix+filepath.ToSlash(rel))
+	})
+}
+
+// Delete deletes the object at key or, if dir is true, every object under
+// the key prefix.
+func Delete(c *Client, key string, dir bool) error {
+	if !dir {
+		return c.DeleteObject(key)
+	}
+	keys, err := c.ListObjects(dirPrefix(key))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := c.DeleteObject(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dirPrefix(key string) string {
+	if key == "" || strings.HasSuffix(key, "/") {
+		return key
+	}
+	return key + "/"
+}
+
+// objectPath returns the path the object named name relative to the
+// downloaded prefix is written to, refusing names which would escape dest.
+func objectPath(dest, name string) (string, error) {
+	p := filepath.Join(dest, filepath.FromSlash(name))
+	if !strings.HasPrefix(p, filepath.Clean(dest)+string(os.PathSeparator)) {
+		return "", xerrors.Errorf("object %q is outside of the destination directory", name)
+	}
+	return p, nil
+}
+
+func downloadFile(c *Client, key, p string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return xerrors.Errorf("creating directory for %s: %w", p, err)
+	}
+	f, err := os.Create(p)
+	if err != nil {
+		return xerrors.Errorf("creating %s: %w", p, err)
+	}
+	if err := c.GetObject(key, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func uploadFile(c *Client, p, key string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return xerrors.Errorf("opening %s: %w", p, err)
+	}
+	defer f.Close()
+	return c.PutObject(key, f)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUploadDownloadDir(t *testing.T) {
+	store, c, closeServer := newFakeServer(t)
+	defer closeServer()
+
+	src, err := ioutil.TempDir("", "s3-src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{"a.txt": "a", "sub/b.txt": "b"}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(src, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Upload(c, src, "prefix", true); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	want := map[string][]byte{"prefix/a.txt": []byte("a"), "prefix/sub/b.txt": []byte("b")}
+	if d := cmp.Diff(want, store.objects); d != "" {
+		t.Errorf("Uploaded objects diff -want, +got: %s", d)
+	}
+
+	dest, err := ioutil.TempDir("", "s3-dest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dest)
+	if err := Download(c, "prefix", dest, true); err != nil {
+		t.Fatalf("Download() = %v", err)
+	}
+	for name, content := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dest, name))
+		if err != nil {
+			t.Fatalf("Expected %s to be downloaded: %v", name, err)
+		}
+		if string(b) != content {
+			t.Errorf("Downloaded %s got %q, want %q", name, b, content)
+		}
+	}
+}
+
+func TestDownloadDirOutsideDestination(t *testing.T) {
+	store, c, closeServer := newFakeServer(t)
+	defer closeServer()
+	store.objects["prefix/../../escaped.txt"] = []byte("escaped")
+
+	parent, err := ioutil.TempDir("", "s3-parent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(parent)
+	dest := filepath.Join(parent, "a", "dest")
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Download(c, "prefix", dest, true); err == nil {
+		t.Error("Expected an error downloading an object outside of the destination")
+	}
+	if _, err := os.Stat(filepath.Join(parent, "escaped.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected no file to be written outside of the destination but got %v", err)
+	}
+}
+
+func TestUploadDownloadFile(t *testing.T) {
+	store, c, closeServer := newFakeServer(t)
+	defer closeServer()
+	store.objects["path/rules.zip"] = []byte("zip")
+
+	dir, err := ioutil.TempDir("", "s3-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := Download(c, "path/rules.zip", dir, false); err != nil {
+		t.Fatalf("Download() = %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "rules.zip"), []byte("updated"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Upload(c, dir, "path/rules.zip", false); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	if got := string(store.objects["path/rules.zip"]); got != "updated" {
+		t.Errorf("Uploaded object got %q, want %q", got, "updated")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	store, c, closeServer := newFakeServer(t)
+	defer closeServer()
+	store.objects["prefix/a.txt"] = []byte("a")
+	store.objects["prefix/sub/b.txt"] = []byte("b")
+	store.objects["prefix-other/c.txt"] = []byte("c")
+	store.objects["rules.zip"] = []byte("zip")
+
+	if err := Delete(c, "prefix", true); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	if err := Delete(c, "rules.zip", false); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	want := map[string][]byte{"prefix-other/c.txt": []byte("c")}
+	if d := cmp.Diff(want, store.objects); d != "" {
+		t.Errorf("Remaining objects diff -want, +got: %s", d)
+	}
+	if err := Delete(c, "prefix", true); err != nil {
+		t.Errorf("Expected deleting a missing prefix to succeed but got %v", err)
+	}
+}
@@ -93,6 +93,14 @@ func PipelineCreationTimestamp(t time.Time) PipelineOp {
 	}
 }
 
+// PipelineRunRetention sets the retention of the completed PipelineRuns to the
+// PipelineSpec.
+func PipelineRunRetention(retention *v1alpha1.RunRetention) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		ps.RunRetention = retention
+	}
+}
+
 // PipelineRunCancelled sets the status to cancel to the TaskRunSpec.
 func PipelineRunCancelled(spec *v1alpha1.PipelineRunSpec) {
 	spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
@@ -303,6 +311,20 @@ func PipelineResourceBindingRef(name string) PipelineResourceBindingOp {
 	}
 }
 
+// PipelineResourceBindingResourceSpec embeds a PipelineResourceSpec, with specified type, in the
+// PipelineResourceBinding instead of referencing a PipelineResource.
+// Any number of PipelineResourceSpec modifier can be passed to transform it.
+func PipelineResourceBindingResourceSpec(resourceType v1alpha1.PipelineResourceType, ops ...PipelineResourceSpecOp) PipelineResourceBindingOp {
+	return func(b *v1alpha1.PipelineResourceBinding) {
+		spec := &v1alpha1.PipelineResourceSpec{Type: resourceType}
+		for _, op := range ops {
+			op(spec)
+		}
+		b.ResourceRef = v1alpha1.PipelineResourceRef{}
+		b.ResourceSpec = spec
+	}
+}
+
 // PipelineRunServiceAccount sets the service account to the PipelineRunSpec.
 func PipelineRunServiceAccount(sa string) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
@@ -327,6 +349,13 @@ func PipelineRunTimeout(duration *metav1.Duration) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunTTL sets the TTL to the PipelineRunSpec.
+func PipelineRunTTL(ttl *metav1.Duration) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.TTL = ttl
+	}
+}
+
 // PipelineRunNodeSelector sets the Node selector to the PipelineSpec.
 func PipelineRunNodeSelector(values map[string]string) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
@@ -348,6 +377,13 @@ func PipelineRunAffinity(affinity *corev1.Affinity) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunPodTemplate sets the PodTemplate to the PipelineRunSpec.
+func PipelineRunPodTemplate(podTemplate *v1alpha1.PodTemplate) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.PodTemplate = podTemplate
+	}
+}
+
 // PipelineRunStatus sets the PipelineRunStatus to the PipelineRun.
 // Any number of PipelineRunStatus modifier can be passed to transform it.
 func PipelineRunStatus(ops ...PipelineRunStatusOp) PipelineRunOp {
@@ -74,6 +74,9 @@ type StepStateOp func(*v1alpha1.StepState)
 // VolumeOp is an operation which modify a Volume struct.
 type VolumeOp func(*corev1.Volume)
 
+// StepTemplateRefOp is an operation which modify a StepTemplateRef struct.
+type StepTemplateRefOp func(*v1alpha1.StepTemplateRef)
+
 var (
 	trueB = true
 )
@@ -165,6 +168,32 @@ func TaskContainerTemplate(ops ...ContainerOp) TaskSpecOp {
 	}
 }
 
+// TaskStepTemplate adds a reference to a StepTemplate to the TaskSpec.
+// Any number of StepTemplateRef modifier can be passed to transform it.
+func TaskStepTemplate(name string, ops ...StepTemplateRefOp) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		ref := v1alpha1.StepTemplateRef{Name: name}
+		for _, op := range ops {
+			op(&ref)
+		}
+		spec.StepTemplates = append(spec.StepTemplates, ref)
+	}
+}
+
+// StepTemplateRefKind sets the kind of the referenced step template.
+func StepTemplateRefKind(kind v1alpha1.StepTemplateKind) StepTemplateRefOp {
+	return func(ref *v1alpha1.StepTemplateRef) {
+		ref.Kind = kind
+	}
+}
+
+// StepTemplateRefSteps restricts the referenced step template to the named steps.
+func StepTemplateRefSteps(steps ...string) StepTemplateRefOp {
+	return func(ref *v1alpha1.StepTemplateRef) {
+		ref.Steps = steps
+	}
+}
+
 // TaskVolume adds a volume with specified name to the TaskSpec.
 // Any number of Volume modifier can be passed to transform it.
 func TaskVolume(name string, ops ...VolumeOp) TaskSpecOp {
@@ -356,6 +385,20 @@ func TaskRunAffinity(affinity *corev1.Affinity) TaskRunSpecOp {
 	}
 }
 
+// TaskRunPodTemplate sets the PodTemplate to the TaskRunSpec.
+func TaskRunPodTemplate(podTemplate *v1alpha1.PodTemplate) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.PodTemplate = podTemplate
+	}
+}
+
+// TaskRunDebug sets the debug breakpoints to the TaskRunSpec.
+func TaskRunDebug(breakpoints ...string) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.Debug = &v1alpha1.TaskRunDebug{Breakpoints: breakpoints}
+	}
+}
+
 // StateTerminated set Terminated to the StepState.
 func StateTerminated(exitcode int) StepStateOp {
 	return func(s *v1alpha1.StepState) {
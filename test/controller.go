@@ -41,14 +41,17 @@ func GetLogMessages(logs *observer.ObservedLogs) []string {
 // Data represents the desired state of the system (i.e. existing resources) to seed controllers
 // with.
 type Data struct {
-	PipelineRuns      []*v1alpha1.PipelineRun
-	Pipelines         []*v1alpha1.Pipeline
-	TaskRuns          []*v1alpha1.TaskRun
-	Tasks             []*v1alpha1.Task
-	ClusterTasks      []*v1alpha1.ClusterTask
-	PipelineResources []*v1alpha1.PipelineResource
-	Pods              []*corev1.Pod
-	Namespaces        []*corev1.Namespace
+	PipelineRuns         []*v1alpha1.PipelineRun
+	Pipelines            []*v1alpha1.Pipeline
+	TaskRuns             []*v1alpha1.TaskRun
+	Tasks                []*v1alpha1.Task
+	ClusterTasks         []*v1alpha1.ClusterTask
+	StepTemplates        []*v1alpha1.StepTemplate
+	ClusterStepTemplates []*v1alpha1.ClusterStepTemplate
+	PipelineResources    []*v1alpha1.PipelineResource
+	ResourceTypes        []*v1alpha1.ResourceType
+	Pods                 []*corev1.Pod
+	Namespaces           []*corev1.Namespace
 }
 
 // Clients holds references to clients which are useful for reconciler tests.
@@ -59,13 +62,16 @@ type Clients struct {
 
 // Informers holds references to informers which are useful for reconciler tests.
 type Informers struct {
-	PipelineRun      informersv1alpha1.PipelineRunInformer
-	Pipeline         informersv1alpha1.PipelineInformer
-	TaskRun          informersv1alpha1.TaskRunInformer
-	Task             informersv1alpha1.TaskInformer
-	ClusterTask      informersv1alpha1.ClusterTaskInformer
-	PipelineResource informersv1alpha1.PipelineResourceInformer
-	Pod              coreinformers.PodInformer
+	PipelineRun         informersv1alpha1.PipelineRunInformer
+	Pipeline            informersv1alpha1.PipelineInformer
+	TaskRun             informersv1alpha1.TaskRunInformer
+	Task                informersv1alpha1.TaskInformer
+	ClusterTask         informersv1alpha1.ClusterTaskInformer
+	StepTemplate        informersv1alpha1.StepTemplateInformer
+	ClusterStepTemplate informersv1alpha1.ClusterStepTemplateInformer
+	PipelineResource    informersv1alpha1.PipelineResourceInformer
+	ResourceType        informersv1alpha1.ResourceTypeInformer
+	Pod                 coreinformers.PodInformer
 }
 
 // TestAssets holds references to the controller, logs, clients, and informers.
@@ -95,6 +101,15 @@ func SeedTestData(t *testing.T, d Data) (Clients, Informers) {
 	for _, ct := range d.ClusterTasks {
 		objs = append(objs, ct)
 	}
+	for _, st := range d.StepTemplates {
+		objs = append(objs, st)
+	}
+	for _, cst := range d.ClusterStepTemplates {
+		objs = append(objs, cst)
+	}
+	for _, rt := range d.ResourceTypes {
+		objs = append(objs, rt)
+	}
 	for _, tr := range d.TaskRuns {
 		objs = append(objs, tr)
 	}
@@ -114,13 +129,16 @@ func SeedTestData(t *testing.T, d Data) (Clients, Informers) {
 	kubeInformer := kubeinformers.NewSharedInformerFactory(c.Kube, 0)
 
 	i := Informers{
-		PipelineRun:      sharedInformer.Tekton().V1alpha1().PipelineRuns(),
-		Pipeline:         sharedInformer.Tekton().V1alpha1().Pipelines(),
-		TaskRun:          sharedInformer.Tekton().V1alpha1().TaskRuns(),
-		Task:             sharedInformer.Tekton().V1alpha1().Tasks(),
-		ClusterTask:      sharedInformer.Tekton().V1alpha1().ClusterTasks(),
-		PipelineResource: sharedInformer.Tekton().V1alpha1().PipelineResources(),
-		Pod:              kubeInformer.Core().V1().Pods(),
+		PipelineRun:         sharedInformer.Tekton().V1alpha1().PipelineRuns(),
+		Pipeline:            sharedInformer.Tekton().V1alpha1().Pipelines(),
+		TaskRun:             sharedInformer.Tekton().V1alpha1().TaskRuns(),
+		Task:                sharedInformer.Tekton().V1alpha1().Tasks(),
+		ClusterTask:         sharedInformer.Tekton().V1alpha1().ClusterTasks(),
+		StepTemplate:        sharedInformer.Tekton().V1alpha1().StepTemplates(),
+		ClusterStepTemplate: sharedInformer.Tekton().V1alpha1().ClusterStepTemplates(),
+		PipelineResource:    sharedInformer.Tekton().V1alpha1().PipelineResources(),
+		ResourceType:        sharedInformer.Tekton().V1alpha1().ResourceTypes(),
+		Pod:                 kubeInformer.Core().V1().Pods(),
 	}
 
 	for _, pr := range d.PipelineRuns {
@@ -148,11 +166,26 @@ func SeedTestData(t *testing.T, d Data) (Clients, Informers) {
 			t.Fatal(err)
 		}
 	}
+	for _, st := range d.StepTemplates {
+		if err := i.StepTemplate.Informer().GetIndexer().Add(st); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, cst := range d.ClusterStepTemplates {
+		if err := i.ClusterStepTemplate.Informer().GetIndexer().Add(cst); err != nil {
+			t.Fatal(err)
+		}
+	}
 	for _, r := range d.PipelineResources {
 		if err := i.PipelineResource.Informer().GetIndexer().Add(r); err != nil {
 			t.Fatal(err)
 		}
 	}
+	for _, rt := range d.ResourceTypes {
+		if err := i.ResourceType.Informer().GetIndexer().Add(rt); err != nil {
+			t.Fatal(err)
+		}
+	}
 	for _, p := range d.Pods {
 		if err := i.Pod.Informer().GetIndexer().Add(p); err != nil {
 			t.Fatal(err)